@@ -0,0 +1,66 @@
+// Package represents main application for administration service client.
+//
+//nolint:gochecknoglobals // build inline variable
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/devldavydov/gophkeeper/internal/admin"
+	"github.com/devldavydov/gophkeeper/internal/common/info"
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
+	pb "github.com/devldavydov/gophkeeper/internal/grpc"
+)
+
+var (
+	buildVersion string
+	buildDate    string
+	buildCommit  string
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	appVer := info.FormatVersion(buildVersion, buildDate, buildCommit)
+
+	config, err := LoadConfig(*flag.CommandLine, os.Args[1:])
+	if err != nil {
+		return fmt.Errorf("failed to load config file, ENV and flag settings: %w", err)
+	}
+
+	if config.Version {
+		fmt.Println(appVer)
+		return nil
+	}
+
+	logger, err := gkLog.NewLogger(config.LogLevel)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	settings, err := AdminSettingsAdapt(config)
+	if err != nil {
+		return fmt.Errorf("failed to create admin settings: %w", err)
+	}
+
+	conn, err := admin.Dial(settings)
+	if err != nil {
+		return fmt.Errorf("failed to connect admin service: %w", err)
+	}
+	defer conn.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	defer stop()
+
+	return admin.NewCLI(pb.NewAdminServiceClient(conn), os.Stdout, os.Stderr, logger).Run(ctx, config.Args)
+}
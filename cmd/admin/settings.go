@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/devldavydov/gophkeeper/internal/admin"
+	gkConfig "github.com/devldavydov/gophkeeper/internal/common/config"
+	"github.com/devldavydov/gophkeeper/internal/common/nettools"
+)
+
+var errInvalidSettings = errors.New("invalid settings")
+
+const (
+	_defaultConfigFile          = ""
+	_defaultConfigServerAddress = "127.0.0.1:8081"
+	_defaultConfigCACert        = ""
+	_defaultConfigCert          = ""
+	_defaultConfigKey           = ""
+	_defaultConfigLogin         = ""
+	_defaultConfigPasswordFile  = ""
+	_defaultConfigLogLevel      = "WARN"
+	_defaultConfigVersion       = false
+)
+
+// Config is a config file/env/command line administration client configuration options.
+// Precedence of sources: defaults < config file < env < flags.
+type Config struct {
+	// ConfigFile - YAML config file, not used if empty.
+	// env: "CONFIG", flag: "config".
+	ConfigFile string `env:"CONFIG" yaml:"-"`
+
+	// ServerAddress - address of administration service to connect.
+	// yaml: "admin_address", env: "ADMIN_ADDRESS", flag: "a".
+	ServerAddress string `env:"ADMIN_ADDRESS" yaml:"admin_address"`
+
+	// CACert - TLS Certification Authority certificate file.
+	// yaml: "tls_ca_cert", env: "TLS_CA_CERT", flag: "tlscacert".
+	CACert string `env:"TLS_CA_CERT" yaml:"tls_ca_cert"`
+
+	// Cert - TLS administrator client certificate file.
+	// yaml: "tls_cert", env: "TLS_CLIENT_CERT", flag: "tlscert".
+	Cert string `env:"TLS_CLIENT_CERT" yaml:"tls_cert"`
+
+	// Key - TLS administrator client certificate key file.
+	// yaml: "tls_key", env: "TLS_CLIENT_KEY", flag: "tlskey".
+	Key string `env:"TLS_CLIENT_KEY" yaml:"tls_key"`
+
+	// Login - administrator login.
+	// yaml: "admin_login", env: "ADMIN_LOGIN", flag: "u".
+	Login string `env:"ADMIN_LOGIN" yaml:"admin_login"`
+
+	// Password - administrator password, only from env to keep it out of shell history.
+	// env: "ADMIN_PASSWORD".
+	Password string `env:"ADMIN_PASSWORD" yaml:"-"`
+
+	// PasswordFile - file with administrator password, overrides Password.
+	// yaml: "admin_password_file", env: "ADMIN_PASSWORD_FILE", flag: "pfile".
+	PasswordFile string `env:"ADMIN_PASSWORD_FILE" yaml:"admin_password_file"`
+
+	// LogLevel - logging level, logs are written to stderr.
+	// yaml: "log_level", env: "LOG_LEVEL", flag: "l".
+	LogLevel string `env:"LOG_LEVEL" yaml:"log_level"`
+
+	// Version - bool flag to show only version
+	// flag: "version"
+	Version bool `yaml:"-"`
+
+	// Args - command and its arguments.
+	Args []string `yaml:"-"`
+}
+
+// LoadConfig loads administration client configuration from config file/env/flags.
+// Administrator password is read from file, if "ADMIN_PASSWORD_FILE" is set.
+func LoadConfig(flagSet flag.FlagSet, flags []string) (*Config, error) {
+	var err error
+	config := &Config{}
+
+	// Flags
+	flagSet.StringVar(&config.ConfigFile, "config", _defaultConfigFile, "YAML config file")
+	flagSet.StringVar(&config.ServerAddress, "a", _defaultConfigServerAddress, "admin service address")
+	flagSet.StringVar(&config.CACert, "tlscacert", _defaultConfigCACert, "CA certificate")
+	flagSet.StringVar(&config.Cert, "tlscert", _defaultConfigCert, "admin client certificate")
+	flagSet.StringVar(&config.Key, "tlskey", _defaultConfigKey, "admin client certificate key")
+	flagSet.StringVar(&config.Login, "u", _defaultConfigLogin, "admin login")
+	flagSet.StringVar(&config.PasswordFile, "pfile", _defaultConfigPasswordFile, "file with admin password")
+	flagSet.StringVar(&config.LogLevel, "l", _defaultConfigLogLevel, "log level")
+	flagSet.BoolVar(&config.Version, "version", _defaultConfigVersion, "show version only")
+
+	flagSet.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of %s: [flags] command [arguments]\n", os.Args[0])
+		flagSet.PrintDefaults()
+		fmt.Fprintln(os.Stderr, "Commands:")
+		fmt.Fprintln(os.Stderr, "  users")
+		fmt.Fprintln(os.Stderr, "  disable <login>")
+		fmt.Fprintln(os.Stderr, "  enable <login>")
+		fmt.Fprintln(os.Stderr, "  logout <login>")
+		fmt.Fprintln(os.Stderr, "  stats <login>")
+		fmt.Fprintln(os.Stderr, "  quota [-bytes N] [-secrets N] <login> | quota -reset <login>")
+		fmt.Fprintln(os.Stderr, "  delete -yes <login>")
+	}
+
+	if err = gkConfig.Load(&flagSet, flags, config, &config.ConfigFile, nil); err != nil {
+		return nil, err
+	}
+	config.Args = flagSet.Args()
+
+	if err = gkConfig.ReadSecretFile(&config.Password, config.PasswordFile, "admin_password_file"); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// AdminSettingsAdapt adapts file/env/flag configuration to administration client settings internal format.
+// Returns gkConfig.FieldError with name of invalid setting in case of invalid configuration.
+func AdminSettingsAdapt(config *Config) (*admin.Settings, error) {
+	serverAddress, err := nettools.NewAddress(config.ServerAddress)
+	if err != nil {
+		return nil, invalidSetting("admin_address", err)
+	}
+
+	if config.CACert == "" {
+		return nil, invalidSetting("tls_ca_cert", errors.New("must not be empty"))
+	}
+
+	if (config.Cert == "") != (config.Key == "") {
+		return nil, invalidSetting("tls_cert", errors.New("tls_cert and tls_key must be set together"))
+	}
+
+	settings, err := admin.NewSettings(
+		serverAddress,
+		config.CACert,
+		config.Cert,
+		config.Key,
+		config.Login,
+		config.Password,
+	)
+	if err != nil {
+		return nil, invalidSetting("admin_login", err)
+	}
+
+	return settings, nil
+}
+
+func invalidSetting(field string, err error) error {
+	return &gkConfig.FieldError{Field: field, Err: fmt.Errorf("%w: %v", errInvalidSettings, err)}
+}
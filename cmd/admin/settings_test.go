@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gkConfig "github.com/devldavydov/gophkeeper/internal/common/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminSettingsAdaptFromEnv(t *testing.T) {
+	t.Setenv("ADMIN_ADDRESS", "127.0.0.1:9091")
+	t.Setenv("TLS_CA_CERT", "/tmp/ca.cert")
+	t.Setenv("ADMIN_LOGIN", "admin")
+	t.Setenv("ADMIN_PASSWORD", "secret")
+
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{"users"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users"}, config.Args)
+
+	settings, err := AdminSettingsAdapt(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, "127.0.0.1:9091", settings.ServerAddress.String())
+	assert.Equal(t, "/tmp/ca.cert", settings.TLSCACertPath)
+	assert.Equal(t, "admin", settings.Login)
+	assert.Equal(t, "secret", settings.Password)
+}
+
+func TestAdminSettingsAdaptFromFlag(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("secret\n"), 0600))
+
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{
+		"-tlscacert", "/tmp/ca.cert",
+		"-tlscert", "/tmp/admin.cert",
+		"-tlskey", "/tmp/admin.key",
+		"-u", "admin",
+		"-pfile", passwordFile,
+		"stats", "alice",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stats", "alice"}, config.Args)
+
+	settings, err := AdminSettingsAdapt(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, "127.0.0.1:8081", settings.ServerAddress.String())
+	assert.Equal(t, "/tmp/admin.cert", settings.TLSCertPath)
+	assert.Equal(t, "/tmp/admin.key", settings.TLSKeyPath)
+	assert.Equal(t, "secret", settings.Password)
+}
+
+func TestAdminSettingsAdaptFieldError(t *testing.T) {
+	for _, tt := range []struct {
+		flags []string
+		field string
+	}{
+		{flags: []string{"-a", "", "-tlscacert", "/tmp/ca.cert", "-u", "admin"}, field: "admin_address"},
+		{flags: []string{"-u", "admin"}, field: "tls_ca_cert"},
+		{flags: []string{"-tlscacert", "/tmp/ca.cert", "-tlscert", "/tmp/admin.cert"}, field: "tls_cert"},
+		{flags: []string{"-tlscacert", "/tmp/ca.cert"}, field: "admin_login"},
+	} {
+		tt := tt
+		t.Run(tt.field, func(t *testing.T) {
+			testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+			config, err := LoadConfig(*testFlagSet, tt.flags)
+			require.NoError(t, err)
+
+			_, err = AdminSettingsAdapt(config)
+			var fieldErr *gkConfig.FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tt.field, fieldErr.Field)
+			assert.ErrorIs(t, err, errInvalidSettings)
+		})
+	}
+}
@@ -5,14 +5,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 
 	"github.com/devldavydov/gophkeeper/internal/client"
+	"github.com/devldavydov/gophkeeper/internal/client/cli"
 	"github.com/devldavydov/gophkeeper/internal/common/info"
 	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
 )
@@ -25,6 +29,11 @@ var (
 
 func main() {
 	if err := run(); err != nil {
+		// Exit with code of process, started by "run" command
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			os.Exit(exitErr.ExitCode())
+		}
 		log.Fatal(err)
 	}
 }
@@ -32,9 +41,15 @@ func main() {
 func run() error {
 	appVer := info.FormatVersion(buildVersion, buildDate, buildCommit)
 
-	config, err := LoadConfig(*flag.CommandLine, os.Args[1:])
+	args := os.Args[1:]
+	if filepath.Base(os.Args[0]) == cli.DockerCredentialHelperName {
+		// Docker runs helper with operation as the only argument, settings are taken from env
+		args = append([]string{"docker-credential"}, args...)
+	}
+
+	config, err := LoadConfig(*flag.CommandLine, args)
 	if err != nil {
-		return fmt.Errorf("failed to load flag and ENV settings: %w", err)
+		return fmt.Errorf("failed to load config file, ENV and flag settings: %w", err)
 	}
 
 	if config.Version {
@@ -52,6 +67,9 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("failed to create application settings: %w", err)
 	}
+	for _, warn := range config.ProfileWarnings {
+		logger.Warnf("invalid profile skipped: %v", warn)
+	}
 
 	logger.Info(appVer)
 	client := client.NewClient(cltSettings, logger)
@@ -59,5 +77,9 @@ func run() error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 	defer stop()
 
+	if len(config.Args) > 0 {
+		return client.RunCommand(ctx, config.Args)
+	}
+
 	return client.Start(ctx)
 }
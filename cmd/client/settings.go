@@ -5,83 +5,425 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
-	"github.com/caarlos0/env/v7"
 	"github.com/devldavydov/gophkeeper/internal/client"
+	gkConfig "github.com/devldavydov/gophkeeper/internal/common/config"
 	"github.com/devldavydov/gophkeeper/internal/common/nettools"
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
 )
 
 var errInvalidSettings = errors.New("invalid settings")
 
 const (
-	_defaultConfigServerAddress = "127.0.0.1:8080"
-	_defaultConfigCACert        = ""
-	_defaultConfigLogLevel      = "INFO"
-	_defaultConfigLogFile       = "client.log"
-	_defaultConfigVersion       = false
+	_configDirName     = "gophkeeper"
+	_configDirProfiles = "profiles"
 )
 
-// Config is a command line/env client configuration options.
+const (
+	_defaultConfigFile            = ""
+	_defaultConfigDir             = ""
+	_defaultConfigProfile         = ""
+	_defaultConfigPasswordFile    = ""
+	_defaultConfigServerAddress   = "127.0.0.1:8080"
+	_defaultConfigCACert          = ""
+	_defaultConfigCert            = ""
+	_defaultConfigKey             = ""
+	_defaultConfigLogLevel        = "INFO"
+	_defaultConfigLogFile         = "client.log"
+	_defaultConfigVersion         = false
+	_defaultConfigUserLogin       = ""
+	_defaultConfigBreachSource    = ""
+	_defaultConfigClipboardClear  = 30 * time.Second
+	_defaultConfigIdleLock        = 5 * time.Minute
+	_defaultConfigTracingExporter = ""
+	_defaultConfigTracingEndpoint = ""
+)
+
+// Config is a config file/env/command line client configuration options.
+// Precedence of sources: defaults < config file (with selected profile) < env < flags.
 type Config struct {
+	// ConfigFile - YAML config file, not used if empty.
+	// env: "CONFIG", flag: "config".
+	ConfigFile string `env:"CONFIG" yaml:"-"`
+
+	// ConfigDir - client config directory, server profiles are loaded from "profiles" subdirectory.
+	// If empty, "gophkeeper" in user config directory is used.
+	// env: "CONFIG_DIR", flag: "configdir".
+	ConfigDir string `env:"CONFIG_DIR" yaml:"-"`
+
 	// ServerAddress - address of server to connect.
-	// env: "SERVER_ADDRESS", flag: "a".
-	ServerAddress string `env:"SERVER_ADDRESS"`
+	// yaml: "server_address", env: "SERVER_ADDRESS", flag: "a".
+	ServerAddress string `env:"SERVER_ADDRESS" yaml:"server_address"`
 
 	// CACert - TLS Certification Authority certificate file.
-	// env: "TLS_CA_CERT", flag: "tlscacert".
-	CACert string `env:"TLS_CA_CERT"`
+	// yaml: "tls_ca_cert", env: "TLS_CA_CERT", flag: "tlscacert".
+	CACert string `env:"TLS_CA_CERT" yaml:"tls_ca_cert"`
+
+	// Cert - TLS client certificate file for mutual TLS.
+	// yaml: "tls_cert", env: "TLS_CLIENT_CERT", flag: "tlscert".
+	Cert string `env:"TLS_CLIENT_CERT" yaml:"tls_cert"`
+
+	// Key - TLS client certificate key file for mutual TLS.
+	// yaml: "tls_key", env: "TLS_CLIENT_KEY", flag: "tlskey".
+	Key string `env:"TLS_CLIENT_KEY" yaml:"tls_key"`
 
 	// LogLevel - logging level.
-	// env: "LOG_LEVEL", flag: "l".
-	LogLevel string `env:"LOG_LEVEL"`
+	// yaml: "log_level", env: "LOG_LEVEL", flag: "l".
+	LogLevel string `env:"LOG_LEVEL" yaml:"log_level"`
 
 	// LogFile - file to log to.
-	// env: "LOG_FILE", flag: "f".
-	LogFile string `env:"LOG_FILE"`
+	// yaml: "log_file", env: "LOG_FILE", flag: "f".
+	LogFile string `env:"LOG_FILE" yaml:"log_file"`
 
 	// Version - bool flag to show only client version
 	// flag: "version"
-	Version bool
+	Version bool `yaml:"-"`
+
+	// UserLogin - user login for command mode.
+	// yaml: "user_login", env: "USER_LOGIN", flag: "u".
+	UserLogin string `env:"USER_LOGIN" yaml:"user_login"`
+
+	// UserPassword - user password for command mode, only from env to keep it out of shell history.
+	// env: "USER_PASSWORD".
+	UserPassword string `env:"USER_PASSWORD" yaml:"-"`
+
+	// UserPasswordFile - file with user password for command mode, overrides UserPassword.
+	// yaml: "user_password_file", env: "USER_PASSWORD_FILE", flag: "pfile".
+	UserPasswordFile string `env:"USER_PASSWORD_FILE" yaml:"user_password_file"`
+
+	// BreachSource - Have I Been Pwned SHA-1 hash dump file or range API mirror URL.
+	// yaml: "hibp_source", env: "HIBP_SOURCE", flag: "hibp".
+	BreachSource string `env:"HIBP_SOURCE" yaml:"hibp_source"`
+
+	// ClipboardClear - timeout to clear copied secret from clipboard, 0 - never clear.
+	// yaml: "clipboard_clear_timeout", env: "CLIPBOARD_CLEAR_TIMEOUT", flag: "clipclear".
+	ClipboardClear time.Duration `env:"CLIPBOARD_CLEAR_TIMEOUT" yaml:"clipboard_clear_timeout"`
+
+	// IdleLock - timeout of inactivity to lock UI, 0 - never lock.
+	// yaml: "idle_lock_timeout", env: "IDLE_LOCK_TIMEOUT", flag: "lock".
+	IdleLock time.Duration `env:"IDLE_LOCK_TIMEOUT" yaml:"idle_lock_timeout"`
+
+	// TracingExporter - tracing spans exporter: "stdout" or "otlp", tracing disabled if empty.
+	// yaml: "tracing_exporter", env: "TRACING_EXPORTER", flag: "trace".
+	TracingExporter string `env:"TRACING_EXPORTER" yaml:"tracing_exporter"`
+
+	// TracingEndpoint - OTLP gRPC collector address for "otlp" exporter.
+	// yaml: "tracing_endpoint", env: "TRACING_ENDPOINT", flag: "traceendpoint".
+	TracingEndpoint string `env:"TRACING_ENDPOINT" yaml:"tracing_endpoint"`
+
+	// Args - command and its arguments to run instead of UI.
+	Args []string `yaml:"-"`
+
+	// Profile - name of server profile to use.
+	// yaml: "profile", env: "PROFILE", flag: "profile".
+	Profile string `env:"PROFILE" yaml:"profile"`
+
+	// Profiles - server profiles by name from config file and config directory "profiles/<name>.yaml" files.
+	// Profile from config file takes precedence over profile file with the same name.
+	// yaml: "profiles".
+	Profiles map[string]Profile `yaml:"profiles"`
+
+	// ProfileWarnings - errors of skipped invalid profiles, except active one, which is required to be valid.
+	ProfileWarnings []error `yaml:"-"`
 }
 
-// LoadConfig loads server configuration from flags/env.
+// Profile is a server profile. Set values override common config file settings,
+// unset values are inherited from them.
+type Profile struct {
+	// ServerAddress - address of server to connect.
+	ServerAddress string `yaml:"server_address"`
+
+	// CACert - TLS Certification Authority certificate file.
+	CACert string `yaml:"tls_ca_cert"`
+
+	// Cert - TLS client certificate file for mutual TLS.
+	Cert string `yaml:"tls_cert"`
+
+	// Key - TLS client certificate key file for mutual TLS.
+	Key string `yaml:"tls_key"`
+
+	// UserLogin - default user login.
+	UserLogin string `yaml:"user_login"`
+}
+
+// LoadConfig loads client configuration from config file/env/flags.
+// User password is read from file, if "USER_PASSWORD_FILE" is set.
 func LoadConfig(flagSet flag.FlagSet, flags []string) (*Config, error) {
 	var err error
 	config := &Config{}
 
 	// Flags
+	flagSet.StringVar(&config.ConfigFile, "config", _defaultConfigFile, "YAML config file")
+	flagSet.StringVar(&config.ConfigDir, "configdir", _defaultConfigDir, "client config directory with server profiles")
+	flagSet.StringVar(&config.Profile, "profile", _defaultConfigProfile, "server profile")
+	flagSet.StringVar(&config.UserPasswordFile, "pfile", _defaultConfigPasswordFile, "file with user password for command mode")
 	flagSet.StringVar(&config.ServerAddress, "a", _defaultConfigServerAddress, "server address")
 	flagSet.StringVar(&config.CACert, "tlscacert", _defaultConfigCACert, "CA certificate")
+	flagSet.StringVar(&config.Cert, "tlscert", _defaultConfigCert, "client certificate")
+	flagSet.StringVar(&config.Key, "tlskey", _defaultConfigKey, "client certificate key")
 	flagSet.StringVar(&config.LogLevel, "l", _defaultConfigLogLevel, "log level")
 	flagSet.StringVar(&config.LogFile, "f", _defaultConfigLogFile, "log file")
 	flagSet.BoolVar(&config.Version, "version", _defaultConfigVersion, "show client version only")
+	flagSet.StringVar(&config.UserLogin, "u", _defaultConfigUserLogin, "user login for command mode")
+	flagSet.StringVar(&config.BreachSource, "hibp", _defaultConfigBreachSource, "HIBP hash dump file or range API mirror URL")
+	flagSet.DurationVar(&config.ClipboardClear, "clipclear", _defaultConfigClipboardClear, "clipboard clear timeout")
+	flagSet.DurationVar(&config.IdleLock, "lock", _defaultConfigIdleLock, "UI idle lock timeout")
+	flagSet.StringVar(&config.TracingExporter, "trace", _defaultConfigTracingExporter, "tracing exporter: stdout or otlp (disabled if empty)")
+	flagSet.StringVar(&config.TracingEndpoint, "traceendpoint", _defaultConfigTracingEndpoint, "tracing OTLP collector address")
 
 	flagSet.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage of %s: [flags] [command [arguments]]\n", os.Args[0])
 		flagSet.PrintDefaults()
+		fmt.Fprintln(os.Stderr, "Commands:")
+		fmt.Fprintln(os.Stderr, "  export -o <file> [-passphrase-file <file>]")
+		fmt.Fprintln(os.Stderr, "  restore -i <file> [-mode skip|overwrite|rename] [-passphrase-file <file>]")
+		fmt.Fprintln(os.Stderr, "  generate [-length N] [-lower] [-upper] [-digits] [-symbols] [-exclude-ambiguous] [-words N] [-sep S]")
+		fmt.Fprintln(os.Stderr, "  strength")
+		fmt.Fprintln(os.Stderr, "  audit [-max-age-days N] [-card-warn-days N]")
+		fmt.Fprintln(os.Stderr, "  expiring [-days N]")
+		fmt.Fprintln(os.Stderr, "  share [-ttl D] [-views N] <name>")
+		fmt.Fprintln(os.Stderr, "  redeem [-o <file>] <link>")
+		fmt.Fprintln(os.Stderr, "  breach-check [-source <file|url>]")
+		fmt.Fprintln(os.Stderr, "  run -env NAME=secret:<name>[#<field>] [-env ...] -- <command> [arguments]")
+		fmt.Fprintln(os.Stderr, "  inject -i <template> [-o <file>]")
+		fmt.Fprintln(os.Stderr, "  git-credential get|store|erase")
+		fmt.Fprintln(os.Stderr, "  docker-credential store|get|erase|list|version")
 	}
 
-	_ = flagSet.Parse(flags)
+	if err = gkConfig.Load(&flagSet, flags, config, &config.ConfigFile, config.loadFile); err != nil {
+		return nil, err
+	}
+	config.Args = flagSet.Args()
 
-	// Check env
-	if err = env.Parse(config); err != nil {
+	if err = gkConfig.ReadSecretFile(&config.UserPassword, config.UserPasswordFile, "user_password_file"); err != nil {
 		return nil, err
 	}
 
 	return config, nil
 }
 
-// ClientSettingsAdapt adapts flag/env configuration settings to client settings internal format.
-// Returns error "errInvalidSettings" in case of invalid configuration.
+// loadFile loads config file, if path is not empty, and server profiles from config directory.
+// Then selected profile settings are applied on top of config file settings.
+// Profile, selected by env/flags, takes precedence over profile from config file.
+func (c *Config) loadFile(path string) error {
+	profileName := c.Profile
+
+	// Config file common settings, profiles inherit unset values from them
+	common := Profile{ServerAddress: _defaultConfigServerAddress}
+	if path != "" {
+		if err := gkConfig.LoadFile(path, c); err != nil {
+			return err
+		}
+
+		fileConfig := &Config{}
+		if err := gkConfig.LoadFile(path, fileConfig); err != nil {
+			return err
+		}
+		common = fileConfig.commonProfile().inherit(common)
+	}
+
+	if profileName == "" {
+		profileName = c.Profile
+	}
+
+	if err := c.loadProfilesDir(profileName); err != nil {
+		return err
+	}
+
+	for name, profile := range c.Profiles {
+		c.Profiles[name] = profile.inherit(common)
+	}
+
+	profile, ok := c.Profiles[profileName]
+	if !ok {
+		return nil
+	}
+
+	c.ServerAddress = profile.ServerAddress
+	c.CACert = profile.CACert
+	c.Cert = profile.Cert
+	c.Key = profile.Key
+	c.UserLogin = profile.UserLogin
+
+	return nil
+}
+
+// loadProfilesDir loads server profiles from "profiles/<name>.yaml" files of config directory.
+// Missing directory is not an error. Invalid profile file is skipped with warning, unless it is active profile.
+func (c *Config) loadProfilesDir(active string) error {
+	configDir := c.ConfigDir
+	if configDir == "" {
+		userConfigDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil
+		}
+		configDir = filepath.Join(userConfigDir, _configDirName)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(configDir, _configDirProfiles))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ext)
+		if _, ok := c.Profiles[name]; ok {
+			continue
+		}
+
+		var profile Profile
+		if err = gkConfig.LoadFile(filepath.Join(configDir, _configDirProfiles, entry.Name()), &profile); err != nil {
+			if name == active {
+				return err
+			}
+			c.ProfileWarnings = append(c.ProfileWarnings, fmt.Errorf("profile %q: %w", name, err))
+			continue
+		}
+
+		if c.Profiles == nil {
+			c.Profiles = make(map[string]Profile)
+		}
+		c.Profiles[name] = profile
+	}
+
+	return nil
+}
+
+func (c *Config) commonProfile() Profile {
+	return Profile{
+		ServerAddress: c.ServerAddress,
+		CACert:        c.CACert,
+		Cert:          c.Cert,
+		Key:           c.Key,
+		UserLogin:     c.UserLogin,
+	}
+}
+
+// inherit returns profile with unset values taken from parent.
+func (p Profile) inherit(parent Profile) Profile {
+	for _, v := range []struct {
+		dst *string
+		val string
+	}{
+		{&p.ServerAddress, parent.ServerAddress},
+		{&p.CACert, parent.CACert},
+		{&p.Cert, parent.Cert},
+		{&p.Key, parent.Key},
+		{&p.UserLogin, parent.UserLogin},
+	} {
+		if *v.dst == "" {
+			*v.dst = v.val
+		}
+	}
+
+	return p
+}
+
+// ClientSettingsAdapt adapts file/env/flag configuration settings to client settings internal format.
+// Returns gkConfig.FieldError with name of invalid setting in case of invalid configuration.
 func ClientSettingsAdapt(config *Config) (*client.Settings, error) {
+	if _, ok := config.Profiles[config.Profile]; config.Profile != "" && !ok {
+		return nil, invalidSetting("profile", fmt.Errorf("profile %q not found", config.Profile))
+	}
+
 	serverAddress, err := nettools.NewAddress(config.ServerAddress)
 	if err != nil {
-		return nil, err
+		return nil, invalidSetting("server_address", err)
 	}
 
 	if config.CACert == "" {
-		return nil, errInvalidSettings
+		return nil, invalidSetting("tls_ca_cert", errors.New("must not be empty"))
+	}
+
+	if (config.Cert == "") != (config.Key == "") {
+		return nil, invalidSetting("tls_cert", errors.New("tls_cert and tls_key must be set together"))
+	}
+
+	var tracingSettings *tracing.Settings
+	if config.TracingExporter != "" {
+		if tracingSettings, err = tracing.NewSettings(config.TracingExporter, config.TracingEndpoint); err != nil {
+			return nil, invalidSetting("tracing_exporter", err)
+		}
+	}
+
+	profiles, skipped := adaptProfiles(config.Profiles, config.Profile)
+	config.ProfileWarnings = append(config.ProfileWarnings, skipped...)
+
+	return client.NewSettings(
+		serverAddress,
+		config.CACert,
+		config.Cert,
+		config.Key,
+		config.UserLogin,
+		config.UserPassword,
+		config.BreachSource,
+		config.ClipboardClear,
+		config.IdleLock,
+		tracingSettings,
+		config.Profile,
+		profiles,
+	), nil
+}
+
+// adaptProfiles validates server profiles and returns valid ones sorted by name with errors of invalid ones.
+// Active profile is skipped, its settings with env/flags overrides are common client settings,
+// which are validated on their own.
+func adaptProfiles(cfgProfiles map[string]Profile, active string) ([]*client.Profile, []error) {
+	names := make([]string, 0, len(cfgProfiles))
+	for name := range cfgProfiles {
+		if name != active {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	profiles := make([]*client.Profile, 0, len(names))
+	var skipped []error
+	for _, name := range names {
+		profile, err := adaptProfile(name, cfgProfiles[name])
+		if err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, skipped
+}
+
+// adaptProfile validates server profile.
+// Returns gkConfig.FieldError with "profiles.<name>.<setting>" field in case of invalid profile.
+func adaptProfile(name string, p Profile) (*client.Profile, error) {
+	fnField := func(field string) string { return fmt.Sprintf("profiles.%s.%s", name, field) }
+
+	serverAddress, err := nettools.NewAddress(p.ServerAddress)
+	if err != nil {
+		return nil, invalidSetting(fnField("server_address"), err)
 	}
 
-	return client.NewSettings(serverAddress, config.CACert), nil
+	if p.CACert == "" {
+		return nil, invalidSetting(fnField("tls_ca_cert"), errors.New("must not be empty"))
+	}
+
+	if (p.Cert == "") != (p.Key == "") {
+		return nil, invalidSetting(fnField("tls_cert"), errors.New("tls_cert and tls_key must be set together"))
+	}
+
+	return client.NewProfile(name, serverAddress, p.CACert, p.Cert, p.Key, p.UserLogin), nil
+}
+
+func invalidSetting(field string, err error) error {
+	return &gkConfig.FieldError{Field: field, Err: fmt.Errorf("%w: %v", errInvalidSettings, err)}
 }
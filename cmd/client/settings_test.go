@@ -3,16 +3,41 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	gkConfig "github.com/devldavydov/gophkeeper/internal/common/config"
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// TestMain isolates tests from profiles in real user config directory.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "gophkeeper-config-")
+	if err != nil {
+		panic(err)
+	}
+	for _, env := range []string{"XDG_CONFIG_HOME", "HOME"} {
+		if err = os.Setenv(env, dir); err != nil {
+			panic(err)
+		}
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 func TestApplicatrionSettingsAdaptFromEnv(t *testing.T) {
 	t.Setenv("SERVER_ADDRESS", "127.0.0.1:8888")
 	t.Setenv("TLS_CA_CERT", "/tmp/ca.cert")
 	t.Setenv("LOG_LEVEL", "DEBUG")
 	t.Setenv("LOG_FILE", "log.log")
+	t.Setenv("TLS_CLIENT_CERT", "/tmp/client.cert")
+	t.Setenv("TLS_CLIENT_KEY", "/tmp/client.key")
 
 	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
 	config, err := LoadConfig(*testFlagSet, []string{})
@@ -23,6 +48,8 @@ func TestApplicatrionSettingsAdaptFromEnv(t *testing.T) {
 
 	assert.Equal(t, "127.0.0.1:8888", cltSettings.ServerAddress.String())
 	assert.Equal(t, "/tmp/ca.cert", cltSettings.TLSCACertPath)
+	assert.Equal(t, "/tmp/client.cert", cltSettings.TLSCertPath)
+	assert.Equal(t, "/tmp/client.key", cltSettings.TLSKeyPath)
 }
 
 func TestApplicationSettingsAdaptFromFlag(t *testing.T) {
@@ -32,6 +59,8 @@ func TestApplicationSettingsAdaptFromFlag(t *testing.T) {
 		"-tlscacert", "/tmp/ca.cert",
 		"-l", "DEBUG",
 		"-f", "log.log",
+		"-tlscert", "/tmp/client.cert",
+		"-tlskey", "/tmp/client.key",
 	})
 	assert.NoError(t, err)
 
@@ -40,6 +69,8 @@ func TestApplicationSettingsAdaptFromFlag(t *testing.T) {
 
 	assert.Equal(t, "127.0.0.1:8888", cltSettings.ServerAddress.String())
 	assert.Equal(t, "/tmp/ca.cert", cltSettings.TLSCACertPath)
+	assert.Equal(t, "/tmp/client.cert", cltSettings.TLSCertPath)
+	assert.Equal(t, "/tmp/client.key", cltSettings.TLSKeyPath)
 }
 
 func TestApplicationSettingsWithDefault(t *testing.T) {
@@ -54,6 +85,7 @@ func TestApplicationSettingsWithDefault(t *testing.T) {
 
 	assert.Equal(t, "127.0.0.1:8080", cltSettings.ServerAddress.String())
 	assert.Equal(t, "/tmp/ca.cert", cltSettings.TLSCACertPath)
+	assert.Equal(t, "", cltSettings.TLSCertPath)
 }
 
 func TestApplicationSettingsAdaptError(t *testing.T) {
@@ -66,6 +98,9 @@ func TestApplicationSettingsAdaptError(t *testing.T) {
 		{flags: []string{"-a", ""}, env: map[string]string{}},
 		{flags: []string{"-tlscacert", ""}, env: map[string]string{}},
 		{flags: []string{}, env: map[string]string{"TLS_CA_CERT": ""}},
+		{flags: []string{"-tlscacert", "/tmp/ca.cert", "-trace", "jaeger"}, env: map[string]string{}},
+		{flags: []string{"-tlscacert", "/tmp/ca.cert", "-tlscert", "/tmp/client.cert"}, env: map[string]string{}},
+		{flags: []string{"-tlscacert", "/tmp/ca.cert"}, env: map[string]string{"TLS_CLIENT_KEY": "/tmp/client.key"}},
 	} {
 		tt := tt
 		t.Run(fmt.Sprintf("Run %d", i), func(t *testing.T) {
@@ -87,3 +122,320 @@ func TestApplicationSettingsAdaptError(t *testing.T) {
 		})
 	}
 }
+
+func TestApplicationSettingsCommand(t *testing.T) {
+	t.Setenv("USER_PASSWORD", "password")
+
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{
+		"-tlscacert", "/tmp/ca.cert",
+		"-u", "user",
+		"export", "-o", "/tmp/vault.gkv",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"export", "-o", "/tmp/vault.gkv"}, config.Args)
+
+	cltSettings, err := ClientSettingsAdapt(config)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "user", cltSettings.UserLogin)
+	assert.Equal(t, "password", cltSettings.UserPassword)
+}
+
+func TestApplicationSettingsBreachSource(t *testing.T) {
+	t.Setenv("HIBP_SOURCE", "http://127.0.0.1:9999")
+
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{
+		"-tlscacert", "/tmp/ca.cert",
+		"-hibp", "/tmp/pwned.txt",
+	})
+	assert.NoError(t, err)
+
+	cltSettings, err := ClientSettingsAdapt(config)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "/tmp/pwned.txt", cltSettings.BreachSource)
+}
+
+func TestApplicationSettingsClipboardClear(t *testing.T) {
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{"-tlscacert", "/tmp/ca.cert"})
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, config.ClipboardClear)
+
+	t.Setenv("CLIPBOARD_CLEAR_TIMEOUT", "1m")
+	testFlagSet = flag.NewFlagSet("test", flag.ExitOnError)
+	config, err = LoadConfig(*testFlagSet, []string{"-tlscacert", "/tmp/ca.cert", "-clipclear", "0s"})
+	assert.NoError(t, err)
+
+	cltSettings, err := ClientSettingsAdapt(config)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), cltSettings.ClipboardClearTimeout)
+}
+
+func TestApplicationSettingsIdleLock(t *testing.T) {
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{"-tlscacert", "/tmp/ca.cert"})
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, config.IdleLock)
+
+	testFlagSet = flag.NewFlagSet("test", flag.ExitOnError)
+	config, err = LoadConfig(*testFlagSet, []string{"-tlscacert", "/tmp/ca.cert", "-lock", "1m"})
+	assert.NoError(t, err)
+
+	cltSettings, err := ClientSettingsAdapt(config)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, cltSettings.IdleLockTimeout)
+}
+
+func TestApplicationSettingsTracing(t *testing.T) {
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{"-tlscacert", "/tmp/ca.cert"})
+	assert.NoError(t, err)
+
+	cltSettings, err := ClientSettingsAdapt(config)
+	assert.NoError(t, err)
+	assert.Nil(t, cltSettings.Tracing)
+
+	t.Setenv("TRACING_EXPORTER", "otlp")
+	t.Setenv("TRACING_ENDPOINT", "127.0.0.1:4317")
+
+	testFlagSet = flag.NewFlagSet("test", flag.ExitOnError)
+	config, err = LoadConfig(*testFlagSet, []string{"-tlscacert", "/tmp/ca.cert"})
+	assert.NoError(t, err)
+
+	cltSettings, err = ClientSettingsAdapt(config)
+	assert.NoError(t, err)
+	assert.Equal(t, &tracing.Settings{Exporter: "otlp", OTLPEndpoint: "127.0.0.1:4317"}, cltSettings.Tracing)
+}
+
+func TestApplicationSettingsDockerCredentialHelper(t *testing.T) {
+	t.Setenv("TLS_CA_CERT", "/tmp/ca.cert")
+	t.Setenv("USER_LOGIN", "user")
+
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{"docker-credential", "get"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docker-credential", "get"}, config.Args)
+	assert.Equal(t, "user", config.UserLogin)
+}
+
+func TestApplicationSettingsProfiles(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "client.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+server_address: 127.0.0.1:8080
+tls_ca_cert: /tmp/ca.cert
+log_level: WARN
+profile: home
+profiles:
+  home:
+    user_login: alice
+  work:
+    server_address: 10.0.0.1:8080
+    tls_ca_cert: /tmp/work-ca.cert
+    tls_cert: /tmp/work.cert
+    tls_key: /tmp/work.key
+    user_login: bob
+`), 0600))
+
+	t.Setenv("CONFIG_DIR", dir)
+
+	for _, tt := range []struct {
+		name       string
+		flags      []string
+		env        map[string]string
+		expAddress string
+		expCACert  string
+		expCert    string
+		expLogin   string
+	}{
+		{
+			name:       "default profile",
+			flags:      []string{"-config", configFile},
+			expAddress: "127.0.0.1:8080",
+			expCACert:  "/tmp/ca.cert",
+			expLogin:   "alice",
+		},
+		{
+			name:       "profile from flag",
+			flags:      []string{"-config", configFile, "-profile", "work"},
+			expAddress: "10.0.0.1:8080",
+			expCACert:  "/tmp/work-ca.cert",
+			expCert:    "/tmp/work.cert",
+			expLogin:   "bob",
+		},
+		{
+			name:       "profile from env, overridden by flag",
+			flags:      []string{"-u", "carol"},
+			env:        map[string]string{"CONFIG": configFile, "PROFILE": "work"},
+			expAddress: "10.0.0.1:8080",
+			expCACert:  "/tmp/work-ca.cert",
+			expCert:    "/tmp/work.cert",
+			expLogin:   "carol",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+			config, err := LoadConfig(*testFlagSet, tt.flags)
+			require.NoError(t, err)
+			assert.Equal(t, "WARN", config.LogLevel)
+
+			cltSettings, err := ClientSettingsAdapt(config)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expAddress, cltSettings.ServerAddress.String())
+			assert.Equal(t, tt.expCACert, cltSettings.TLSCACertPath)
+			assert.Equal(t, tt.expCert, cltSettings.TLSCertPath)
+			assert.Equal(t, tt.expLogin, cltSettings.UserLogin)
+		})
+	}
+}
+
+func TestApplicationSettingsFieldError(t *testing.T) {
+	for _, tt := range []struct {
+		flags []string
+		field string
+	}{
+		{flags: []string{"-a", "", "-tlscacert", "/tmp/ca.cert"}, field: "server_address"},
+		{flags: []string{}, field: "tls_ca_cert"},
+		{flags: []string{"-tlscacert", "/tmp/ca.cert", "-tlscert", "/tmp/client.cert"}, field: "tls_cert"},
+		{flags: []string{"-tlscacert", "/tmp/ca.cert", "-profile", "work"}, field: "profile"},
+	} {
+		tt := tt
+		t.Run(tt.field, func(t *testing.T) {
+			testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+			config, err := LoadConfig(*testFlagSet, tt.flags)
+			require.NoError(t, err)
+
+			_, err = ClientSettingsAdapt(config)
+			var fieldErr *gkConfig.FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tt.field, fieldErr.Field)
+			assert.ErrorIs(t, err, errInvalidSettings)
+		})
+	}
+}
+
+func TestApplicationSettingsPasswordFile(t *testing.T) {
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0600))
+	t.Setenv("USER_PASSWORD_FILE", passwordFile)
+
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{"-tlscacert", "/tmp/ca.cert", "-u", "user", "export", "-o", "/tmp/vault.gkv"})
+	require.NoError(t, err)
+	assert.Equal(t, "password", config.UserPassword)
+}
+
+func TestApplicationSettingsProfilesDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "profiles"), 0700))
+	for name, data := range map[string]string{
+		"home.yaml":  "server_address: 192.168.1.1:8080\ntls_ca_cert: /tmp/home-ca.cert\nuser_login: alice\n",
+		"work.yml":   "server_address: 10.0.0.1:8080\ntls_ca_cert: /tmp/work-ca.cert\ntls_cert: /tmp/work.cert\ntls_key: /tmp/work.key\n",
+		"README.txt": "not a profile",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "profiles", name), []byte(data), 0600))
+	}
+
+	configFile := filepath.Join(dir, "client.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+tls_ca_cert: /tmp/ca.cert
+profiles:
+  work:
+    user_login: bob
+`), 0600))
+
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{"-configdir", dir, "-config", configFile})
+	require.NoError(t, err)
+
+	cltSettings, err := ClientSettingsAdapt(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, "", cltSettings.Profile)
+	assert.Equal(t, "127.0.0.1:8080", cltSettings.ServerAddress.String())
+	require.Len(t, cltSettings.Profiles, 2)
+
+	home := cltSettings.Profiles[0]
+	assert.Equal(t, "home", home.Name)
+	assert.Equal(t, "192.168.1.1:8080", home.ServerAddress.String())
+	assert.Equal(t, "/tmp/home-ca.cert", home.TLSCACertPath)
+	assert.Equal(t, "alice", home.UserLogin)
+
+	// Profile from config file takes precedence over profile file
+	work := cltSettings.Profiles[1]
+	assert.Equal(t, "work", work.Name)
+	assert.Equal(t, "127.0.0.1:8080", work.ServerAddress.String())
+	assert.Equal(t, "/tmp/ca.cert", work.TLSCACertPath)
+	assert.Equal(t, "", work.TLSCertPath)
+	assert.Equal(t, "bob", work.UserLogin)
+
+	// Active profile, overridden by flags, is not in profiles list
+	testFlagSet = flag.NewFlagSet("test", flag.ExitOnError)
+	config, err = LoadConfig(*testFlagSet, []string{"-configdir", dir, "-profile", "home", "-u", "carol"})
+	require.NoError(t, err)
+
+	cltSettings, err = ClientSettingsAdapt(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, "home", cltSettings.Profile)
+	assert.Equal(t, "192.168.1.1:8080", cltSettings.ServerAddress.String())
+	assert.Equal(t, "carol", cltSettings.UserLogin)
+	require.Len(t, cltSettings.Profiles, 1)
+	assert.Equal(t, "work", cltSettings.Profiles[0].Name)
+	assert.Equal(t, "/tmp/work.cert", cltSettings.Profiles[0].TLSCertPath)
+}
+
+func TestApplicationSettingsProfilesDirError(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "profiles"), 0700))
+	for name, data := range map[string]string{
+		"broken.yaml":  "foo: bar\n",
+		"invalid.yaml": "server_address: foo\ntls_ca_cert: /tmp/ca.cert\n",
+		"valid.yaml":   "server_address: 10.0.0.1:8080\ntls_ca_cert: /tmp/ca.cert\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "profiles", name), []byte(data), 0600))
+	}
+
+	t.Run("invalid profiles skipped", func(t *testing.T) {
+		testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+		config, err := LoadConfig(*testFlagSet, []string{"-configdir", dir, "-tlscacert", "/tmp/ca.cert"})
+		require.NoError(t, err)
+
+		cltSettings, err := ClientSettingsAdapt(config)
+		require.NoError(t, err)
+		require.Len(t, cltSettings.Profiles, 1)
+		assert.Equal(t, "valid", cltSettings.Profiles[0].Name)
+
+		require.Len(t, config.ProfileWarnings, 2)
+		assert.ErrorContains(t, config.ProfileWarnings[0], `profile "broken"`)
+		var fieldErr *gkConfig.FieldError
+		require.ErrorAs(t, config.ProfileWarnings[1], &fieldErr)
+		assert.Equal(t, "profiles.invalid.server_address", fieldErr.Field)
+	})
+
+	t.Run("active profile file broken", func(t *testing.T) {
+		testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+		_, err := LoadConfig(*testFlagSet, []string{"-configdir", dir, "-profile", "broken"})
+		assert.Error(t, err)
+	})
+
+	t.Run("active profile invalid", func(t *testing.T) {
+		testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+		config, err := LoadConfig(*testFlagSet, []string{"-configdir", dir, "-profile", "invalid"})
+		require.NoError(t, err)
+
+		_, err = ClientSettingsAdapt(config)
+		var fieldErr *gkConfig.FieldError
+		require.ErrorAs(t, err, &fieldErr)
+		assert.Equal(t, "server_address", fieldErr.Field)
+	})
+}
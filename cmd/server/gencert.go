@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	gkTLS "github.com/devldavydov/gophkeeper/internal/common/tls"
+)
+
+const (
+	_genClientCertCommand = "gen-client-cert"
+
+	_defaultGenClientCertValidity = 365 * 24 * time.Hour
+)
+
+// runGenClientCert issues client certificate for user login, signed by CA,
+// and writes it to <prefix>-cert.pem and <prefix>-key.pem. Existing files are not overwritten.
+func runGenClientCert(args []string, out io.Writer) error {
+	flagSet := flag.NewFlagSet(_genClientCertCommand, flag.ContinueOnError)
+	flagSet.SetOutput(out)
+	caCertPath := flagSet.String("cacert", "", "CA certificate")
+	caKeyPath := flagSet.String("cakey", "", "CA private key")
+	userLogin := flagSet.String("user", "", "user login, used as certificate subject")
+	validity := flagSet.Duration("validity", _defaultGenClientCertValidity, "certificate validity")
+	prefix := flagSet.String("o", "", "output files prefix (user login if empty)")
+
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *caCertPath == "" || *caKeyPath == "" || *userLogin == "" {
+		flagSet.Usage()
+		return errInvalidSettings
+	}
+	if *prefix == "" {
+		*prefix = *userLogin
+	}
+
+	caCertPEM, err := os.ReadFile(*caCertPath)
+	if err != nil {
+		return err
+	}
+	caKeyPEM, err := os.ReadFile(*caKeyPath)
+	if err != nil {
+		return err
+	}
+
+	certPEM, keyPEM, err := gkTLS.GenerateClientCert(caCertPEM, caKeyPEM, *userLogin, *validity)
+	if err != nil {
+		return err
+	}
+
+	certPath, keyPath := *prefix+"-cert.pem", *prefix+"-key.pem"
+	if err = writeNewFile(keyPath, keyPEM, 0600); err != nil {
+		return err
+	}
+	if err = writeNewFile(certPath, certPEM, 0644); err != nil {
+		return errors.Join(err, os.Remove(keyPath))
+	}
+
+	fmt.Fprintf(out, "client certificate for [%s] written to %s, key to %s\n", *userLogin, certPath, keyPath)
+	return nil
+}
+
+func writeNewFile(name string, data []byte, perm os.FileMode) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
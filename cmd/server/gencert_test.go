@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunGenClientCert(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "alice")
+	args := []string{
+		"-cacert", getTLSFile("ca-cert.pem"),
+		"-cakey", getTLSFile("ca-key.pem"),
+		"-user", "alice",
+		"-o", prefix,
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, runGenClientCert(args, out))
+	assert.Contains(t, out.String(), "alice-cert.pem")
+
+	certPEM, err := os.ReadFile(prefix + "-cert.pem")
+	require.NoError(t, err)
+	block, _ := pem.Decode(certPEM)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", cert.Subject.CommonName)
+
+	keyInfo, err := os.Stat(prefix + "-key.pem")
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), keyInfo.Mode().Perm())
+
+	// Existing files are not overwritten
+	assert.Error(t, runGenClientCert(args, out))
+	certPEMAfter, err := os.ReadFile(prefix + "-cert.pem")
+	require.NoError(t, err)
+	assert.Equal(t, certPEM, certPEMAfter)
+}
+
+func TestRunGenClientCertError(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, args := range [][]string{
+		{"-foo"},
+		{"-cacert", getTLSFile("ca-cert.pem"), "-cakey", getTLSFile("ca-key.pem")},
+		{"-cacert", "/tmp/foo", "-cakey", getTLSFile("ca-key.pem"), "-user", "alice", "-o", filepath.Join(dir, "a")},
+		{"-cacert", getTLSFile("ca-cert.pem"), "-cakey", getTLSFile("ca-cert.pem"), "-user", "alice", "-o", filepath.Join(dir, "b")},
+	} {
+		assert.Error(t, runGenClientCert(args, &bytes.Buffer{}), args)
+	}
+}
+
+func getTLSFile(fileName string) string {
+	_, this, _, _ := runtime.Caller(0)
+	tlsRoot := filepath.Join(this, "../../../tls")
+	return filepath.Join(tlsRoot, fileName)
+}
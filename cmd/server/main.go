@@ -34,9 +34,13 @@ func run() error {
 	appVer := info.FormatVersion(buildVersion, buildDate, buildCommit)
 	fmt.Println(appVer)
 
+	if len(os.Args) > 1 && os.Args[1] == _genClientCertCommand {
+		return runGenClientCert(os.Args[2:], os.Stdout)
+	}
+
 	config, err := LoadConfig(*flag.CommandLine, os.Args[1:])
 	if err != nil {
-		return fmt.Errorf("failed to load flag and ENV settings: %w", err)
+		return fmt.Errorf("failed to load config file, ENV and flag settings: %w", err)
 	}
 
 	logger, err := gkLog.NewLogger(config.LogLevel)
@@ -55,5 +59,11 @@ func run() error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 	defer stop()
 
+	// Reload settings on SIGHUP
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	defer signal.Stop(hupCh)
+	go reloadOnSignal(ctx, hupCh, os.Args[1:], serverService, logger)
+
 	return serverService.Start(ctx)
 }
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
+	"github.com/devldavydov/gophkeeper/internal/server"
+	"github.com/sirupsen/logrus"
+)
+
+// serviceReloader is an interface of running service settings reload.
+type serviceReloader interface {
+	Reload(settings *server.ServiceSettings)
+}
+
+// reloadOnSignal reloads configuration with the same command line arguments on signal till context canceled.
+// Log level is applied to logger, other settings are passed to service. Invalid configuration is skipped.
+func reloadOnSignal(
+	ctx context.Context,
+	sigCh <-chan os.Signal,
+	args []string,
+	svc serviceReloader,
+	logger *logrus.Logger,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-sigCh:
+			logger.Infof("signal [%s] received, reloading settings", sig)
+			if err := reloadConfig(args, svc, logger); err != nil {
+				logger.Errorf("settings reload error, current settings kept: %v", err)
+			}
+		}
+	}
+}
+
+func reloadConfig(args []string, svc serviceReloader, logger *logrus.Logger) error {
+	flagSet := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+
+	config, err := LoadConfig(*flagSet, args)
+	if err != nil {
+		return err
+	}
+
+	serverSettings, err := ServiceSettingsAdapt(config)
+	if err != nil {
+		return err
+	}
+
+	if err = gkLog.SetLevel(logger, config.LogLevel); err != nil {
+		return err
+	}
+
+	svc.Reload(serverSettings)
+	return nil
+}
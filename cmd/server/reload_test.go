@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/server"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServiceReloader struct {
+	ch chan *server.ServiceSettings
+}
+
+func (r *testServiceReloader) Reload(settings *server.ServiceSettings) {
+	r.ch <- settings
+}
+
+func TestReloadOnSignal(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "server.yaml")
+	fnWriteConfig := func(data string) {
+		require.NoError(t, os.WriteFile(configFile, []byte(data), 0600))
+	}
+	fnWriteConfig("log_level: INFO\nshutdown_timeout: 1s\n")
+
+	args := []string{"-config", configFile, "-d", "postgre:5432", "-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key"}
+	logger := logrus.New()
+	svc := &testServiceReloader{ch: make(chan *server.ServiceSettings, 1)}
+	sigCh := make(chan os.Signal, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloadOnSignal(ctx, sigCh, args, svc, logger)
+
+	// Changed config file is applied
+	fnWriteConfig("log_level: DEBUG\nshutdown_timeout: 3s\n")
+	sigCh <- syscall.SIGHUP
+
+	select {
+	case settings := <-svc.ch:
+		assert.Equal(t, 3*time.Second, settings.ShutdownTimeout)
+		assert.Equal(t, "/tmp/tls.cert", settings.GRPCServerTLS.ServerCertPath)
+	case <-time.After(time.Second):
+		require.Fail(t, "settings not reloaded")
+	}
+	assert.Equal(t, logrus.DebugLevel, logger.GetLevel())
+
+	// Invalid config is skipped
+	fnWriteConfig("log_level: DEBUG\nserver_secret: short\n")
+	sigCh <- syscall.SIGHUP
+
+	select {
+	case <-svc.ch:
+		require.Fail(t, "invalid settings reloaded")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestReloadConfigError(t *testing.T) {
+	svc := &testServiceReloader{ch: make(chan *server.ServiceSettings, 1)}
+	logger := logrus.New()
+
+	for _, args := range [][]string{
+		{"-foo"},
+		{"-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key"},
+		{"-d", "postgre:5432", "-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-l", "foobar"},
+	} {
+		assert.Error(t, reloadConfig(args, svc, logger), args)
+	}
+	assert.Equal(t, logrus.InfoLevel, logger.GetLevel())
+	assert.Empty(t, svc.ch)
+}
@@ -5,106 +5,283 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
-	"github.com/caarlos0/env/v7"
 	"github.com/devldavydov/gophkeeper/internal/common/cipher"
+	gkConfig "github.com/devldavydov/gophkeeper/internal/common/config"
 	"github.com/devldavydov/gophkeeper/internal/common/nettools"
 	gkTLS "github.com/devldavydov/gophkeeper/internal/common/tls"
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
 	"github.com/devldavydov/gophkeeper/internal/server"
+	"github.com/devldavydov/gophkeeper/internal/server/storage"
 )
 
 var errInvalidSettings = errors.New("invalid settings")
 
 const (
+	_defaultConfigFile              = ""
 	_defaultConfigGRPCAddress       = "127.0.0.1:8080"
 	_defaultConfigGRPCServerTLSCert = ""
 	_defaultConfigGRPCServerTLSKey  = ""
+	_defaultConfigGRPCClientTLSCA   = ""
+	_defaultConfigGRPCClientRequire = false
+	_defaultConfigGRPCClientBind    = false
 	_defaultConfigDatabaseDsn       = ""
+	_defaultConfigDatabaseDsnFile   = ""
 	_defaultConfigLogLevel          = "INFO"
 	_defaultConfigServerSecret      = "GophKeeperSupaSecretKeyForCrypto" //nolint:gosec // OK
+	_defaultConfigServerSecretFile  = ""
 	_defaultConfigShutdownTimeout   = 10 * time.Second
+	_defaultConfigMetricsAddress    = ""
+	_defaultConfigTracingExporter   = ""
+	_defaultConfigTracingEndpoint   = ""
+	_defaultConfigReflection        = false
+	_defaultConfigAdminAddress      = ""
+	_defaultConfigAdminLogin        = ""
+	_defaultConfigAdminPassword     = ""
+	_defaultConfigAdminPasswordFile = ""
+	_defaultConfigAdminClientTLSCA  = ""
+	_defaultConfigAdminClientNames  = ""
+	_defaultConfigQuotaBytes        = 100 << 20
+	_defaultConfigQuotaSecrets      = 1000
 )
 
-// Config is a command line/env server configuration options.
+// Config is a config file/env/command line server configuration options.
+// Precedence of sources: defaults < config file < env < flags.
 type Config struct {
+	// ConfigFile - YAML config file, not used if empty.
+	// env: "CONFIG", flag: "config".
+	ConfigFile string `env:"CONFIG" yaml:"-"`
+
 	// GRPCAddress - listen address of server.
-	// env: "GRPC_ADDRESS", flag: "a".
-	GRPCAddress string `env:"GRPC_ADDRESS"`
+	// yaml: "grpc_address", env: "GRPC_ADDRESS", flag: "a".
+	GRPCAddress string `env:"GRPC_ADDRESS" yaml:"grpc_address"`
 
 	// GRPCServerTLSCert - TLS certificate of server.
-	// env: "GRPC_SERVER_TLS_CERT", flag: "tlscert".
-	GRPCServerTLSCert string `env:"GRPC_SERVER_TLS_CERT"`
+	// yaml: "grpc_server_tls_cert", env: "GRPC_SERVER_TLS_CERT", flag: "tlscert".
+	GRPCServerTLSCert string `env:"GRPC_SERVER_TLS_CERT" yaml:"grpc_server_tls_cert"`
 
 	// GRPCServerTLSKey - TLS certificate key of server.
-	// env: "GRPC_SERVER_TLS_KEY", flag: "tlskey".
-	GRPCServerTLSKey string `env:"GRPC_SERVER_TLS_KEY"`
+	// yaml: "grpc_server_tls_key", env: "GRPC_SERVER_TLS_KEY", flag: "tlskey".
+	GRPCServerTLSKey string `env:"GRPC_SERVER_TLS_KEY" yaml:"grpc_server_tls_key"`
+
+	// GRPCClientTLSCA - CA bundle to verify client certificates, client certificates are not used if empty.
+	// yaml: "grpc_client_tls_ca", env: "GRPC_CLIENT_TLS_CA", flag: "tlsclientca".
+	GRPCClientTLSCA string `env:"GRPC_CLIENT_TLS_CA" yaml:"grpc_client_tls_ca"`
+
+	// GRPCClientTLSRequire - require client certificate for all connections.
+	// yaml: "grpc_client_tls_require", env: "GRPC_CLIENT_TLS_REQUIRE", flag: "tlsclientrequire".
+	GRPCClientTLSRequire bool `env:"GRPC_CLIENT_TLS_REQUIRE" yaml:"grpc_client_tls_require"`
+
+	// GRPCClientTLSBind - user login requires client certificate, issued for this user.
+	// yaml: "grpc_client_tls_bind", env: "GRPC_CLIENT_TLS_BIND", flag: "tlsclientbind".
+	GRPCClientTLSBind bool `env:"GRPC_CLIENT_TLS_BIND" yaml:"grpc_client_tls_bind"`
 
 	// DatabaseDsn - database connection string.
-	// env: "DATABASE_DSN", flag: "d".
-	DatabaseDsn string `env:"DATABASE_DSN"`
+	// yaml: "database_dsn", env: "DATABASE_DSN", flag: "d".
+	DatabaseDsn string `env:"DATABASE_DSN" yaml:"database_dsn"`
+
+	// DatabaseDsnFile - file with database connection string, overrides DatabaseDsn.
+	// yaml: "database_dsn_file", env: "DATABASE_DSN_FILE", flag: "dfile".
+	DatabaseDsnFile string `env:"DATABASE_DSN_FILE" yaml:"database_dsn_file"`
 
 	// LogLevel - logging level.
-	// env: "LOG_LEVEL", flag: "l".
-	LogLevel string `env:"LOG_LEVEL"`
+	// yaml: "log_level", env: "LOG_LEVEL", flag: "l".
+	LogLevel string `env:"LOG_LEVEL" yaml:"log_level"`
 
 	// ServerSecret - unique 32 chars string to be used as a key of encryption.
-	// env: "SERVER_SECRET", flag: "s".
-	ServerSecret string `env:"SERVER_SECRET"`
+	// yaml: "server_secret", env: "SERVER_SECRET", flag: "s".
+	ServerSecret string `env:"SERVER_SECRET" yaml:"server_secret"`
+
+	// ServerSecretFile - file with server secret, overrides ServerSecret.
+	// yaml: "server_secret_file", env: "SERVER_SECRET_FILE", flag: "sfile".
+	ServerSecretFile string `env:"SERVER_SECRET_FILE" yaml:"server_secret_file"`
 
 	// ShutdownTimeout - server shitdown timeout.
-	// env: "SHUTDOWN_TIMEOUT", flag: "t".
-	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT"`
+	// yaml: "shutdown_timeout", env: "SHUTDOWN_TIMEOUT", flag: "t".
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" yaml:"shutdown_timeout"`
+
+	// MetricsAddress - listen address of HTTP metrics endpoint, metrics disabled if empty.
+	// yaml: "metrics_address", env: "METRICS_ADDRESS", flag: "m".
+	MetricsAddress string `env:"METRICS_ADDRESS" yaml:"metrics_address"`
+
+	// TracingExporter - tracing spans exporter: "stdout" or "otlp", tracing disabled if empty.
+	// yaml: "tracing_exporter", env: "TRACING_EXPORTER", flag: "trace".
+	TracingExporter string `env:"TRACING_EXPORTER" yaml:"tracing_exporter"`
+
+	// TracingEndpoint - OTLP gRPC collector address for "otlp" exporter.
+	// yaml: "tracing_endpoint", env: "TRACING_ENDPOINT", flag: "traceendpoint".
+	TracingEndpoint string `env:"TRACING_ENDPOINT" yaml:"tracing_endpoint"`
+
+	// Reflection - enables gRPC server reflection.
+	// yaml: "grpc_reflection", env: "GRPC_REFLECTION", flag: "reflection".
+	Reflection bool `env:"GRPC_REFLECTION" yaml:"grpc_reflection"`
+
+	// AdminAddress - listen address of administration service, service disabled if empty.
+	// yaml: "admin_address", env: "ADMIN_ADDRESS", flag: "admin".
+	AdminAddress string `env:"ADMIN_ADDRESS" yaml:"admin_address"`
+
+	// AdminLogin - administrator login, password authentication disabled if empty.
+	// yaml: "admin_login", env: "ADMIN_LOGIN", flag: "adminlogin".
+	AdminLogin string `env:"ADMIN_LOGIN" yaml:"admin_login"`
+
+	// AdminPassword - administrator password.
+	// yaml: "admin_password", env: "ADMIN_PASSWORD", flag: "adminpassword".
+	AdminPassword string `env:"ADMIN_PASSWORD" yaml:"admin_password"`
+
+	// AdminPasswordFile - file with administrator password, overrides AdminPassword.
+	// yaml: "admin_password_file", env: "ADMIN_PASSWORD_FILE", flag: "adminpasswordfile".
+	AdminPasswordFile string `env:"ADMIN_PASSWORD_FILE" yaml:"admin_password_file"`
+
+	// AdminClientTLSCA - CA bundle to verify administrator client certificates,
+	// certificate authentication disabled if empty.
+	// yaml: "admin_client_tls_ca", env: "ADMIN_CLIENT_TLS_CA", flag: "adminclientca".
+	AdminClientTLSCA string `env:"ADMIN_CLIENT_TLS_CA" yaml:"admin_client_tls_ca"`
+
+	// AdminClientNames - comma separated subject common names or DNS/email SANs of administrator
+	// client certificates, required with AdminClientTLSCA.
+	// yaml: "admin_client_names", env: "ADMIN_CLIENT_NAMES", flag: "adminclientnames".
+	AdminClientNames string `env:"ADMIN_CLIENT_NAMES" yaml:"admin_client_names"`
+
+	// QuotaBytes - default limit of user's secrets total size in bytes, unlimited if 0.
+	// yaml: "quota_bytes", env: "QUOTA_BYTES", flag: "quotabytes".
+	QuotaBytes int64 `env:"QUOTA_BYTES" yaml:"quota_bytes"`
+
+	// QuotaSecrets - default limit of user's secrets count, unlimited if 0.
+	// yaml: "quota_secrets", env: "QUOTA_SECRETS", flag: "quotasecrets".
+	QuotaSecrets int64 `env:"QUOTA_SECRETS" yaml:"quota_secrets"`
 }
 
-// LoadConfig loads server configuration from flags/env.
+// LoadConfig loads server configuration from config file/env/flags.
+// Secret values are read from files, if "*_FILE" settings are set.
 func LoadConfig(flagSet flag.FlagSet, flags []string) (*Config, error) {
 	var err error
 	config := &Config{}
 
 	// Flags
+	flagSet.StringVar(&config.ConfigFile, "config", _defaultConfigFile, "YAML config file")
 	flagSet.StringVar(&config.GRPCAddress, "a", _defaultConfigGRPCAddress, "gRPC address")
 	flagSet.StringVar(&config.GRPCServerTLSCert, "tlscert", _defaultConfigGRPCServerTLSCert, "gRPC server certificate")
 	flagSet.StringVar(&config.GRPCServerTLSKey, "tlskey", _defaultConfigGRPCServerTLSKey, "gRPC server certificate key")
+	flagSet.StringVar(&config.GRPCClientTLSCA, "tlsclientca", _defaultConfigGRPCClientTLSCA, "gRPC client certificates CA")
+	flagSet.BoolVar(&config.GRPCClientTLSRequire, "tlsclientrequire", _defaultConfigGRPCClientRequire, "require gRPC client certificate")
+	flagSet.BoolVar(&config.GRPCClientTLSBind, "tlsclientbind", _defaultConfigGRPCClientBind, "bind gRPC client certificate to user")
 	flagSet.StringVar(&config.DatabaseDsn, "d", _defaultConfigDatabaseDsn, "database dsn")
+	flagSet.StringVar(&config.DatabaseDsnFile, "dfile", _defaultConfigDatabaseDsnFile, "file with database dsn")
 	flagSet.StringVar(&config.LogLevel, "l", _defaultConfigLogLevel, "log level")
 	flagSet.StringVar(&config.ServerSecret, "s", _defaultConfigServerSecret, "server secret")
+	flagSet.StringVar(&config.ServerSecretFile, "sfile", _defaultConfigServerSecretFile, "file with server secret")
 	flagSet.DurationVar(&config.ShutdownTimeout, "t", _defaultConfigShutdownTimeout, "server shutdown timeout")
+	flagSet.StringVar(&config.MetricsAddress, "m", _defaultConfigMetricsAddress, "metrics HTTP address (disabled if empty)")
+	flagSet.StringVar(&config.TracingExporter, "trace", _defaultConfigTracingExporter, "tracing exporter: stdout or otlp (disabled if empty)")
+	flagSet.StringVar(&config.TracingEndpoint, "traceendpoint", _defaultConfigTracingEndpoint, "tracing OTLP collector address")
+	flagSet.BoolVar(&config.Reflection, "reflection", _defaultConfigReflection, "enable gRPC server reflection")
+	flagSet.StringVar(&config.AdminAddress, "admin", _defaultConfigAdminAddress, "admin service address (disabled if empty)")
+	flagSet.StringVar(&config.AdminLogin, "adminlogin", _defaultConfigAdminLogin, "admin login")
+	flagSet.StringVar(&config.AdminPassword, "adminpassword", _defaultConfigAdminPassword, "admin password")
+	flagSet.StringVar(&config.AdminPasswordFile, "adminpasswordfile", _defaultConfigAdminPasswordFile, "file with admin password")
+	flagSet.StringVar(&config.AdminClientTLSCA, "adminclientca", _defaultConfigAdminClientTLSCA, "admin client certificates CA")
+	flagSet.StringVar(&config.AdminClientNames, "adminclientnames", _defaultConfigAdminClientNames, "comma separated admin client certificate names")
+	flagSet.Int64Var(&config.QuotaBytes, "quotabytes", _defaultConfigQuotaBytes, "default user quota of secrets size in bytes (unlimited if 0)")
+	flagSet.Int64Var(&config.QuotaSecrets, "quotasecrets", _defaultConfigQuotaSecrets, "default user quota of secrets count (unlimited if 0)")
 
 	flagSet.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage of %s: [flags] | gen-client-cert [arguments]\n", os.Args[0])
 		flagSet.PrintDefaults()
+		fmt.Fprintln(os.Stderr, "Commands:")
+		fmt.Fprintln(os.Stderr, "  gen-client-cert -cacert <file> -cakey <file> -user <login> [-validity D] [-o <prefix>]")
+		fmt.Fprintln(os.Stderr, "Signals:")
+		fmt.Fprintln(os.Stderr, "  SIGHUP - reload settings: TLS certificate, log level and shutdown timeout")
 	}
 
-	_ = flagSet.Parse(flags)
+	if err = gkConfig.Load(&flagSet, flags, config, &config.ConfigFile, nil); err != nil {
+		return nil, err
+	}
 
-	// Check env
-	if err = env.Parse(config); err != nil {
+	// Secrets from files
+	if err = gkConfig.ReadSecretFile(&config.DatabaseDsn, config.DatabaseDsnFile, "database_dsn_file"); err != nil {
+		return nil, err
+	}
+	if err = gkConfig.ReadSecretFile(&config.ServerSecret, config.ServerSecretFile, "server_secret_file"); err != nil {
+		return nil, err
+	}
+	if err = gkConfig.ReadSecretFile(&config.AdminPassword, config.AdminPasswordFile, "admin_password_file"); err != nil {
 		return nil, err
 	}
 
 	return config, nil
 }
 
-// ServiceSettingsAdapt adapts flag/en configuration to server service settings internal format.
-// Returns error "errInvalidSettings" in case of invalid configuration.
+// ServiceSettingsAdapt adapts file/env/flag configuration to server service settings internal format.
+// Returns gkConfig.FieldError with name of invalid setting in case of invalid configuration.
 func ServiceSettingsAdapt(config *Config) (*server.ServiceSettings, error) {
 	grpcAddress, err := nettools.NewAddress(config.GRPCAddress)
 	if err != nil {
-		return nil, err
+		return nil, invalidSetting("grpc_address", err)
 	}
 
-	grpcServerTLS, err := gkTLS.NewServerSettings(config.GRPCServerTLSCert, config.GRPCServerTLSKey)
+	grpcServerTLS, err := gkTLS.NewServerSettings(
+		config.GRPCServerTLSCert,
+		config.GRPCServerTLSKey,
+		config.GRPCClientTLSCA,
+		config.GRPCClientTLSRequire,
+	)
 	if err != nil {
-		return nil, err
+		return nil, invalidSetting("grpc_server_tls", err)
+	}
+
+	if config.GRPCClientTLSBind && config.GRPCClientTLSCA == "" {
+		return nil, invalidSetting("grpc_client_tls_bind", errors.New("requires grpc_client_tls_ca"))
 	}
 
 	if config.DatabaseDsn == "" {
-		return nil, errInvalidSettings
+		return nil, invalidSetting("database_dsn", errors.New("must not be empty"))
 	}
 
 	if len(config.ServerSecret) != cipher.AESKeyLength {
-		return nil, errInvalidSettings
+		return nil, invalidSetting("server_secret", fmt.Errorf("must be %d chars length", cipher.AESKeyLength))
+	}
+
+	var metricsAddress *nettools.Address
+	if config.MetricsAddress != "" {
+		if metricsAddress, err = nettools.NewAddress(config.MetricsAddress); err != nil {
+			return nil, invalidSetting("metrics_address", err)
+		}
+	}
+
+	var tracingSettings *tracing.Settings
+	if config.TracingExporter != "" {
+		if tracingSettings, err = tracing.NewSettings(config.TracingExporter, config.TracingEndpoint); err != nil {
+			return nil, invalidSetting("tracing_exporter", err)
+		}
+	}
+
+	var adminSettings *server.AdminSettings
+	if config.AdminAddress != "" {
+		adminAddress, err := nettools.NewAddress(config.AdminAddress)
+		if err != nil {
+			return nil, invalidSetting("admin_address", err)
+		}
+
+		adminSettings, err = server.NewAdminSettings(
+			adminAddress,
+			config.AdminLogin,
+			config.AdminPassword,
+			config.AdminClientTLSCA,
+			splitNames(config.AdminClientNames),
+		)
+		if err != nil {
+			return nil, invalidSetting("admin_address", err)
+		}
+	}
+
+	if config.QuotaBytes < 0 {
+		return nil, invalidSetting("quota_bytes", errors.New("must not be negative"))
+	}
+
+	if config.QuotaSecrets < 0 {
+		return nil, invalidSetting("quota_secrets", errors.New("must not be negative"))
 	}
 
 	serverSettings := server.NewServiceSettings(
@@ -113,6 +290,28 @@ func ServiceSettingsAdapt(config *Config) (*server.ServiceSettings, error) {
 		config.DatabaseDsn,
 		config.ServerSecret,
 		config.ShutdownTimeout,
+		metricsAddress,
+		tracingSettings,
+		config.Reflection,
+		config.GRPCClientTLSBind,
+		adminSettings,
+		storage.Quota{MaxBytes: config.QuotaBytes, MaxSecrets: config.QuotaSecrets},
 	)
 	return serverSettings, nil
 }
+
+func invalidSetting(field string, err error) error {
+	return &gkConfig.FieldError{Field: field, Err: fmt.Errorf("%w: %v", errInvalidSettings, err)}
+}
+
+// splitNames splits comma separated names, skipping empty ones.
+func splitNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
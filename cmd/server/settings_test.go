@@ -3,10 +3,16 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	gkConfig "github.com/devldavydov/gophkeeper/internal/common/config"
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
+	"github.com/devldavydov/gophkeeper/internal/server/storage"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestServiceSettingsAdaptFromEnv(t *testing.T) {
@@ -17,6 +23,20 @@ func TestServiceSettingsAdaptFromEnv(t *testing.T) {
 	t.Setenv("LOG_LEVEL", "DEBUG")
 	t.Setenv("SERVER_SECRET", "asuperstrong32bitpasswordgohere!")
 	t.Setenv("SHUTDOWN_TIMEOUT", "1s")
+	t.Setenv("METRICS_ADDRESS", "127.0.0.1:9090")
+	t.Setenv("TRACING_EXPORTER", "otlp")
+	t.Setenv("TRACING_ENDPOINT", "127.0.0.1:4317")
+	t.Setenv("GRPC_REFLECTION", "true")
+	t.Setenv("GRPC_CLIENT_TLS_CA", "/tmp/client-ca.cert")
+	t.Setenv("GRPC_CLIENT_TLS_REQUIRE", "true")
+	t.Setenv("GRPC_CLIENT_TLS_BIND", "true")
+	t.Setenv("ADMIN_ADDRESS", "127.0.0.1:8081")
+	t.Setenv("ADMIN_LOGIN", "admin")
+	t.Setenv("ADMIN_PASSWORD", "secret")
+	t.Setenv("ADMIN_CLIENT_TLS_CA", "/tmp/admin-ca.cert")
+	t.Setenv("ADMIN_CLIENT_NAMES", "operator, admin.example.com")
+	t.Setenv("QUOTA_BYTES", "1024")
+	t.Setenv("QUOTA_SECRETS", "0")
 
 	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
 	config, err := LoadConfig(*testFlagSet, []string{})
@@ -31,6 +51,18 @@ func TestServiceSettingsAdaptFromEnv(t *testing.T) {
 	assert.Equal(t, "postgre:5432", serviceSettings.DatabaseDsn)
 	assert.Equal(t, "asuperstrong32bitpasswordgohere!", serviceSettings.ServerSecret)
 	assert.Equal(t, 1*time.Second, serviceSettings.ShutdownTimeout)
+	assert.Equal(t, "127.0.0.1:9090", serviceSettings.MetricsAddress.String())
+	assert.Equal(t, &tracing.Settings{Exporter: "otlp", OTLPEndpoint: "127.0.0.1:4317"}, serviceSettings.Tracing)
+	assert.True(t, serviceSettings.Reflection)
+	assert.Equal(t, "/tmp/client-ca.cert", serviceSettings.GRPCServerTLS.ClientCACertPath)
+	assert.True(t, serviceSettings.GRPCServerTLS.RequireClientCert)
+	assert.True(t, serviceSettings.BindClientCert)
+	assert.Equal(t, "127.0.0.1:8081", serviceSettings.Admin.Address.String())
+	assert.Equal(t, "admin", serviceSettings.Admin.Login)
+	assert.Equal(t, "secret", serviceSettings.Admin.Password)
+	assert.Equal(t, "/tmp/admin-ca.cert", serviceSettings.Admin.ClientCACertPath)
+	assert.Equal(t, []string{"operator", "admin.example.com"}, serviceSettings.Admin.ClientNames)
+	assert.Equal(t, storage.Quota{MaxBytes: 1024}, serviceSettings.DefaultQuota)
 }
 
 func TestServiceSettingsAdaptFromFlag(t *testing.T) {
@@ -43,6 +75,16 @@ func TestServiceSettingsAdaptFromFlag(t *testing.T) {
 		"-l", "DEBUG",
 		"-s", "asuperstrong32bitpasswordgohere!",
 		"-t", "1s",
+		"-m", "127.0.0.1:9090",
+		"-trace", "stdout",
+		"-reflection",
+		"-tlsclientca", "/tmp/client-ca.cert",
+		"-tlsclientbind",
+		"-admin", "127.0.0.1:8081",
+		"-adminclientca", "/tmp/admin-ca.cert",
+		"-adminclientnames", "operator",
+		"-quotabytes", "2048",
+		"-quotasecrets", "10",
 	})
 	assert.NoError(t, err)
 
@@ -55,6 +97,17 @@ func TestServiceSettingsAdaptFromFlag(t *testing.T) {
 	assert.Equal(t, "postgre:5432", serviceSettings.DatabaseDsn)
 	assert.Equal(t, "asuperstrong32bitpasswordgohere!", serviceSettings.ServerSecret)
 	assert.Equal(t, 1*time.Second, serviceSettings.ShutdownTimeout)
+	assert.Equal(t, "127.0.0.1:9090", serviceSettings.MetricsAddress.String())
+	assert.Equal(t, &tracing.Settings{Exporter: "stdout"}, serviceSettings.Tracing)
+	assert.True(t, serviceSettings.Reflection)
+	assert.Equal(t, "/tmp/client-ca.cert", serviceSettings.GRPCServerTLS.ClientCACertPath)
+	assert.False(t, serviceSettings.GRPCServerTLS.RequireClientCert)
+	assert.True(t, serviceSettings.BindClientCert)
+	assert.Equal(t, "127.0.0.1:8081", serviceSettings.Admin.Address.String())
+	assert.Equal(t, "", serviceSettings.Admin.Login)
+	assert.Equal(t, "/tmp/admin-ca.cert", serviceSettings.Admin.ClientCACertPath)
+	assert.Equal(t, []string{"operator"}, serviceSettings.Admin.ClientNames)
+	assert.Equal(t, storage.Quota{MaxBytes: 2048, MaxSecrets: 10}, serviceSettings.DefaultQuota)
 }
 
 func TestServiceSettingsAdaptWithDefault(t *testing.T) {
@@ -75,6 +128,13 @@ func TestServiceSettingsAdaptWithDefault(t *testing.T) {
 	assert.Equal(t, "postgre:5432", serviceSettings.DatabaseDsn)
 	assert.Equal(t, "GophKeeperSupaSecretKeyForCrypto", serviceSettings.ServerSecret)
 	assert.Equal(t, 10*time.Second, serviceSettings.ShutdownTimeout)
+	assert.Nil(t, serviceSettings.MetricsAddress)
+	assert.Nil(t, serviceSettings.Tracing)
+	assert.False(t, serviceSettings.Reflection)
+	assert.Equal(t, "", serviceSettings.GRPCServerTLS.ClientCACertPath)
+	assert.False(t, serviceSettings.BindClientCert)
+	assert.Nil(t, serviceSettings.Admin)
+	assert.Equal(t, storage.Quota{MaxBytes: 100 << 20, MaxSecrets: 1000}, serviceSettings.DefaultQuota)
 }
 
 func TestServiceSettingsAdaptError(t *testing.T) {
@@ -86,6 +146,22 @@ func TestServiceSettingsAdaptError(t *testing.T) {
 		{flags: []string{}, env: map[string]string{}},
 		{flags: []string{"-a", ""}, env: map[string]string{}},
 		{flags: []string{"-s", "123"}, env: map[string]string{}},
+		{
+			flags: []string{"-d", "postgre:5432", "-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-m", "9090"},
+			env:   map[string]string{},
+		},
+		{
+			flags: []string{"-d", "postgre:5432", "-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-trace", "jaeger"},
+			env:   map[string]string{},
+		},
+		{
+			flags: []string{"-d", "postgre:5432", "-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-tlsclientrequire"},
+			env:   map[string]string{},
+		},
+		{
+			flags: []string{"-d", "postgre:5432", "-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-tlsclientbind"},
+			env:   map[string]string{},
+		},
 		{
 			flags: []string{"-d", "", "-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key"},
 			env:   map[string]string{},
@@ -117,3 +193,87 @@ func TestServiceSettingsAdaptError(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceSettingsAdaptFromFile(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("asuperstrong32bitpasswordgohere!\n"), 0600))
+	dsnFile := filepath.Join(dir, "dsn")
+	require.NoError(t, os.WriteFile(dsnFile, []byte("postgre:5432"), 0600))
+	adminPasswordFile := filepath.Join(dir, "admin")
+	require.NoError(t, os.WriteFile(adminPasswordFile, []byte("secret\n"), 0600))
+
+	configFile := filepath.Join(dir, "server.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`
+grpc_address: 127.0.0.1:9000
+grpc_server_tls_cert: /tmp/tls.cert
+grpc_server_tls_key: /tmp/tls.key
+database_dsn_file: `+dsnFile+`
+server_secret_file: `+secretFile+`
+log_level: WARN
+shutdown_timeout: 3s
+grpc_reflection: true
+admin_address: 127.0.0.1:8081
+admin_login: admin
+admin_password_file: `+adminPasswordFile+`
+`), 0600))
+
+	t.Setenv("LOG_LEVEL", "ERROR")
+	t.Setenv("SHUTDOWN_TIMEOUT", "5s")
+
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	config, err := LoadConfig(*testFlagSet, []string{"-config", configFile, "-t", "7s"})
+	require.NoError(t, err)
+	assert.Equal(t, "ERROR", config.LogLevel)
+
+	serviceSettings, err := ServiceSettingsAdapt(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, "127.0.0.1:9000", serviceSettings.GRPCAddress.String())
+	assert.Equal(t, "/tmp/tls.cert", serviceSettings.GRPCServerTLS.ServerCertPath)
+	assert.Equal(t, "postgre:5432", serviceSettings.DatabaseDsn)
+	assert.Equal(t, "asuperstrong32bitpasswordgohere!", serviceSettings.ServerSecret)
+	assert.Equal(t, 7*time.Second, serviceSettings.ShutdownTimeout)
+	assert.True(t, serviceSettings.Reflection)
+	assert.Equal(t, "secret", serviceSettings.Admin.Password)
+}
+
+func TestServiceSettingsAdaptFieldError(t *testing.T) {
+	for _, tt := range []struct {
+		flags []string
+		field string
+	}{
+		{flags: []string{"-a", "", "-d", "postgre:5432"}, field: "grpc_address"},
+		{flags: []string{"-d", "postgre:5432"}, field: "grpc_server_tls"},
+		{flags: []string{"-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key"}, field: "database_dsn"},
+		{flags: []string{"-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-d", "postgre:5432", "-s", "123"}, field: "server_secret"},
+		{flags: []string{"-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-d", "postgre:5432", "-tlsclientbind"}, field: "grpc_client_tls_bind"},
+		{flags: []string{"-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-d", "postgre:5432", "-admin", "8081"}, field: "admin_address"},
+		{flags: []string{"-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-d", "postgre:5432", "-admin", "127.0.0.1:8081"}, field: "admin_address"},
+		{flags: []string{"-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-d", "postgre:5432", "-admin", "127.0.0.1:8081", "-adminclientca", "/tmp/ca.cert"}, field: "admin_address"},
+		{flags: []string{"-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-d", "postgre:5432", "-quotabytes", "-1"}, field: "quota_bytes"},
+		{flags: []string{"-tlscert", "/tmp/tls.cert", "-tlskey", "/tmp/tls.key", "-d", "postgre:5432", "-quotasecrets", "-1"}, field: "quota_secrets"},
+	} {
+		tt := tt
+		t.Run(tt.field, func(t *testing.T) {
+			testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+			config, err := LoadConfig(*testFlagSet, tt.flags)
+			require.NoError(t, err)
+
+			_, err = ServiceSettingsAdapt(config)
+			var fieldErr *gkConfig.FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, tt.field, fieldErr.Field)
+			assert.ErrorIs(t, err, errInvalidSettings)
+		})
+	}
+}
+
+func TestLoadConfigSecretFileError(t *testing.T) {
+	testFlagSet := flag.NewFlagSet("test", flag.ExitOnError)
+	_, err := LoadConfig(*testFlagSet, []string{"-sfile", "/tmp/not/exists"})
+
+	var fieldErr *gkConfig.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "server_secret_file", fieldErr.Field)
+}
@@ -0,0 +1,279 @@
+// Package admin contains command line interface of GophKeeper administration service.
+package admin
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	pb "github.com/devldavydov/gophkeeper/internal/grpc"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrNoCommand        = errors.New("no command")
+	ErrUnknownCommand   = errors.New("unknown command")
+	ErrMissingArgument  = errors.New("missing argument")
+	ErrDeleteNotConfirm = errors.New("user deletion not confirmed, use -yes")
+)
+
+type command func(ctx context.Context, args []string) error
+
+// CLI represents command line interface of administration service.
+type CLI struct {
+	clt    pb.AdminServiceClient
+	stdout io.Writer
+	stderr io.Writer
+	logger *logrus.Logger
+}
+
+// NewCLI creates new CLI object. Command results are written to stdout, usage errors to stderr.
+func NewCLI(clt pb.AdminServiceClient, stdout, stderr io.Writer, logger *logrus.Logger) *CLI {
+	return &CLI{clt: clt, stdout: stdout, stderr: stderr, logger: logger}
+}
+
+// Run executes command with arguments.
+//
+// First element of args is a command name, others are command arguments.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return ErrNoCommand
+	}
+
+	cmd, ok := c.commands()[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: %s (available: %s)", ErrUnknownCommand, args[0], strings.Join(c.commandNames(), ", "))
+	}
+
+	c.logger.Infof("run command [%s]", args[0])
+	return cmd(ctx, args[1:])
+}
+
+func (c *CLI) commands() map[string]command {
+	return map[string]command{
+		"users":   c.doUsers,
+		"disable": c.doDisable,
+		"enable":  c.doEnable,
+		"logout":  c.doLogout,
+		"stats":   c.doStats,
+		"quota":   c.doQuota,
+		"delete":  c.doDelete,
+	}
+}
+
+func (c *CLI) commandNames() []string {
+	names := make([]string, 0, len(c.commands()))
+	for name := range c.commands() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// doUsers prints table of all users.
+//
+// Usage: users.
+func (c *CLI) doUsers(ctx context.Context, _ []string) error {
+	resp, err := c.clt.ListUsers(ctx, &pb.Empty{})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tLOGIN\tSTATUS\tCREATED")
+	for _, user := range resp.Users {
+		userStatus := "active"
+		if user.Disabled {
+			userStatus = "disabled"
+		}
+		created := time.Unix(user.CreatedAt, 0).Format(time.DateTime)
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", user.Id, user.Login, userStatus, created)
+	}
+
+	return w.Flush()
+}
+
+// doDisable disables user. Disabled user can not login and use issued tokens.
+//
+// Usage: disable <login>.
+func (c *CLI) doDisable(ctx context.Context, args []string) error {
+	login, err := loginArg(args)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.clt.DisableUser(ctx, &pb.AdminUserRequest{Login: login}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "User %s disabled\n", login)
+	return nil
+}
+
+// doEnable enables disabled user.
+//
+// Usage: enable <login>.
+func (c *CLI) doEnable(ctx context.Context, args []string) error {
+	login, err := loginArg(args)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.clt.EnableUser(ctx, &pb.AdminUserRequest{Login: login}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "User %s enabled\n", login)
+	return nil
+}
+
+// doLogout revokes all issued user's tokens.
+//
+// Usage: logout <login>.
+func (c *CLI) doLogout(ctx context.Context, args []string) error {
+	login, err := loginArg(args)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.clt.ForceLogout(ctx, &pb.AdminUserRequest{Login: login}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "User %s logged out\n", login)
+	return nil
+}
+
+// doStats prints user's secrets count, total payload size and quota.
+//
+// Usage: stats <login>.
+func (c *CLI) doStats(ctx context.Context, args []string) error {
+	login, err := loginArg(args)
+	if err != nil {
+		return err
+	}
+
+	stats, err := c.clt.GetUserStats(ctx, &pb.AdminUserRequest{Login: login})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "User: %s\nSecrets: %d of %s\nSecrets size: %d of %s bytes\n",
+		stats.Login, stats.Secrets, formatLimit(stats.MaxSecrets), stats.SecretsBytes, formatLimit(stats.MaxBytes))
+	return nil
+}
+
+// doQuota sets user's quota or resets it to server default. Zero limit means unlimited,
+// not given limit keeps its current value.
+//
+// Usage: quota [-bytes N] [-secrets N] <login> | quota -reset <login>.
+func (c *CLI) doQuota(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("quota", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	maxBytes := flagSet.Int64("bytes", 0, "max total size of secrets in bytes (0 - unlimited)")
+	maxSecrets := flagSet.Int64("secrets", 0, "max count of secrets (0 - unlimited)")
+	reset := flagSet.Bool("reset", false, "reset to server default quota")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	login, err := loginArg(flagSet.Args())
+	if err != nil {
+		return err
+	}
+
+	setFlags := make(map[string]bool)
+	flagSet.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	if *reset {
+		if _, err = c.clt.SetUserQuota(ctx, &pb.AdminSetUserQuotaRequest{Login: login, ResetDefault: true}); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(c.stdout, "User %s quota reset to default\n", login)
+		return nil
+	}
+
+	if !setFlags["bytes"] && !setFlags["secrets"] {
+		return fmt.Errorf("%w: -bytes, -secrets or -reset", ErrMissingArgument)
+	}
+
+	if !setFlags["bytes"] || !setFlags["secrets"] {
+		stats, err := c.clt.GetUserStats(ctx, &pb.AdminUserRequest{Login: login})
+		if err != nil {
+			return err
+		}
+		if !setFlags["bytes"] {
+			*maxBytes = stats.MaxBytes
+		}
+		if !setFlags["secrets"] {
+			*maxSecrets = stats.MaxSecrets
+		}
+	}
+
+	_, err = c.clt.SetUserQuota(ctx, &pb.AdminSetUserQuotaRequest{
+		Login:      login,
+		MaxBytes:   *maxBytes,
+		MaxSecrets: *maxSecrets,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "User %s quota set: %s bytes, %s secrets\n",
+		login, formatLimit(*maxBytes), formatLimit(*maxSecrets))
+	return nil
+}
+
+// doDelete deletes user with all secrets. Deletion should be confirmed with flag.
+//
+// Usage: delete -yes <login>.
+func (c *CLI) doDelete(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("delete", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	confirm := flagSet.Bool("yes", false, "confirm deletion of user with all secrets")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	login, err := loginArg(flagSet.Args())
+	if err != nil {
+		return err
+	}
+
+	if !*confirm {
+		return ErrDeleteNotConfirm
+	}
+
+	if _, err = c.clt.DeleteUser(ctx, &pb.AdminUserRequest{Login: login}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stdout, "User %s deleted\n", login)
+	return nil
+}
+
+// formatLimit returns quota limit as string, zero limit is unlimited.
+func formatLimit(limit int64) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+
+	return strconv.FormatInt(limit, 10)
+}
+
+// loginArg returns user login, which should be the only command argument.
+func loginArg(args []string) (string, error) {
+	if len(args) != 1 || args[0] == "" {
+		return "", fmt.Errorf("%w: user login", ErrMissingArgument)
+	}
+
+	return args[0], nil
+}
@@ -0,0 +1,163 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/devldavydov/gophkeeper/internal/grpc"
+	"github.com/devldavydov/gophkeeper/internal/grpc/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const _testLogin = "alice"
+
+type CLISuite struct {
+	suite.Suite
+	gmckCtrl *gomock.Controller
+	cltMock  *mocks.MockAdminServiceClient
+	cli      *CLI
+	stdout   *bytes.Buffer
+}
+
+func (c *CLISuite) SetupTest() {
+	c.gmckCtrl = gomock.NewController(c.T())
+	c.cltMock = mocks.NewMockAdminServiceClient(c.gmckCtrl)
+	c.stdout = &bytes.Buffer{}
+	c.cli = NewCLI(c.cltMock, c.stdout, &bytes.Buffer{}, logrus.New())
+}
+
+func (c *CLISuite) TearDownTest() {
+	c.gmckCtrl.Finish()
+}
+
+func (c *CLISuite) TestRunUnknownCommand() {
+	c.ErrorIs(c.cli.Run(context.Background(), nil), ErrNoCommand)
+	c.ErrorIs(c.cli.Run(context.Background(), []string{"foobar"}), ErrUnknownCommand)
+}
+
+func (c *CLISuite) TestUsers() {
+	created := time.Date(2023, 6, 1, 10, 0, 0, 0, time.Local)
+	c.cltMock.EXPECT().ListUsers(gomock.Any(), &pb.Empty{}).Return(&pb.AdminListUsersResponse{
+		Users: []*pb.AdminUser{
+			{Id: 1, Login: "alice", CreatedAt: created.Unix()},
+			{Id: 2, Login: "bob", Disabled: true, CreatedAt: created.Unix()},
+		},
+	}, nil)
+
+	c.Require().NoError(c.cli.Run(context.Background(), []string{"users"}))
+	c.Equal(""+
+		"ID  LOGIN  STATUS    CREATED\n"+
+		"1   alice  active    2023-06-01 10:00:00\n"+
+		"2   bob    disabled  2023-06-01 10:00:00\n",
+		c.stdout.String())
+}
+
+func (c *CLISuite) TestUserCommands() {
+	req := &pb.AdminUserRequest{Login: _testLogin}
+
+	for _, tt := range []struct {
+		args   []string
+		fMock  func() *gomock.Call
+		expOut string
+	}{
+		{
+			args:   []string{"disable", _testLogin},
+			fMock:  func() *gomock.Call { return c.cltMock.EXPECT().DisableUser(gomock.Any(), req) },
+			expOut: "User alice disabled\n",
+		},
+		{
+			args:   []string{"enable", _testLogin},
+			fMock:  func() *gomock.Call { return c.cltMock.EXPECT().EnableUser(gomock.Any(), req) },
+			expOut: "User alice enabled\n",
+		},
+		{
+			args:   []string{"logout", _testLogin},
+			fMock:  func() *gomock.Call { return c.cltMock.EXPECT().ForceLogout(gomock.Any(), req) },
+			expOut: "User alice logged out\n",
+		},
+		{
+			args:   []string{"delete", "-yes", _testLogin},
+			fMock:  func() *gomock.Call { return c.cltMock.EXPECT().DeleteUser(gomock.Any(), req) },
+			expOut: "User alice deleted\n",
+		},
+	} {
+		tt := tt
+		c.Run(tt.args[0], func() {
+			c.stdout.Reset()
+			tt.fMock().Return(&pb.Empty{}, nil)
+			c.Require().NoError(c.cli.Run(context.Background(), tt.args))
+			c.Equal(tt.expOut, c.stdout.String())
+
+			tt.fMock().Return(nil, status.Error(codes.NotFound, "user not found"))
+			err := c.cli.Run(context.Background(), tt.args)
+			c.Equal(codes.NotFound, status.Code(err))
+
+			c.ErrorIs(c.cli.Run(context.Background(), tt.args[:1]), ErrMissingArgument)
+		})
+	}
+}
+
+func (c *CLISuite) TestStats() {
+	c.cltMock.EXPECT().
+		GetUserStats(gomock.Any(), &pb.AdminUserRequest{Login: _testLogin}).
+		Return(&pb.AdminUserStats{Login: _testLogin, Secrets: 3, SecretsBytes: 1024, MaxBytes: 4096}, nil)
+
+	c.Require().NoError(c.cli.Run(context.Background(), []string{"stats", _testLogin}))
+	c.Equal("User: alice\nSecrets: 3 of unlimited\nSecrets size: 1024 of 4096 bytes\n", c.stdout.String())
+}
+
+func (c *CLISuite) TestQuota() {
+	c.Run("set all limits", func() {
+		c.stdout.Reset()
+		c.cltMock.EXPECT().
+			SetUserQuota(gomock.Any(), &pb.AdminSetUserQuotaRequest{Login: _testLogin, MaxBytes: 2048, MaxSecrets: 10}).
+			Return(&pb.Empty{}, nil)
+
+		c.Require().NoError(c.cli.Run(
+			context.Background(), []string{"quota", "-bytes", "2048", "-secrets", "10", _testLogin}))
+		c.Equal("User alice quota set: 2048 bytes, 10 secrets\n", c.stdout.String())
+	})
+
+	c.Run("keep current limit", func() {
+		c.stdout.Reset()
+		gomock.InOrder(
+			c.cltMock.EXPECT().
+				GetUserStats(gomock.Any(), &pb.AdminUserRequest{Login: _testLogin}).
+				Return(&pb.AdminUserStats{Login: _testLogin, MaxBytes: 4096, MaxSecrets: 100}, nil),
+			c.cltMock.EXPECT().
+				SetUserQuota(gomock.Any(), &pb.AdminSetUserQuotaRequest{Login: _testLogin, MaxBytes: 4096}).
+				Return(&pb.Empty{}, nil),
+		)
+
+		c.Require().NoError(c.cli.Run(context.Background(), []string{"quota", "-secrets", "0", _testLogin}))
+		c.Equal("User alice quota set: 4096 bytes, unlimited secrets\n", c.stdout.String())
+	})
+
+	c.Run("reset", func() {
+		c.stdout.Reset()
+		c.cltMock.EXPECT().
+			SetUserQuota(gomock.Any(), &pb.AdminSetUserQuotaRequest{Login: _testLogin, ResetDefault: true}).
+			Return(&pb.Empty{}, nil)
+
+		c.Require().NoError(c.cli.Run(context.Background(), []string{"quota", "-reset", _testLogin}))
+		c.Equal("User alice quota reset to default\n", c.stdout.String())
+	})
+
+	c.Run("no limits", func() {
+		c.ErrorIs(c.cli.Run(context.Background(), []string{"quota", _testLogin}), ErrMissingArgument)
+	})
+}
+
+func (c *CLISuite) TestDeleteNotConfirmed() {
+	c.ErrorIs(c.cli.Run(context.Background(), []string{"delete", _testLogin}), ErrDeleteNotConfirm)
+}
+
+func TestCLISuite(t *testing.T) {
+	suite.Run(t, new(CLISuite))
+}
@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"errors"
+
+	"github.com/devldavydov/gophkeeper/internal/common/nettools"
+	gkTLS "github.com/devldavydov/gophkeeper/internal/common/tls"
+	"github.com/devldavydov/gophkeeper/internal/grpc/interceptor"
+	"google.golang.org/grpc"
+)
+
+const _userAgent = "gophkeeper-admin"
+
+// Settings represents administration service connection settings.
+type Settings struct {
+	ServerAddress *nettools.Address
+	TLSCACertPath string
+	TLSCertPath   string
+	TLSKeyPath    string
+	Login         string
+	Password      string
+}
+
+// NewSettings creates new Settings object.
+//
+// Administrator is authenticated with client certificate, if tlsCertPath is set,
+// and with login and password, if login is set. At least one of them is required.
+func NewSettings(
+	serverAddress *nettools.Address,
+	tlsCACertPath, tlsCertPath, tlsKeyPath string,
+	login, password string,
+) (*Settings, error) {
+	if tlsCertPath == "" && login == "" {
+		return nil, errors.New("invalid admin settings: client certificate or login required")
+	}
+
+	return &Settings{
+		ServerAddress: serverAddress,
+		TLSCACertPath: tlsCACertPath,
+		TLSCertPath:   tlsCertPath,
+		TLSKeyPath:    tlsKeyPath,
+		Login:         login,
+		Password:      password,
+	}, nil
+}
+
+// Dial creates connection to administration service.
+func Dial(settings *Settings) (*grpc.ClientConn, error) {
+	tlsCredentials, err := gkTLS.LoadClientCert(
+		settings.TLSCACertPath,
+		"",
+		settings.TLSCertPath,
+		settings.TLSKeyPath,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(tlsCredentials),
+		grpc.WithUserAgent(_userAgent),
+	}
+	if settings.Login != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(interceptor.NewBasicCredentials(settings.Login, settings.Password)))
+	}
+
+	return grpc.Dial(settings.ServerAddress.String(), opts...)
+}
@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/common/nettools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSettings(t *testing.T) {
+	address, err := nettools.NewAddress("127.0.0.1:8081")
+	require.NoError(t, err)
+
+	_, err = NewSettings(address, "/tmp/ca.pem", "", "", "", "")
+	assert.Error(t, err)
+
+	settings, err := NewSettings(address, "/tmp/ca.pem", "", "", "admin", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", settings.Login)
+
+	settings, err = NewSettings(address, "/tmp/ca.pem", "/tmp/cert.pem", "/tmp/key.pem", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/cert.pem", settings.TLSCertPath)
+}
+
+func TestDialInvalidCACert(t *testing.T) {
+	address, err := nettools.NewAddress("127.0.0.1:8081")
+	require.NoError(t, err)
+	settings, err := NewSettings(address, "/tmp/not/exists.pem", "", "", "admin", "secret")
+	require.NoError(t, err)
+
+	_, err = Dial(settings)
+	assert.Error(t, err)
+}
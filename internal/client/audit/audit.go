@@ -0,0 +1,244 @@
+// Package audit contains security audit of user's vault.
+//
+// Audit is made on client side, server never sees decrypted payloads.
+package audit
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/passgen"
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/vault"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+var ErrInvalidValidThru = errors.New("invalid card valid thru date")
+
+const (
+	DefaultMaxPasswordAge    = 180 * 24 * time.Hour
+	DefaultCardExpiryWarning = 60 * 24 * time.Hour
+)
+
+// IssueType is a type of found security issue.
+type IssueType string
+
+const (
+	IssueReusedPassword    IssueType = "reused_password"
+	IssueWeakPassword      IssueType = "weak_password"
+	IssueOldPassword       IssueType = "old_password"
+	IssueCardExpired       IssueType = "card_expired"
+	IssueCardExpiring      IssueType = "card_expiring"
+	IssueCardInvalidExpiry IssueType = "card_invalid_expiry"
+)
+
+// Issue represents single security issue of secret.
+type Issue struct {
+	Secret  string    `json:"secret"`
+	Type    IssueType `json:"type"`
+	Details string    `json:"details"`
+}
+
+// Report represents result of vault audit.
+type Report struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Checked     int       `json:"checked"`
+	Issues      []Issue   `json:"issues"`
+}
+
+// Options defines audit thresholds.
+type Options struct {
+	// MaxPasswordAge - password, not changed longer, is reported as old. Zero disables check.
+	MaxPasswordAge time.Duration
+	// CardExpiryWarning - card, expiring within this period, is reported.
+	CardExpiryWarning time.Duration
+	// MinScore - password with lower strength score is reported as weak.
+	MinScore passgen.Score
+	// Now - audit time. If zero, current time is used.
+	Now time.Time
+}
+
+// DefaultOptions returns default audit options.
+func DefaultOptions() Options {
+	return Options{
+		MaxPasswordAge:    DefaultMaxPasswordAge,
+		CardExpiryWarning: DefaultCardExpiryWarning,
+		MinScore:          passgen.ScoreFair,
+	}
+}
+
+// Item represents decrypted secret to audit.
+type Item struct {
+	Info    model.SecretInfo
+	Payload model.Payload
+}
+
+// Run reads user's credentials and cards from server and audits them.
+//
+// Returns audit report or transport error.
+func Run(tr transport.Transport, token string, opts Options) (*Report, error) {
+	items := make([]Item, 0)
+	fnItem := func(secretInfo model.SecretInfo, _ *model.Secret, payload model.Payload) error {
+		items = append(items, Item{Info: secretInfo, Payload: payload})
+		return nil
+	}
+
+	if err := vault.ForEachSecret(tr, token, vault.ByType(model.CredsSecret, model.CardSecret), fnItem); err != nil {
+		return nil, err
+	}
+
+	return Analyze(items, opts), nil
+}
+
+// Analyze audits decrypted secrets.
+//
+// Credentials are checked for reused, weak and old passwords,
+// cards are checked for expiration.
+func Analyze(items []Item, opts Options) *Report {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	report := &Report{GeneratedAt: now, Issues: make([]Issue, 0)}
+
+	// Group credentials by password hash to find reused ones
+	reused := make(map[string][]string)
+	for _, item := range items {
+		if creds, ok := item.Payload.(*model.CredsPayload); ok && creds.Password != "" {
+			hash := passwordHash(creds.Password)
+			reused[hash] = append(reused[hash], item.Info.Name)
+		}
+	}
+
+	for _, item := range items {
+		switch payload := item.Payload.(type) {
+		case *model.CredsPayload:
+			report.Checked++
+			report.Issues = append(report.Issues, checkCreds(item.Info, payload, reused, now, opts)...)
+		case *model.CardPayload:
+			report.Checked++
+			report.Issues = append(report.Issues, checkCard(item.Info, payload, now, opts)...)
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].Secret < report.Issues[j].Secret
+	})
+
+	return report
+}
+
+func checkCreds(
+	info model.SecretInfo,
+	creds *model.CredsPayload,
+	reused map[string][]string,
+	now time.Time,
+	opts Options,
+) []Issue {
+	var issues []Issue
+
+	if creds.Password != "" {
+		var others []string
+		for _, name := range reused[passwordHash(creds.Password)] {
+			if name != info.Name {
+				others = append(others, name)
+			}
+		}
+		if len(others) > 0 {
+			issues = append(issues, Issue{
+				Secret:  info.Name,
+				Type:    IssueReusedPassword,
+				Details: fmt.Sprintf("same password used in: %s", strings.Join(others, ", ")),
+			})
+		}
+	}
+
+	if strength := passgen.EstimateStrength(creds.Password, creds.Login); strength.Score < opts.MinScore {
+		issues = append(issues, Issue{
+			Secret:  info.Name,
+			Type:    IssueWeakPassword,
+			Details: fmt.Sprintf("password strength: %s", strength),
+		})
+	}
+
+	// Age is counted from last payload change, meta-only edits don't change password
+	age := now.Sub(info.PayloadUpdatedAt)
+	if opts.MaxPasswordAge > 0 && !info.PayloadUpdatedAt.IsZero() && age > opts.MaxPasswordAge {
+		issues = append(issues, Issue{
+			Secret:  info.Name,
+			Type:    IssueOldPassword,
+			Details: fmt.Sprintf("password not changed for %d days", int(age/(24*time.Hour))),
+		})
+	}
+
+	return issues
+}
+
+func checkCard(info model.SecretInfo, card *model.CardPayload, now time.Time, opts Options) []Issue {
+	expiresAt, err := ParseValidThru(card.ValidThru)
+	if err != nil {
+		return []Issue{{
+			Secret:  info.Name,
+			Type:    IssueCardInvalidExpiry,
+			Details: fmt.Sprintf("can't parse valid thru [%s], expected MM/YY", card.ValidThru),
+		}}
+	}
+
+	if !now.Before(expiresAt) {
+		return []Issue{{
+			Secret:  info.Name,
+			Type:    IssueCardExpired,
+			Details: fmt.Sprintf("card expired on %s", expiresAt.Format("2006-01-02")),
+		}}
+	}
+
+	if expiresAt.Sub(now) <= opts.CardExpiryWarning {
+		return []Issue{{
+			Secret:  info.Name,
+			Type:    IssueCardExpiring,
+			Details: fmt.Sprintf("card expires on %s", expiresAt.Format("2006-01-02")),
+		}}
+	}
+
+	return nil
+}
+
+// ParseValidThru parses card valid thru date in MM/YY or MM/YYYY format.
+// Card is valid through the last day of month.
+//
+// Returns first moment, when card is not valid, or ErrInvalidValidThru.
+func ParseValidThru(validThru string) (time.Time, error) {
+	parts := strings.Split(strings.TrimSpace(validThru), "/")
+	if len(parts) != 2 {
+		return time.Time{}, ErrInvalidValidThru
+	}
+
+	month, err := strconv.Atoi(parts[0])
+	if err != nil || month < 1 || month > 12 {
+		return time.Time{}, ErrInvalidValidThru
+	}
+
+	year, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return time.Time{}, ErrInvalidValidThru
+	}
+	switch len(parts[1]) {
+	case 2:
+		year += 2000
+	case 4:
+	default:
+		return time.Time{}, ErrInvalidValidThru
+	}
+
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.Local), nil
+}
+
+func passwordHash(password string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(password)))
+}
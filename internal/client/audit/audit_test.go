@@ -0,0 +1,183 @@
+package audit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/transport/mocks"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+)
+
+const _testStrongPassword = "Ofq7#xLt2!pWz9$Rk"
+
+var _testNow = time.Date(2023, time.May, 15, 12, 0, 0, 0, time.Local)
+
+func TestAnalyze(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Now = _testNow
+
+	items := []Item{
+		newItem(model.CredsSecret, "bank", 10, model.NewCredsPayload("john", _testStrongPassword)),
+		newItem(model.CredsSecret, "mail", 10, model.NewCredsPayload("john", _testStrongPassword)),
+		newItem(model.CredsSecret, "forum", 1, model.NewCredsPayload("john", "password")),
+		newItem(model.CredsSecret, "old", 365, model.NewCredsPayload("john", "Zk4!vQ9@bN2#mL7$")),
+		newItem(model.CardSecret, "expired", 1, model.NewCardPayload("2202", "JOHN", "04/23", "123")),
+		newItem(model.CardSecret, "expiring", 1, model.NewCardPayload("2202", "JOHN", "06/2023", "123")),
+		newItem(model.CardSecret, "valid", 1, model.NewCardPayload("2202", "JOHN", "12/30", "123")),
+		newItem(model.CardSecret, "invalid", 1, model.NewCardPayload("2202", "JOHN", "foo", "123")),
+		newItem(model.TextSecret, "text", 1, model.NewTextPayload("password")),
+	}
+
+	report := Analyze(items, opts)
+	assert.Equal(t, _testNow, report.GeneratedAt)
+	assert.Equal(t, 8, report.Checked)
+
+	var found []string
+	for _, issue := range report.Issues {
+		found = append(found, fmt.Sprintf("%s:%s", issue.Secret, issue.Type))
+	}
+	assert.Equal(t, []string{
+		"bank:reused_password",
+		"expired:card_expired",
+		"expiring:card_expiring",
+		"forum:weak_password",
+		"invalid:card_invalid_expiry",
+		"mail:reused_password",
+		"old:old_password",
+	}, found)
+
+	assert.Equal(t, "same password used in: mail", report.Issues[0].Details)
+	assert.Equal(t, "password not changed for 365 days", report.Issues[6].Details)
+}
+
+func TestAnalyzeDisabledAgeCheck(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Now = _testNow
+	opts.MaxPasswordAge = 0
+
+	report := Analyze([]Item{
+		newItem(model.CredsSecret, "old", 1000, model.NewCredsPayload("john", _testStrongPassword)),
+	}, opts)
+	assert.Empty(t, report.Issues)
+}
+
+func TestAnalyzeMetaUpdateKeepsAge(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Now = _testNow
+
+	item := newItem(model.CredsSecret, "old", 365, model.NewCredsPayload("john", _testStrongPassword))
+	item.Info.UpdatedAt = _testNow
+
+	report := Analyze([]Item{item}, opts)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, IssueOldPassword, report.Issues[0].Type)
+	assert.Equal(t, "password not changed for 365 days", report.Issues[0].Details)
+}
+
+func TestParseValidThru(t *testing.T) {
+	for i, tt := range []struct {
+		validThru string
+		expTime   time.Time
+		expErr    error
+	}{
+		{validThru: "01/25", expTime: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.Local)},
+		{validThru: "12/2025", expTime: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.Local)},
+		{validThru: " 7/30 ", expTime: time.Date(2030, time.August, 1, 0, 0, 0, 0, time.Local)},
+		{validThru: "", expErr: ErrInvalidValidThru},
+		{validThru: "13/25", expErr: ErrInvalidValidThru},
+		{validThru: "01/225", expErr: ErrInvalidValidThru},
+		{validThru: "01-25", expErr: ErrInvalidValidThru},
+		{validThru: "ab/cd", expErr: ErrInvalidValidThru},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Run %d", i), func(t *testing.T) {
+			expTime, err := ParseValidThru(tt.validThru)
+			if tt.expErr != nil {
+				assert.ErrorIs(t, err, tt.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expTime, expTime)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	gmckCtrl := gomock.NewController(t)
+	defer gmckCtrl.Finish()
+	trMock := mocks.NewMockTransport(gmckCtrl)
+
+	opts := DefaultOptions()
+	opts.Now = _testNow
+
+	gomock.InOrder(
+		trMock.EXPECT().SecretGetList("token").Return([]model.SecretInfo{
+			{Type: model.CredsSecret, Name: "creds", Version: 1, UpdatedAt: _testNow},
+			{Type: model.CredsSecret, Name: "deleted", Version: 1, UpdatedAt: _testNow},
+			{Type: model.TextSecret, Name: "text", Version: 1, UpdatedAt: _testNow},
+		}, nil),
+		trMock.EXPECT().SecretGet("token", "creds").
+			Return(newSecret(t, model.CredsSecret, "creds", model.NewCredsPayload("john", "qwerty")), nil),
+		trMock.EXPECT().SecretGet("token", "deleted").Return(nil, transport.ErrSecretNotFound),
+	)
+
+	report, err := Run(trMock, "token", opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Checked)
+	assert.Equal(t, []Issue{{
+		Secret:  "creds",
+		Type:    IssueWeakPassword,
+		Details: "password strength: Very weak (crack time: instant)",
+	}}, report.Issues)
+}
+
+func TestRunEmptyVault(t *testing.T) {
+	gmckCtrl := gomock.NewController(t)
+	defer gmckCtrl.Finish()
+	trMock := mocks.NewMockTransport(gmckCtrl)
+
+	trMock.EXPECT().SecretGetList("token").Return(make([]model.SecretInfo, 0), nil)
+
+	report, err := Run(trMock, "token", DefaultOptions())
+	require.NoError(t, err)
+	assert.Zero(t, report.Checked)
+	assert.Empty(t, report.Issues)
+}
+
+func TestRunError(t *testing.T) {
+	gmckCtrl := gomock.NewController(t)
+	defer gmckCtrl.Finish()
+	trMock := mocks.NewMockTransport(gmckCtrl)
+
+	trMock.EXPECT().SecretGetList("token").Return(nil, transport.ErrUserPermissionDenied)
+
+	_, err := Run(trMock, "token", DefaultOptions())
+	assert.ErrorIs(t, err, transport.ErrUserPermissionDenied)
+}
+
+func newItem(secretType model.SecretType, name string, ageDays int, payload model.Payload) Item {
+	updatedAt := _testNow.Add(-time.Duration(ageDays) * 24 * time.Hour)
+	return Item{
+		Info: model.SecretInfo{
+			Type:             secretType,
+			Name:             name,
+			Version:          1,
+			UpdatedAt:        updatedAt,
+			PayloadUpdatedAt: updatedAt,
+		},
+		Payload: payload,
+	}
+}
+
+func newSecret(t *testing.T, secretType model.SecretType, name string, payload msgp.Encodable) *model.Secret {
+	payloadRaw, err := gkMsgp.Serialize(payload)
+	require.NoError(t, err)
+	return &model.Secret{Type: secretType, Name: name, Version: 1, PayloadRaw: payloadRaw}
+}
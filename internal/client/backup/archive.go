@@ -0,0 +1,39 @@
+package backup
+
+import "time"
+
+//go:generate msgp -tests=false
+
+// Archive represents decrypted content of vault export file.
+type Archive struct {
+	// CreatedAt - export time as unix timestamp.
+	CreatedAt int64
+	// Manifest - description of every exported secret.
+	Manifest []ManifestEntry
+	// Payloads - raw secret payloads by secret name.
+	Payloads map[string][]byte
+}
+
+// ManifestEntry represents exported secret description.
+//
+// ExpiresAt (unix timestamp) and RotateEvery (seconds) are zero, if not set.
+type ManifestEntry struct {
+	Name        string
+	Type        int32
+	Version     int64
+	Meta        string
+	Checksum    string
+	ExpiresAt   int64
+	RotateEvery int64
+}
+
+func (e *ManifestEntry) expiresAt() time.Time {
+	if e.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(e.ExpiresAt, 0)
+}
+
+func (e *ManifestEntry) rotateEvery() time.Duration {
+	return time.Duration(e.RotateEvery) * time.Second
+}
@@ -0,0 +1,527 @@
+package backup
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// DecodeMsg implements msgp.Decodable
+func (z *Archive) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "CreatedAt":
+			z.CreatedAt, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "CreatedAt")
+				return
+			}
+		case "Manifest":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Manifest")
+				return
+			}
+			if cap(z.Manifest) >= int(zb0002) {
+				z.Manifest = (z.Manifest)[:zb0002]
+			} else {
+				z.Manifest = make([]ManifestEntry, zb0002)
+			}
+			for za0001 := range z.Manifest {
+				err = z.Manifest[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Manifest", za0001)
+					return
+				}
+			}
+		case "Payloads":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Payloads")
+				return
+			}
+			if z.Payloads == nil {
+				z.Payloads = make(map[string][]byte, zb0003)
+			} else if len(z.Payloads) > 0 {
+				for key := range z.Payloads {
+					delete(z.Payloads, key)
+				}
+			}
+			for zb0003 > 0 {
+				zb0003--
+				var za0002 string
+				var za0003 []byte
+				za0002, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Payloads")
+					return
+				}
+				za0003, err = dc.ReadBytes(za0003)
+				if err != nil {
+					err = msgp.WrapError(err, "Payloads", za0002)
+					return
+				}
+				z.Payloads[za0002] = za0003
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *Archive) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "CreatedAt"
+	err = en.Append(0x83, 0xa9, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.CreatedAt)
+	if err != nil {
+		err = msgp.WrapError(err, "CreatedAt")
+		return
+	}
+	// write "Manifest"
+	err = en.Append(0xa8, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Manifest)))
+	if err != nil {
+		err = msgp.WrapError(err, "Manifest")
+		return
+	}
+	for za0001 := range z.Manifest {
+		err = z.Manifest[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Manifest", za0001)
+			return
+		}
+	}
+	// write "Payloads"
+	err = en.Append(0xa8, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.Payloads)))
+	if err != nil {
+		err = msgp.WrapError(err, "Payloads")
+		return
+	}
+	for za0002, za0003 := range z.Payloads {
+		err = en.WriteString(za0002)
+		if err != nil {
+			err = msgp.WrapError(err, "Payloads")
+			return
+		}
+		err = en.WriteBytes(za0003)
+		if err != nil {
+			err = msgp.WrapError(err, "Payloads", za0002)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Archive) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "CreatedAt"
+	o = append(o, 0x83, 0xa9, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74)
+	o = msgp.AppendInt64(o, z.CreatedAt)
+	// string "Manifest"
+	o = append(o, 0xa8, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Manifest)))
+	for za0001 := range z.Manifest {
+		o, err = z.Manifest[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Manifest", za0001)
+			return
+		}
+	}
+	// string "Payloads"
+	o = append(o, 0xa8, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Payloads)))
+	for za0002, za0003 := range z.Payloads {
+		o = msgp.AppendString(o, za0002)
+		o = msgp.AppendBytes(o, za0003)
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Archive) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "CreatedAt":
+			z.CreatedAt, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CreatedAt")
+				return
+			}
+		case "Manifest":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Manifest")
+				return
+			}
+			if cap(z.Manifest) >= int(zb0002) {
+				z.Manifest = (z.Manifest)[:zb0002]
+			} else {
+				z.Manifest = make([]ManifestEntry, zb0002)
+			}
+			for za0001 := range z.Manifest {
+				bts, err = z.Manifest[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Manifest", za0001)
+					return
+				}
+			}
+		case "Payloads":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Payloads")
+				return
+			}
+			if z.Payloads == nil {
+				z.Payloads = make(map[string][]byte, zb0003)
+			} else if len(z.Payloads) > 0 {
+				for key := range z.Payloads {
+					delete(z.Payloads, key)
+				}
+			}
+			for zb0003 > 0 {
+				var za0002 string
+				var za0003 []byte
+				zb0003--
+				za0002, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Payloads")
+					return
+				}
+				za0003, bts, err = msgp.ReadBytesBytes(bts, za0003)
+				if err != nil {
+					err = msgp.WrapError(err, "Payloads", za0002)
+					return
+				}
+				z.Payloads[za0002] = za0003
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Archive) Msgsize() (s int) {
+	s = 1 + 10 + msgp.Int64Size + 9 + msgp.ArrayHeaderSize
+	for za0001 := range z.Manifest {
+		s += z.Manifest[za0001].Msgsize()
+	}
+	s += 9 + msgp.MapHeaderSize
+	if z.Payloads != nil {
+		for za0002, za0003 := range z.Payloads {
+			_ = za0003
+			s += msgp.StringPrefixSize + len(za0002) + msgp.BytesPrefixSize + len(za0003)
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *ManifestEntry) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Name":
+			z.Name, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Name")
+				return
+			}
+		case "Type":
+			z.Type, err = dc.ReadInt32()
+			if err != nil {
+				err = msgp.WrapError(err, "Type")
+				return
+			}
+		case "Version":
+			z.Version, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Version")
+				return
+			}
+		case "Meta":
+			z.Meta, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Meta")
+				return
+			}
+		case "Checksum":
+			z.Checksum, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Checksum")
+				return
+			}
+		case "ExpiresAt":
+			z.ExpiresAt, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "ExpiresAt")
+				return
+			}
+		case "RotateEvery":
+			z.RotateEvery, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "RotateEvery")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *ManifestEntry) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 7
+	// write "Name"
+	err = en.Append(0x87, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Name)
+	if err != nil {
+		err = msgp.WrapError(err, "Name")
+		return
+	}
+	// write "Type"
+	err = en.Append(0xa4, 0x54, 0x79, 0x70, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt32(z.Type)
+	if err != nil {
+		err = msgp.WrapError(err, "Type")
+		return
+	}
+	// write "Version"
+	err = en.Append(0xa7, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Version)
+	if err != nil {
+		err = msgp.WrapError(err, "Version")
+		return
+	}
+	// write "Meta"
+	err = en.Append(0xa4, 0x4d, 0x65, 0x74, 0x61)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Meta)
+	if err != nil {
+		err = msgp.WrapError(err, "Meta")
+		return
+	}
+	// write "Checksum"
+	err = en.Append(0xa8, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Checksum)
+	if err != nil {
+		err = msgp.WrapError(err, "Checksum")
+		return
+	}
+	// write "ExpiresAt"
+	err = en.Append(0xa9, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.ExpiresAt)
+	if err != nil {
+		err = msgp.WrapError(err, "ExpiresAt")
+		return
+	}
+	// write "RotateEvery"
+	err = en.Append(0xab, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x72, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.RotateEvery)
+	if err != nil {
+		err = msgp.WrapError(err, "RotateEvery")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ManifestEntry) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 7
+	// string "Name"
+	o = append(o, 0x87, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.Name)
+	// string "Type"
+	o = append(o, 0xa4, 0x54, 0x79, 0x70, 0x65)
+	o = msgp.AppendInt32(o, z.Type)
+	// string "Version"
+	o = append(o, 0xa7, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendInt64(o, z.Version)
+	// string "Meta"
+	o = append(o, 0xa4, 0x4d, 0x65, 0x74, 0x61)
+	o = msgp.AppendString(o, z.Meta)
+	// string "Checksum"
+	o = append(o, 0xa8, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d)
+	o = msgp.AppendString(o, z.Checksum)
+	// string "ExpiresAt"
+	o = append(o, 0xa9, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74)
+	o = msgp.AppendInt64(o, z.ExpiresAt)
+	// string "RotateEvery"
+	o = append(o, 0xab, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x72, 0x79)
+	o = msgp.AppendInt64(o, z.RotateEvery)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ManifestEntry) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Name":
+			z.Name, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Name")
+				return
+			}
+		case "Type":
+			z.Type, bts, err = msgp.ReadInt32Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Type")
+				return
+			}
+		case "Version":
+			z.Version, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Version")
+				return
+			}
+		case "Meta":
+			z.Meta, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Meta")
+				return
+			}
+		case "Checksum":
+			z.Checksum, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Checksum")
+				return
+			}
+		case "ExpiresAt":
+			z.ExpiresAt, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ExpiresAt")
+				return
+			}
+		case "RotateEvery":
+			z.RotateEvery, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RotateEvery")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ManifestEntry) Msgsize() (s int) {
+	s = 1 + 5 + msgp.StringPrefixSize + len(z.Name) + 5 + msgp.Int32Size + 8 + msgp.Int64Size + 5 + msgp.StringPrefixSize + len(z.Meta) + 9 + msgp.StringPrefixSize + len(z.Checksum) + 10 + msgp.Int64Size + 12 + msgp.Int64Size
+	return
+}
@@ -0,0 +1,301 @@
+// Package backup contains encrypted export and restore of user's vault.
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/vault"
+	"github.com/devldavydov/gophkeeper/internal/common/cipher"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+)
+
+var (
+	ErrWrongFormat        = errors.New("wrong backup file format")
+	ErrWrongPassphrase    = errors.New("wrong passphrase or backup file corrupted")
+	ErrChecksumMismatch   = errors.New("secret checksum mismatch")
+	ErrUnknownRestoreMode = errors.New("unknown restore mode")
+)
+
+const _fileMagic = "GKVAULT1"
+
+// RestoreMode defines what to do, when restored secret already exists in vault.
+type RestoreMode int
+
+const (
+	// RestoreSkip - keep existing secret untouched.
+	RestoreSkip RestoreMode = iota
+	// RestoreOverwrite - replace existing secret with restored one.
+	RestoreOverwrite
+	// RestoreRename - create restored secret under new name.
+	RestoreRename
+)
+
+// ParseRestoreMode converts string to RestoreMode.
+//
+// Returns ErrUnknownRestoreMode for unknown string.
+func ParseRestoreMode(s string) (RestoreMode, error) {
+	switch s {
+	case "skip":
+		return RestoreSkip, nil
+	case "overwrite":
+		return RestoreOverwrite, nil
+	case "rename":
+		return RestoreRename, nil
+	default:
+		return RestoreSkip, fmt.Errorf("%w: %s", ErrUnknownRestoreMode, s)
+	}
+}
+
+// RestoreAction describes what was done with restored secret.
+type RestoreAction string
+
+const (
+	ActionCreated     RestoreAction = "created"
+	ActionSkipped     RestoreAction = "skipped"
+	ActionOverwritten RestoreAction = "overwritten"
+	ActionRenamed     RestoreAction = "renamed"
+)
+
+// RestoreItem represents result of single secret restore.
+type RestoreItem struct {
+	Name       string
+	RestoredAs string
+	Action     RestoreAction
+}
+
+// Export reads all user's secrets from server and writes them to w,
+// encrypted with key derived from passphrase.
+//
+// Returns manifest of exported secrets or error.
+func Export(tr transport.Transport, token, passphrase string, w io.Writer) ([]ManifestEntry, error) {
+	archive := &Archive{
+		CreatedAt: time.Now().Unix(),
+		Manifest:  make([]ManifestEntry, 0),
+		Payloads:  make(map[string][]byte),
+	}
+
+	fnExport := func(_ model.SecretInfo, secret *model.Secret, payload model.Payload) error {
+		archive.Manifest = append(archive.Manifest, ManifestEntry{
+			Name:        secret.Name,
+			Type:        int32(secret.Type),
+			Version:     secret.Version,
+			Meta:        secret.Meta,
+			Checksum:    payload.GetHash(),
+			ExpiresAt:   toUnix(secret.ExpiresAt),
+			RotateEvery: int64(secret.RotateEvery / time.Second),
+		})
+		archive.Payloads[secret.Name] = secret.PayloadRaw
+		return nil
+	}
+
+	if err := vault.ForEachSecret(tr, token, nil, fnExport); err != nil {
+		return nil, err
+	}
+
+	plainData, err := gkMsgp.Serialize(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	salt, err := cipher.NewSalt()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := cipher.KeyFromPassphrase(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherData, err := cipher.AESGCMEncrypt(plainData, key)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, data := range [][]byte{[]byte(_fileMagic), salt, cipherData} {
+		if _, err = w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+
+	return archive.Manifest, nil
+}
+
+// Restore reads exported vault from r, decrypts it with passphrase,
+// verifies every secret and creates secrets on server.
+//
+// Existing secrets are handled according to mode. If secret exists with another type,
+// it can't be overwritten and is restored under new name.
+//
+// Returns result for every secret or error:
+//
+// - ErrWrongFormat - input is not a vault export.
+//
+// - ErrWrongPassphrase - wrong passphrase or input was modified.
+//
+// - ErrChecksumMismatch - secret payload not match manifest.
+//
+// - transport error.
+func Restore(
+	tr transport.Transport,
+	token, passphrase string,
+	r io.Reader,
+	mode RestoreMode,
+) ([]RestoreItem, error) {
+	archive, err := readArchive(r, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify all secrets before any change on server
+	for _, entry := range archive.Manifest {
+		if err = verifyEntry(entry, archive.Payloads[entry.Name]); err != nil {
+			return nil, err
+		}
+	}
+
+	lstSecrets, err := tr.SecretGetList(token)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]model.SecretInfo, len(lstSecrets))
+	for _, secretInfo := range lstSecrets {
+		existing[secretInfo.Name] = secretInfo
+	}
+
+	result := make([]RestoreItem, 0, len(archive.Manifest))
+	for _, entry := range archive.Manifest {
+		item, err := restoreEntry(tr, token, entry, archive.Payloads[entry.Name], existing, mode)
+		if err != nil {
+			return result, fmt.Errorf("secret [%s]: %w", entry.Name, err)
+		}
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+func readArchive(r io.Reader, passphrase string) (*Archive, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < len(_fileMagic)+cipher.SaltLength || !bytes.Equal(data[:len(_fileMagic)], []byte(_fileMagic)) {
+		return nil, ErrWrongFormat
+	}
+	data = data[len(_fileMagic):]
+	salt, cipherData := data[:cipher.SaltLength], data[cipher.SaltLength:]
+
+	key, err := cipher.KeyFromPassphrase(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plainData, err := cipher.AESGCMDecrypt(cipherData, key)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	archive := &Archive{}
+	if err = gkMsgp.Deserialize(plainData, archive); err != nil {
+		return nil, ErrWrongFormat
+	}
+
+	return archive, nil
+}
+
+func verifyEntry(entry ManifestEntry, payloadRaw []byte) error {
+	secret := &model.Secret{Type: model.SecretType(entry.Type), PayloadRaw: payloadRaw}
+	payload, err := secret.GetPayload()
+	if err != nil {
+		return fmt.Errorf("secret [%s]: %w: %v", entry.Name, ErrChecksumMismatch, err)
+	}
+
+	if payload.GetHash() != entry.Checksum {
+		return fmt.Errorf("secret [%s]: %w", entry.Name, ErrChecksumMismatch)
+	}
+
+	return nil
+}
+
+func restoreEntry(
+	tr transport.Transport,
+	token string,
+	entry ManifestEntry,
+	payloadRaw []byte,
+	existing map[string]model.SecretInfo,
+	mode RestoreMode,
+) (RestoreItem, error) {
+	item := RestoreItem{Name: entry.Name, RestoredAs: entry.Name, Action: ActionCreated}
+
+	cur, ok := existing[entry.Name]
+	if ok {
+		switch {
+		case mode == RestoreSkip:
+			item.Action = ActionSkipped
+			return item, nil
+		case mode == RestoreOverwrite && cur.Type == model.SecretType(entry.Type):
+			err := tr.SecretUpdate(token, entry.Name, &model.SecretUpdate{
+				Meta:          entry.Meta,
+				Version:       cur.Version + 1,
+				PayloadRaw:    payloadRaw,
+				UpdatePayload: true,
+				ExpiresAt:     entry.expiresAt(),
+				RotateEvery:   entry.rotateEvery(),
+			})
+			if err != nil {
+				return item, err
+			}
+			item.Action = ActionOverwritten
+			return item, nil
+		default:
+			item.RestoredAs = freeName(entry.Name, existing)
+			item.Action = ActionRenamed
+		}
+	}
+
+	err := tr.SecretCreate(token, &model.Secret{
+		Type:        model.SecretType(entry.Type),
+		Name:        item.RestoredAs,
+		Meta:        entry.Meta,
+		PayloadRaw:  payloadRaw,
+		ExpiresAt:   entry.expiresAt(),
+		RotateEvery: entry.rotateEvery(),
+	})
+	if err != nil {
+		return item, err
+	}
+
+	existing[item.RestoredAs] = model.SecretInfo{
+		Type:    model.SecretType(entry.Type),
+		Name:    item.RestoredAs,
+		Version: 1,
+	}
+	return item, nil
+}
+
+func freeName(name string, existing map[string]model.SecretInfo) string {
+	newName := name + " (restored)"
+	for i := 2; ; i++ {
+		if _, ok := existing[newName]; !ok {
+			return newName
+		}
+		newName = fmt.Sprintf("%s (restored %d)", name, i)
+	}
+}
+
+// toUnix converts time to unix timestamp, zero time is 0.
+func toUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
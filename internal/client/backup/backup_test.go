@@ -0,0 +1,205 @@
+package backup
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/transport/mocks"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"github.com/tinylib/msgp/msgp"
+)
+
+const (
+	_testToken      = "token"
+	_testPassphrase = "passphrase"
+)
+
+type BackupSuite struct {
+	suite.Suite
+	gmckCtrl *gomock.Controller
+	trMock   *mocks.MockTransport
+	secrets  []*model.Secret
+}
+
+func (b *BackupSuite) SetupTest() {
+	b.gmckCtrl = gomock.NewController(b.T())
+	b.trMock = mocks.NewMockTransport(b.gmckCtrl)
+	b.secrets = []*model.Secret{
+		b.newSecret(model.CredsSecret, "creds", 2, model.NewCredsPayload("login", "password")),
+		b.newSecret(model.CardSecret, "card", 1, model.NewCardPayload("2202", "foo", "11/26", "777")),
+	}
+	b.secrets[0].RotateEvery = 90 * 24 * time.Hour
+	b.secrets[1].ExpiresAt = time.Unix(1796083200, 0)
+}
+
+func (b *BackupSuite) TearDownTest() {
+	b.gmckCtrl.Finish()
+}
+
+func (b *BackupSuite) TestExportRestore() {
+	data := b.export()
+
+	b.Run("restore to empty vault", func() {
+		b.trMock.EXPECT().SecretGetList(_testToken).Return(nil, nil)
+		for _, s := range b.secrets {
+			b.trMock.EXPECT().SecretCreate(_testToken, &model.Secret{
+				Type: s.Type, Name: s.Name, Meta: s.Meta, PayloadRaw: s.PayloadRaw,
+				ExpiresAt: s.ExpiresAt, RotateEvery: s.RotateEvery,
+			}).Return(nil)
+		}
+
+		res, err := Restore(b.trMock, _testToken, _testPassphrase, bytes.NewReader(data), RestoreSkip)
+		b.NoError(err)
+		b.Equal([]RestoreItem{
+			{Name: "creds", RestoredAs: "creds", Action: ActionCreated},
+			{Name: "card", RestoredAs: "card", Action: ActionCreated},
+		}, res)
+	})
+
+	b.Run("restore with skip", func() {
+		b.trMock.EXPECT().SecretGetList(_testToken).Return(b.secretList(), nil)
+
+		res, err := Restore(b.trMock, _testToken, _testPassphrase, bytes.NewReader(data), RestoreSkip)
+		b.NoError(err)
+		b.Equal([]RestoreItem{
+			{Name: "creds", RestoredAs: "creds", Action: ActionSkipped},
+			{Name: "card", RestoredAs: "card", Action: ActionSkipped},
+		}, res)
+	})
+
+	b.Run("restore with overwrite", func() {
+		b.trMock.EXPECT().SecretGetList(_testToken).Return(b.secretList(), nil)
+		for _, s := range b.secrets {
+			b.trMock.EXPECT().SecretUpdate(_testToken, s.Name, &model.SecretUpdate{
+				Meta: s.Meta, Version: s.Version + 1, PayloadRaw: s.PayloadRaw, UpdatePayload: true,
+				ExpiresAt: s.ExpiresAt, RotateEvery: s.RotateEvery,
+			}).Return(nil)
+		}
+
+		res, err := Restore(b.trMock, _testToken, _testPassphrase, bytes.NewReader(data), RestoreOverwrite)
+		b.NoError(err)
+		b.Equal([]RestoreItem{
+			{Name: "creds", RestoredAs: "creds", Action: ActionOverwritten},
+			{Name: "card", RestoredAs: "card", Action: ActionOverwritten},
+		}, res)
+	})
+
+	b.Run("restore with rename", func() {
+		lst := append(b.secretList(), model.SecretInfo{Type: model.CredsSecret, Name: "creds (restored)", Version: 1})
+		b.trMock.EXPECT().SecretGetList(_testToken).Return(lst, nil)
+		b.trMock.EXPECT().SecretCreate(_testToken, gomock.Any()).Return(nil).Times(2)
+
+		res, err := Restore(b.trMock, _testToken, _testPassphrase, bytes.NewReader(data), RestoreRename)
+		b.NoError(err)
+		b.Equal([]RestoreItem{
+			{Name: "creds", RestoredAs: "creds (restored 2)", Action: ActionRenamed},
+			{Name: "card", RestoredAs: "card (restored)", Action: ActionRenamed},
+		}, res)
+	})
+}
+
+func (b *BackupSuite) TestRestoreErrors() {
+	data := b.export()
+
+	b.Run("wrong passphrase", func() {
+		_, err := Restore(b.trMock, _testToken, "foobar", bytes.NewReader(data), RestoreSkip)
+		b.ErrorIs(err, ErrWrongPassphrase)
+	})
+
+	b.Run("modified file", func() {
+		modified := append([]byte(nil), data...)
+		modified[len(modified)-1] ^= 0xff
+		_, err := Restore(b.trMock, _testToken, _testPassphrase, bytes.NewReader(modified), RestoreSkip)
+		b.ErrorIs(err, ErrWrongPassphrase)
+	})
+
+	b.Run("wrong format", func() {
+		_, err := Restore(b.trMock, _testToken, _testPassphrase, bytes.NewReader([]byte("foobar")), RestoreSkip)
+		b.ErrorIs(err, ErrWrongFormat)
+	})
+}
+
+func (b *BackupSuite) TestExportSkipsDeletedSecret() {
+	b.trMock.EXPECT().SecretGetList(_testToken).Return(b.secretList(), nil)
+	b.trMock.EXPECT().SecretGet(_testToken, "creds").Return(nil, transport.ErrSecretNotFound)
+	b.trMock.EXPECT().SecretGet(_testToken, "card").Return(b.secrets[1], nil)
+
+	var buf bytes.Buffer
+	manifest, err := Export(b.trMock, _testToken, _testPassphrase, &buf)
+	b.NoError(err)
+	b.Len(manifest, 1)
+	b.Equal("card", manifest[0].Name)
+}
+
+func (b *BackupSuite) TestVerifyEntry() {
+	s := b.secrets[0]
+	payload, err := s.GetPayload()
+	b.Require().NoError(err)
+
+	entry := ManifestEntry{Name: s.Name, Type: int32(s.Type), Checksum: payload.GetHash()}
+	b.NoError(verifyEntry(entry, s.PayloadRaw))
+
+	entry.Checksum = "foobar"
+	b.ErrorIs(verifyEntry(entry, s.PayloadRaw), ErrChecksumMismatch)
+
+	entry.Type = int32(model.CardSecret)
+	b.ErrorIs(verifyEntry(entry, s.PayloadRaw), ErrChecksumMismatch)
+}
+
+func (b *BackupSuite) TestParseRestoreMode() {
+	for s, exp := range map[string]RestoreMode{
+		"skip":      RestoreSkip,
+		"overwrite": RestoreOverwrite,
+		"rename":    RestoreRename,
+	} {
+		mode, err := ParseRestoreMode(s)
+		b.NoError(err)
+		b.Equal(exp, mode)
+	}
+
+	_, err := ParseRestoreMode("foobar")
+	b.ErrorIs(err, ErrUnknownRestoreMode)
+}
+
+func (b *BackupSuite) export() []byte {
+	b.trMock.EXPECT().SecretGetList(_testToken).Return(b.secretList(), nil)
+	for _, s := range b.secrets {
+		b.trMock.EXPECT().SecretGet(_testToken, s.Name).Return(s, nil)
+	}
+
+	var buf bytes.Buffer
+	manifest, err := Export(b.trMock, _testToken, _testPassphrase, &buf)
+	b.Require().NoError(err)
+	b.Len(manifest, len(b.secrets))
+
+	return buf.Bytes()
+}
+
+func (b *BackupSuite) secretList() []model.SecretInfo {
+	lst := make([]model.SecretInfo, 0, len(b.secrets))
+	for _, s := range b.secrets {
+		lst = append(lst, model.SecretInfo{Type: s.Type, Name: s.Name, Version: s.Version})
+	}
+	return lst
+}
+
+func (b *BackupSuite) newSecret(
+	secretType model.SecretType,
+	name string,
+	version int64,
+	payload msgp.Encodable,
+) *model.Secret {
+	payloadRaw, err := gkMsgp.Serialize(payload)
+	b.Require().NoError(err)
+
+	return &model.Secret{Type: secretType, Name: name, Meta: "meta " + name, Version: version, PayloadRaw: payloadRaw}
+}
+
+func TestBackupSuite(t *testing.T) {
+	suite.Run(t, new(BackupSuite))
+}
@@ -0,0 +1,155 @@
+// Package breach contains check of passwords against Have I Been Pwned breached passwords.
+//
+// Passwords never leave client: SHA-1 hash of password is calculated locally and only
+// 5 char hash prefix is used for lookup (k-anonymity model) in local dump or local mirror.
+package breach
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // HIBP uses SHA-1
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/vault"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+var ErrInvalidPrefix = errors.New("invalid hash prefix")
+
+const (
+	// PrefixLength - length of SHA-1 hash prefix used for range lookup.
+	PrefixLength = 5
+	// SuffixLength - length of SHA-1 hash suffix returned by range lookup.
+	SuffixLength = 35
+)
+
+// Lookup is an interface for range lookup of breached password hashes.
+type Lookup interface {
+	// Range returns uppercase hash suffixes of breached passwords, which SHA-1 hash starts
+	// with given uppercase prefix, with number of times password was seen in breaches.
+	Range(prefix string) (map[string]int, error)
+}
+
+// NewLookup creates Lookup for source: HTTP mirror of range API for http:// or https://
+// source, local hash dump file otherwise.
+func NewLookup(source string) Lookup {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return NewHTTPLookup(source)
+	}
+	return NewFileLookup(source)
+}
+
+// Checker checks passwords with lookup. Results of range lookups are cached.
+type Checker struct {
+	lookup Lookup
+	cache  map[string]map[string]int
+}
+
+// NewChecker creates new Checker object.
+func NewChecker(lookup Lookup) *Checker {
+	return &Checker{lookup: lookup, cache: make(map[string]map[string]int)}
+}
+
+// Check returns number of times password was seen in breaches, 0 if password not breached.
+func (c *Checker) Check(password string) (int, error) {
+	hash := HashPassword(password)
+	prefix, suffix := hash[:PrefixLength], hash[PrefixLength:]
+
+	suffixes, ok := c.cache[prefix]
+	if !ok {
+		var err error
+		if suffixes, err = c.lookup.Range(prefix); err != nil {
+			return 0, err
+		}
+		c.cache[prefix] = suffixes
+	}
+
+	return suffixes[suffix], nil
+}
+
+// Result represents breached secret.
+type Result struct {
+	Secret string `json:"secret"`
+	Count  int    `json:"count"`
+}
+
+// CheckVault reads user's credentials from server and checks their passwords.
+//
+// Returns list of secrets with breached passwords or error.
+func CheckVault(tr transport.Transport, token string, lookup Lookup) ([]Result, error) {
+	checker := NewChecker(lookup)
+	results := make([]Result, 0)
+
+	fnCheck := func(_ model.SecretInfo, secret *model.Secret, payload model.Payload) error {
+		creds, ok := payload.(*model.CredsPayload)
+		if !ok || creds.Password == "" {
+			return nil
+		}
+
+		count, err := checker.Check(creds.Password)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			results = append(results, Result{Secret: secret.Name, Count: count})
+		}
+		return nil
+	}
+
+	if err := vault.ForEachSecret(tr, token, vault.ByType(model.CredsSecret), fnCheck); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// HashPassword returns uppercase hex SHA-1 hash of password.
+func HashPassword(password string) string {
+	return fmt.Sprintf("%X", sha1.Sum([]byte(password))) //nolint:gosec // HIBP uses SHA-1
+}
+
+func validPrefix(prefix string) error {
+	if len(prefix) != PrefixLength {
+		return fmt.Errorf("%w: %s", ErrInvalidPrefix, prefix)
+	}
+	if _, err := strconv.ParseUint(prefix, 16, 32); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPrefix, prefix)
+	}
+	return nil
+}
+
+// parseHashLine parses "HASH:COUNT" line.
+func parseHashLine(line string) (string, int, bool) {
+	hash, strCount, ok := strings.Cut(strings.TrimSpace(line), ":")
+	if !ok {
+		return "", 0, false
+	}
+
+	count, err := strconv.Atoi(strCount)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return strings.ToUpper(hash), count, true
+}
+
+// readRange reads range API response: "SUFFIX:COUNT" lines.
+// Padding entries with zero count are skipped.
+func readRange(r io.Reader) (map[string]int, error) {
+	suffixes := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		suffix, count, ok := parseHashLine(scanner.Text())
+		if !ok || len(suffix) != SuffixLength || count == 0 {
+			continue
+		}
+		suffixes[suffix] = count
+	}
+
+	return suffixes, scanner.Err()
+}
@@ -0,0 +1,151 @@
+package breach
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport/mocks"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BreachSuite struct {
+	suite.Suite
+	breached map[string]int
+	dumpPath string
+	srv      *httptest.Server
+}
+
+func (b *BreachSuite) SetupSuite() {
+	b.breached = map[string]int{
+		"password": 9545824,
+		"qwerty":   3946737,
+		"123456":   37359195,
+	}
+
+	// Dump with breached passwords and filler hashes, ordered by hash
+	var lines []string
+	for password, count := range b.breached {
+		lines = append(lines, fmt.Sprintf("%s:%d", HashPassword(password), count))
+	}
+	for i := 0; i < 1000; i++ {
+		lines = append(lines, fmt.Sprintf("%s:%d", HashPassword(fmt.Sprintf("filler%d", i)), i+1))
+	}
+	sort.Strings(lines)
+
+	b.dumpPath = filepath.Join(b.T().TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+	b.Require().NoError(os.WriteFile(b.dumpPath, []byte(strings.Join(lines, "\r\n")+"\r\n"), 0600))
+
+	// Local stand-in for range API
+	fileLookup := NewFileLookup(b.dumpPath)
+	b.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		suffixes, err := fileLookup.Range(strings.TrimPrefix(r.URL.Path, "/range/"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for suffix, count := range suffixes {
+			fmt.Fprintf(w, "%s:%d\r\n", suffix, count)
+		}
+		// Padding entry
+		fmt.Fprintf(w, "%s:0\r\n", strings.Repeat("F", SuffixLength))
+	}))
+}
+
+func (b *BreachSuite) TearDownSuite() {
+	b.srv.Close()
+}
+
+func (b *BreachSuite) TestCheck() {
+	for _, lookup := range []Lookup{NewLookup(b.dumpPath), NewLookup(b.srv.URL)} {
+		checker := NewChecker(lookup)
+
+		for password, expCount := range b.breached {
+			count, err := checker.Check(password)
+			b.NoError(err)
+			b.Equal(expCount, count, password)
+		}
+
+		count, err := checker.Check("filler999")
+		b.NoError(err)
+		b.Equal(1000, count)
+
+		count, err = checker.Check("Ofq7#xLt2!pWz9$Rk")
+		b.NoError(err)
+		b.Equal(0, count)
+	}
+}
+
+func (b *BreachSuite) TestRangeErrors() {
+	_, err := NewFileLookup(b.dumpPath).Range("XYZ")
+	b.ErrorIs(err, ErrInvalidPrefix)
+
+	_, err = NewFileLookup(filepath.Join(b.T().TempDir(), "missing")).Range("00000")
+	b.Error(err)
+
+	_, err = NewHTTPLookup(b.srv.URL + "/missing").Range("00000")
+	b.ErrorIs(err, ErrMirrorResponse)
+}
+
+func (b *BreachSuite) TestFileRangeBounds() {
+	lookup := NewFileLookup(b.dumpPath)
+
+	for _, prefix := range []string{"00000", "fffff"} {
+		_, err := lookup.Range(prefix)
+		b.NoError(err)
+	}
+
+	hash := HashPassword("password")
+	suffixes, err := lookup.Range(strings.ToLower(hash[:PrefixLength]))
+	b.NoError(err)
+	b.Equal(b.breached["password"], suffixes[hash[PrefixLength:]])
+}
+
+func (b *BreachSuite) TestCheckVault() {
+	gmckCtrl := gomock.NewController(b.T())
+	defer gmckCtrl.Finish()
+	trMock := mocks.NewMockTransport(gmckCtrl)
+
+	newSecret := func(name, password string) *model.Secret {
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload("login", password))
+		b.Require().NoError(err)
+		return &model.Secret{Type: model.CredsSecret, Name: name, Version: 1, PayloadRaw: payloadRaw}
+	}
+
+	trMock.EXPECT().SecretGetList("token").Return([]model.SecretInfo{
+		{Type: model.CredsSecret, Name: "breached", Version: 1},
+		{Type: model.CredsSecret, Name: "safe", Version: 1},
+		{Type: model.TextSecret, Name: "text", Version: 1},
+	}, nil)
+	trMock.EXPECT().SecretGet("token", "breached").Return(newSecret("breached", "qwerty"), nil)
+	trMock.EXPECT().SecretGet("token", "safe").Return(newSecret("safe", "Ofq7#xLt2!pWz9$Rk"), nil)
+
+	results, err := CheckVault(trMock, "token", NewLookup(b.srv.URL))
+	b.NoError(err)
+	b.Equal([]Result{{Secret: "breached", Count: b.breached["qwerty"]}}, results)
+}
+
+func TestHashPassword(t *testing.T) {
+	assert.Equal(t, "5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8", HashPassword("password"))
+}
+
+func TestReadRange(t *testing.T) {
+	suffixes, err := readRange(strings.NewReader(
+		"1E4C9B93F3F0682250B6CF8331B7EE68FD8:3\r\nbad line\r\n0018A45C4D1DEF81644B54AB7F969B88D65:0\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"1E4C9B93F3F0682250B6CF8331B7EE68FD8": 3}, suffixes)
+}
+
+func TestBreachSuite(t *testing.T) {
+	suite.Run(t, new(BreachSuite))
+}
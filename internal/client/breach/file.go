@@ -0,0 +1,120 @@
+package breach
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// FileLookup is a Lookup implementation for local Have I Been Pwned SHA-1 dump,
+// ordered by hash, with "HASH:COUNT" lines.
+//
+// Dump is not loaded in memory, range is found with binary search in file.
+type FileLookup struct {
+	path string
+}
+
+var _ Lookup = (*FileLookup)(nil)
+
+// NewFileLookup creates new FileLookup object.
+func NewFileLookup(path string) *FileLookup {
+	return &FileLookup{path: path}
+}
+
+// Range returns hash suffixes for prefix from dump file.
+func (f *FileLookup) Range(prefix string) (map[string]int, error) {
+	if err := validPrefix(prefix); err != nil {
+		return nil, err
+	}
+	prefix = strings.ToUpper(prefix)
+
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// Find smallest offset, from which first line has hash not less than prefix
+	lo, hi := int64(0), stat.Size()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		line, err := lineFrom(file, mid)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(line) >= PrefixLength && strings.ToUpper(line[:PrefixLength]) < prefix {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	rd, err := readerFrom(file, lo)
+	if err != nil {
+		return nil, err
+	}
+
+	suffixes := make(map[string]int)
+	for {
+		line, err := rd.ReadString('\n')
+		if hash, count, ok := parseHashLine(line); ok && len(hash) == PrefixLength+SuffixLength {
+			if !strings.HasPrefix(hash, prefix) {
+				break
+			}
+			suffixes[hash[PrefixLength:]] = count
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return suffixes, nil
+}
+
+// readerFrom returns reader, positioned at start of first line, which starts at offset or later.
+func readerFrom(file *os.File, offset int64) (*bufio.Reader, error) {
+	if offset == 0 {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return bufio.NewReader(file), nil
+	}
+
+	if _, err := file.Seek(offset-1, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	rd := bufio.NewReader(file)
+	if _, err := rd.ReadString('\n'); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	return rd, nil
+}
+
+// lineFrom returns first line, which starts at offset or later. Empty string on EOF.
+func lineFrom(file *os.File, offset int64) (string, error) {
+	rd, err := readerFrom(file, offset)
+	if err != nil {
+		return "", err
+	}
+
+	line, err := rd.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,59 @@
+package breach
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var ErrMirrorResponse = errors.New("unexpected mirror response")
+
+const _httpRequestTimeout = 15 * time.Second
+
+// HTTPLookup is a Lookup implementation for local mirror of Have I Been Pwned range API:
+// GET <baseURL>/range/<prefix> returns "SUFFIX:COUNT" lines.
+type HTTPLookup struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ Lookup = (*HTTPLookup)(nil)
+
+// NewHTTPLookup creates new HTTPLookup object.
+func NewHTTPLookup(baseURL string) *HTTPLookup {
+	return &HTTPLookup{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: _httpRequestTimeout},
+	}
+}
+
+// Range requests hash suffixes for prefix from mirror.
+func (h *HTTPLookup) Range(prefix string) (map[string]int, error) {
+	if err := validPrefix(prefix); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		context.Background(),
+		http.MethodGet,
+		fmt.Sprintf("%s/range/%s", h.baseURL, strings.ToUpper(prefix)),
+		nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrMirrorResponse, resp.Status)
+	}
+
+	return readRange(resp.Body)
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/audit"
+)
+
+// doAudit makes security audit of user's vault and prints report as JSON.
+//
+// Usage: audit [-max-age-days N] [-card-warn-days N].
+func (c *CLI) doAudit(_ context.Context, args []string) error {
+	opts := audit.DefaultOptions()
+
+	flagSet := flag.NewFlagSet("audit", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	maxAgeDays := flagSet.Int("max-age-days", int(opts.MaxPasswordAge/(24*time.Hour)),
+		"report passwords not changed for given number of days (0 - disable)")
+	cardWarnDays := flagSet.Int("card-warn-days", int(opts.CardExpiryWarning/(24*time.Hour)),
+		"report cards expiring within given number of days")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	opts.MaxPasswordAge = time.Duration(*maxAgeDays) * 24 * time.Hour
+	opts.CardExpiryWarning = time.Duration(*cardWarnDays) * 24 * time.Hour
+
+	token, err := c.login()
+	if err != nil {
+		return err
+	}
+
+	report, err := audit.Run(c.tr, token, opts)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
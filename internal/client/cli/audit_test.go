@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/audit"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+)
+
+func (c *CLISuite) TestAudit() {
+	c.cli.userPassword = _testPassword
+	defer func() { c.cli.userPassword = "" }()
+
+	payloadRaw, err := gkMsgp.Serialize(model.NewCardPayload("2202", "JOHN", "01/20", "123"))
+	c.Require().NoError(err)
+
+	c.trMock.EXPECT().UserLogin(_testLogin, _testPassword).Return(_testToken, nil)
+	c.trMock.EXPECT().SecretGetList(_testToken).Return([]model.SecretInfo{
+		{Type: model.CardSecret, Name: "card", Version: 1, UpdatedAt: time.Now()},
+	}, nil)
+	c.trMock.EXPECT().SecretGet(_testToken, "card").Return(&model.Secret{
+		Type: model.CardSecret, Name: "card", Version: 1, PayloadRaw: payloadRaw,
+	}, nil)
+
+	c.stdout.Reset()
+	c.NoError(c.cli.Run(context.Background(), []string{"audit", "-max-age-days", "30"}))
+
+	var report audit.Report
+	c.NoError(json.Unmarshal(c.stdout.Bytes(), &report))
+	c.Equal(1, report.Checked)
+	c.Len(report.Issues, 1)
+	c.Equal(audit.IssueCardExpired, report.Issues[0].Type)
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/devldavydov/gophkeeper/internal/client/backup"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+// doExport exports all user's secrets to encrypted file.
+//
+// Usage: export -o <file> [-passphrase-file <file>].
+func (c *CLI) doExport(_ context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("export", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	output := flagSet.String("o", "", "output file")
+	passphraseFile := flagSet.String("passphrase-file", "", "file with export passphrase")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return fmt.Errorf("%w: -o", ErrMissingArgument)
+	}
+
+	passphrase, err := c.readPassphrase(*passphraseFile, *passphraseFile == "")
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := c.exportTo(file, passphrase)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		os.Remove(*output)
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	for _, entry := range manifest {
+		fmt.Fprintf(c.stdout, "%s (%s) v%d\n", entry.Name, model.SecretType(entry.Type), entry.Version)
+	}
+	fmt.Fprintf(c.stdout, "Exported %d secrets to %s\n", len(manifest), *output)
+
+	return nil
+}
+
+func (c *CLI) exportTo(w io.Writer, passphrase string) ([]backup.ManifestEntry, error) {
+	token, err := c.login()
+	if err != nil {
+		return nil, err
+	}
+
+	return backup.Export(c.tr, token, passphrase, w)
+}
+
+// doRestore restores secrets from encrypted export file.
+//
+// Usage: restore -i <file> [-mode skip|overwrite|rename] [-passphrase-file <file>].
+func (c *CLI) doRestore(_ context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("restore", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	input := flagSet.String("i", "", "input file")
+	sMode := flagSet.String("mode", "skip", "existing secrets handling: skip, overwrite or rename")
+	passphraseFile := flagSet.String("passphrase-file", "", "file with export passphrase")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *input == "" {
+		return fmt.Errorf("%w: -i", ErrMissingArgument)
+	}
+
+	mode, err := backup.ParseRestoreMode(*sMode)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := c.readPassphrase(*passphraseFile, false)
+	if err != nil {
+		return err
+	}
+
+	token, err := c.login()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(*input)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	result, err := backup.Restore(c.tr, token, passphrase, file, mode)
+	for _, item := range result {
+		if item.RestoredAs != item.Name {
+			fmt.Fprintf(c.stdout, "%s: %s as %s\n", item.Name, item.Action, item.RestoredAs)
+			continue
+		}
+		fmt.Fprintf(c.stdout, "%s: %s\n", item.Name, item.Action)
+	}
+	if err != nil {
+		return fmt.Errorf("restore failed: %w", err)
+	}
+
+	fmt.Fprintf(c.stdout, "Restored %d secrets from %s\n", len(result), *input)
+	return nil
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/devldavydov/gophkeeper/internal/client/breach"
+)
+
+// doBreachCheck checks user's passwords against Have I Been Pwned local hash dump
+// or range API mirror and prints breached secrets as JSON.
+//
+// Usage: breach-check [-source <file|url>].
+func (c *CLI) doBreachCheck(_ context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("breach-check", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	source := flagSet.String("source", c.breachSource, "HIBP SHA-1 hash dump file or range API mirror URL")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *source == "" {
+		return fmt.Errorf("%w: -source", ErrMissingArgument)
+	}
+
+	token, err := c.login()
+	if err != nil {
+		return err
+	}
+
+	results, err := breach.CheckVault(c.tr, token, breach.NewLookup(*source))
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
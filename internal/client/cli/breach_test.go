@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/devldavydov/gophkeeper/internal/client/breach"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+)
+
+func (c *CLISuite) TestBreachCheck() {
+	c.Run("no source", func() {
+		c.ErrorIs(c.cli.Run(context.Background(), []string{"breach-check"}), ErrMissingArgument)
+	})
+
+	c.Run("breached password", func() {
+		dumpPath := filepath.Join(c.T().TempDir(), "dump.txt")
+		c.Require().NoError(os.WriteFile(dumpPath, []byte(fmt.Sprintf("%s:42\n", breach.HashPassword("qwerty"))), 0600))
+
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload("login", "qwerty"))
+		c.Require().NoError(err)
+
+		c.secrets["Password for login: "] = _testPassword
+		c.trMock.EXPECT().UserLogin(_testLogin, _testPassword).Return(_testToken, nil)
+		c.trMock.EXPECT().SecretGetList(_testToken).Return([]model.SecretInfo{
+			{Type: model.CredsSecret, Name: "creds", Version: 1},
+		}, nil)
+		c.trMock.EXPECT().SecretGet(_testToken, "creds").Return(&model.Secret{
+			Type: model.CredsSecret, Name: "creds", Version: 1, PayloadRaw: payloadRaw,
+		}, nil)
+
+		c.stdout.Reset()
+		c.NoError(c.cli.Run(context.Background(), []string{"breach-check", "-source", dumpPath}))
+
+		var results []breach.Result
+		c.NoError(json.Unmarshal(c.stdout.Bytes(), &results))
+		c.Equal([]breach.Result{{Secret: "creds", Count: 42}}, results)
+	})
+}
@@ -0,0 +1,172 @@
+// Package cli contains non-interactive client commands.
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/term"
+)
+
+var (
+	ErrNoCommand          = errors.New("no command")
+	ErrUnknownCommand     = errors.New("unknown command")
+	ErrMissingArgument    = errors.New("missing argument")
+	ErrNoUserLogin        = errors.New("user login not set")
+	ErrPassphraseMismatch = errors.New("passphrases do not match")
+	ErrEmptyPassphrase    = errors.New("empty passphrase")
+)
+
+type command func(ctx context.Context, args []string) error
+
+// CLI represents command line interface of client application.
+type CLI struct {
+	tr           transport.Transport
+	userLogin    string
+	userPassword string
+	breachSource string
+	stdin        io.Reader
+	stdout       io.Writer
+	stderr       io.Writer
+	fnReadSecret func(prompt string) (string, error)
+	logger       *logrus.Logger
+}
+
+// NewCLI creates new CLI object.
+//
+// If userPassword is empty, it will be asked in terminal.
+// breachSource is a default source for breach check.
+func NewCLI(tr transport.Transport, userLogin, userPassword, breachSource string, logger *logrus.Logger) *CLI {
+	return &CLI{
+		tr:           tr,
+		userLogin:    userLogin,
+		userPassword: userPassword,
+		breachSource: breachSource,
+		stdin:        os.Stdin,
+		stdout:       os.Stdout,
+		stderr:       os.Stderr,
+		fnReadSecret: readSecretFromTerminal,
+		logger:       logger,
+	}
+}
+
+// Run executes command with arguments.
+//
+// First element of args is a command name, others are command arguments.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return ErrNoCommand
+	}
+
+	cmd, ok := c.commands()[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: %s (available: %s)", ErrUnknownCommand, args[0], strings.Join(c.commandNames(), ", "))
+	}
+
+	c.logger.Infof("run command [%s]", args[0])
+	return cmd(ctx, args[1:])
+}
+
+func (c *CLI) commands() map[string]command {
+	return map[string]command{
+		"audit":             c.doAudit,
+		"breach-check":      c.doBreachCheck,
+		"docker-credential": c.doDockerCredential,
+		"export":            c.doExport,
+		"expiring":          c.doExpiring,
+		"restore":           c.doRestore,
+		"generate":          c.doGenerate,
+		"git-credential":    c.doGitCredential,
+		"inject":            c.doInject,
+		"redeem":            c.doRedeem,
+		"run":               c.doRun,
+		"share":             c.doShare,
+		"strength":          c.doStrength,
+	}
+}
+
+func (c *CLI) commandNames() []string {
+	names := make([]string, 0, len(c.commands()))
+	for name := range c.commands() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *CLI) login() (string, error) {
+	if c.userLogin == "" {
+		return "", ErrNoUserLogin
+	}
+
+	password := c.userPassword
+	if password == "" {
+		var err error
+		if password, err = c.fnReadSecret(fmt.Sprintf("Password for %s: ", c.userLogin)); err != nil {
+			return "", err
+		}
+	}
+
+	return c.tr.UserLogin(c.userLogin, password)
+}
+
+// readPassphrase reads passphrase from file or from terminal.
+//
+// If confirm set, passphrase is asked twice in terminal.
+func (c *CLI) readPassphrase(passphraseFile string, confirm bool) (string, error) {
+	if passphraseFile != "" {
+		data, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return "", err
+		}
+		passphrase := strings.TrimRight(string(data), "\r\n")
+		if passphrase == "" {
+			return "", ErrEmptyPassphrase
+		}
+		return passphrase, nil
+	}
+
+	passphrase, err := c.fnReadSecret("Passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", ErrEmptyPassphrase
+	}
+
+	if confirm {
+		confirmation, err := c.fnReadSecret("Repeat passphrase: ")
+		if err != nil {
+			return "", err
+		}
+		if confirmation != passphrase {
+			return "", ErrPassphraseMismatch
+		}
+	}
+
+	return passphrase, nil
+}
+
+func readSecretFromTerminal(prompt string) (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return "", err
+	}
+	defer tty.Close()
+
+	fmt.Fprint(tty, prompt)
+	data, err := term.ReadPassword(int(tty.Fd()))
+	fmt.Fprintln(tty)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
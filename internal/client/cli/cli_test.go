@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/transport/mocks"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	_testLogin    = "login"
+	_testPassword = "password"
+	_testToken    = "token"
+)
+
+type CLISuite struct {
+	suite.Suite
+	gmckCtrl *gomock.Controller
+	trMock   *mocks.MockTransport
+	cli      *CLI
+	stdout   *bytes.Buffer
+	secrets  map[string]string
+}
+
+func (c *CLISuite) SetupTest() {
+	c.gmckCtrl = gomock.NewController(c.T())
+	c.trMock = mocks.NewMockTransport(c.gmckCtrl)
+	c.stdout = &bytes.Buffer{}
+	c.secrets = map[string]string{}
+
+	c.cli = NewCLI(c.trMock, _testLogin, "", "", logrus.New())
+	c.cli.stdin = &bytes.Buffer{}
+	c.cli.stdout = c.stdout
+	c.cli.stderr = &bytes.Buffer{}
+	c.cli.fnReadSecret = func(prompt string) (string, error) {
+		secret, ok := c.secrets[prompt]
+		if !ok {
+			return "", errors.New("unexpected prompt")
+		}
+		return secret, nil
+	}
+}
+
+func (c *CLISuite) TearDownTest() {
+	c.gmckCtrl.Finish()
+}
+
+func (c *CLISuite) TestRunUnknownCommand() {
+	c.ErrorIs(c.cli.Run(context.Background(), nil), ErrNoCommand)
+	c.ErrorIs(c.cli.Run(context.Background(), []string{"foobar"}), ErrUnknownCommand)
+}
+
+func (c *CLISuite) TestLogin() {
+	c.Run("no user login", func() {
+		c.cli.userLogin = ""
+		_, err := c.cli.login()
+		c.ErrorIs(err, ErrNoUserLogin)
+		c.cli.userLogin = _testLogin
+	})
+
+	c.Run("password from settings", func() {
+		c.cli.userPassword = _testPassword
+		c.trMock.EXPECT().UserLogin(_testLogin, _testPassword).Return(_testToken, nil)
+		token, err := c.cli.login()
+		c.NoError(err)
+		c.Equal(_testToken, token)
+		c.cli.userPassword = ""
+	})
+
+	c.Run("password from terminal", func() {
+		c.secrets["Password for login: "] = "other"
+		c.trMock.EXPECT().UserLogin(_testLogin, "other").Return("", transport.ErrUserPermissionDenied)
+		_, err := c.cli.login()
+		c.ErrorIs(err, transport.ErrUserPermissionDenied)
+	})
+}
+
+func (c *CLISuite) TestReadPassphrase() {
+	c.Run("passphrase mismatch", func() {
+		c.secrets["Passphrase: "] = "foo"
+		c.secrets["Repeat passphrase: "] = "bar"
+		_, err := c.cli.readPassphrase("", true)
+		c.ErrorIs(err, ErrPassphraseMismatch)
+	})
+
+	c.Run("empty passphrase", func() {
+		c.secrets["Passphrase: "] = ""
+		_, err := c.cli.readPassphrase("", false)
+		c.ErrorIs(err, ErrEmptyPassphrase)
+	})
+
+	c.Run("passphrase from file", func() {
+		passphraseFile := filepath.Join(c.T().TempDir(), "passphrase")
+		c.Require().NoError(os.WriteFile(passphraseFile, []byte("foobar\n"), 0600))
+		passphrase, err := c.cli.readPassphrase(passphraseFile, true)
+		c.NoError(err)
+		c.Equal("foobar", passphrase)
+	})
+}
+
+func (c *CLISuite) TestExportRestore() {
+	payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload("foo", "bar"))
+	c.Require().NoError(err)
+	secret := &model.Secret{Type: model.CredsSecret, Name: "creds", Version: 1, PayloadRaw: payloadRaw}
+
+	c.secrets["Password for login: "] = _testPassword
+	c.secrets["Passphrase: "] = "passphrase"
+	c.secrets["Repeat passphrase: "] = "passphrase"
+	exportFile := filepath.Join(c.T().TempDir(), "vault.gkv")
+
+	c.Run("export", func() {
+		c.trMock.EXPECT().UserLogin(_testLogin, _testPassword).Return(_testToken, nil)
+		c.trMock.EXPECT().SecretGetList(_testToken).Return([]model.SecretInfo{
+			{Type: secret.Type, Name: secret.Name, Version: secret.Version},
+		}, nil)
+		c.trMock.EXPECT().SecretGet(_testToken, secret.Name).Return(secret, nil)
+
+		c.NoError(c.cli.Run(context.Background(), []string{"export", "-o", exportFile}))
+		c.Contains(c.stdout.String(), "Exported 1 secrets")
+	})
+
+	c.Run("export to existing file", func() {
+		c.Error(c.cli.Run(context.Background(), []string{"export", "-o", exportFile}))
+	})
+
+	c.Run("restore", func() {
+		c.trMock.EXPECT().UserLogin(_testLogin, _testPassword).Return(_testToken, nil)
+		c.trMock.EXPECT().SecretGetList(_testToken).Return(nil, nil)
+		c.trMock.EXPECT().SecretCreate(_testToken, &model.Secret{
+			Type: secret.Type, Name: secret.Name, PayloadRaw: secret.PayloadRaw,
+		}).Return(nil)
+
+		c.NoError(c.cli.Run(context.Background(), []string{"restore", "-i", exportFile, "-mode", "rename"}))
+		c.Contains(c.stdout.String(), "creds: created")
+	})
+
+	c.Run("restore with wrong mode", func() {
+		c.Error(c.cli.Run(context.Background(), []string{"restore", "-i", exportFile, "-mode", "foobar"}))
+	})
+}
+
+func TestCLISuite(t *testing.T) {
+	suite.Run(t, new(CLISuite))
+}
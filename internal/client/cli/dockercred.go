@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/devldavydov/gophkeeper/internal/client/dockercred"
+)
+
+// DockerCredentialHelperName - name of client binary (symlink), which runs as docker credential helper.
+const DockerCredentialHelperName = "docker-credential-gophkeeper"
+
+// doDockerCredential implements docker credential helpers protocol.
+// Request is read from stdin, answer is written to stdout.
+// On error, error message is written to stdout, as protocol requires.
+//
+// Usage: docker-credential store|get|erase|list|version.
+//
+// Docker configuration: symlink client binary as docker-credential-gophkeeper and set
+// "credsStore": "gophkeeper" in ~/.docker/config.json. Client settings are taken from env.
+func (c *CLI) doDockerCredential(_ context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: operation", ErrMissingArgument)
+	}
+
+	err := c.dockerCredential(args[0])
+	if err != nil {
+		fmt.Fprintln(c.stdout, err)
+	}
+
+	return err
+}
+
+func (c *CLI) dockerCredential(operation string) error {
+	if operation == "version" {
+		_, err := fmt.Fprintln(c.stdout, DockerCredentialHelperName)
+		return err
+	}
+
+	input, err := io.ReadAll(c.stdin)
+	if err != nil {
+		return err
+	}
+
+	token, err := c.login()
+	if err != nil {
+		return err
+	}
+	helper := dockercred.NewHelper(c.tr, token)
+
+	switch operation {
+	case "store":
+		creds := &dockercred.Credentials{}
+		if err = json.Unmarshal(input, creds); err != nil {
+			return fmt.Errorf("%w: %v", dockercred.ErrInvalidCredentials, err)
+		}
+		return helper.Store(creds)
+	case "get":
+		var creds *dockercred.Credentials
+		if creds, err = helper.Get(strings.TrimSpace(string(input))); err != nil {
+			return err
+		}
+		return json.NewEncoder(c.stdout).Encode(creds)
+	case "erase":
+		return helper.Erase(strings.TrimSpace(string(input)))
+	case "list":
+		var lst map[string]string
+		if lst, err = helper.List(); err != nil {
+			return err
+		}
+		return json.NewEncoder(c.stdout).Encode(lst)
+	default:
+		return fmt.Errorf("%w: docker-credential %s", ErrUnknownCommand, operation)
+	}
+}
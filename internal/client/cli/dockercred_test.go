@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/devldavydov/gophkeeper/internal/client/dockercred"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+)
+
+func (c *CLISuite) TestDockerCredential() {
+	c.Run("version", func() {
+		c.stdout.Reset()
+		c.NoError(c.cli.Run(context.Background(), []string{"docker-credential", "version"}))
+		c.Equal(DockerCredentialHelperName+"\n", c.stdout.String())
+	})
+
+	c.Run("store", func() {
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload("john", "token"))
+		c.Require().NoError(err)
+
+		c.secrets["Password for login: "] = _testPassword
+		c.trMock.EXPECT().UserLogin(_testLogin, _testPassword).Return(_testToken, nil)
+		c.trMock.EXPECT().SecretGetList(_testToken).Return(nil, nil)
+		c.trMock.EXPECT().SecretCreate(_testToken, &model.Secret{
+			Type:       model.CredsSecret,
+			Name:       "docker:ghcr.io",
+			Meta:       "registry=ghcr.io",
+			PayloadRaw: payloadRaw,
+		}).Return(nil)
+
+		c.cli.stdin = bytes.NewBufferString(`{"ServerURL":"ghcr.io","Username":"john","Secret":"token"}`)
+		c.NoError(c.cli.Run(context.Background(), []string{"docker-credential", "store"}))
+	})
+
+	c.Run("get not found", func() {
+		c.secrets["Password for login: "] = _testPassword
+		c.trMock.EXPECT().UserLogin(_testLogin, _testPassword).Return(_testToken, nil)
+		c.trMock.EXPECT().SecretGetList(_testToken).Return(nil, nil)
+
+		c.cli.stdin = bytes.NewBufferString("ghcr.io\n")
+		c.stdout.Reset()
+		c.ErrorIs(c.cli.Run(context.Background(), []string{"docker-credential", "get"}), dockercred.ErrCredentialsNotFound)
+		c.Equal("credentials not found in native keychain\n", c.stdout.String())
+	})
+}
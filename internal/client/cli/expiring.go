@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+const _defaultExpiringDays = 30
+
+// doExpiring prints table of secrets expired or due to rotation within given number of days,
+// most urgent first.
+//
+// Usage: expiring [-days N].
+func (c *CLI) doExpiring(_ context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("expiring", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	days := flagSet.Int("days", _defaultExpiringDays,
+		"report secrets expiring or due to rotation within given number of days")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	token, err := c.login()
+	if err != nil {
+		return err
+	}
+
+	items, err := c.tr.SecretGetExpiring(token, *days)
+	if err != nil {
+		return err
+	}
+	model.SortByUrgency(items)
+
+	now := time.Now()
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tDUE\tREASON")
+	for i := range items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+			items[i].Name, items[i].Type, items[i].DueAt().Format(time.DateOnly), dueReason(&items[i], now))
+	}
+
+	return w.Flush()
+}
+
+// dueReason describes why secret is due: expiration or rotation, already overdue or not.
+func dueReason(info *model.SecretInfo, now time.Time) string {
+	dueAt := info.DueAt()
+	if !info.ExpiresAt.IsZero() && dueAt.Equal(info.ExpiresAt) {
+		if dueAt.After(now) {
+			return "expires"
+		}
+		return "expired"
+	}
+
+	if dueAt.After(now) {
+		return "rotate"
+	}
+	return "rotation overdue"
+}
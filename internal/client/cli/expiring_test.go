@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+func (c *CLISuite) TestExpiring() {
+	c.cli.userPassword = _testPassword
+	defer func() { c.cli.userPassword = "" }()
+
+	now := time.Now()
+	c.trMock.EXPECT().UserLogin(_testLogin, _testPassword).Return(_testToken, nil)
+	c.trMock.EXPECT().SecretGetExpiring(_testToken, 7).Return([]model.SecretInfo{
+		{Type: model.CredsSecret, Name: "db", PayloadUpdatedAt: now.AddDate(0, 0, -29), RotateEvery: 30 * 24 * time.Hour},
+		{Type: model.CardSecret, Name: "card", UpdatedAt: now, ExpiresAt: now.AddDate(0, 0, -1)},
+	}, nil)
+
+	c.stdout.Reset()
+	c.NoError(c.cli.Run(context.Background(), []string{"expiring", "-days", "7"}))
+
+	lines := strings.Split(strings.TrimSpace(c.stdout.String()), "\n")
+	c.Require().Len(lines, 3)
+	c.Contains(lines[0], "REASON")
+	c.Contains(lines[1], "card")
+	c.Contains(lines[1], "expired")
+	c.Contains(lines[2], "db")
+	c.Contains(lines[2], "rotate")
+}
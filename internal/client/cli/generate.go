@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/devldavydov/gophkeeper/internal/client/passgen"
+)
+
+// doGenerate generates random password or diceware passphrase.
+// Password is printed to stdout, its strength to stderr.
+//
+// Usage: generate [-length N] [-lower] [-upper] [-digits] [-symbols] [-exclude-ambiguous] [-words N] [-sep S].
+func (c *CLI) doGenerate(_ context.Context, args []string) error {
+	defOpts := passgen.DefaultOptions()
+
+	flagSet := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	length := flagSet.Int("length", defOpts.Length, "password length")
+	lower := flagSet.Bool("lower", defOpts.Lower, "use lowercase letters")
+	upper := flagSet.Bool("upper", defOpts.Upper, "use uppercase letters")
+	digits := flagSet.Bool("digits", defOpts.Digits, "use digits")
+	symbols := flagSet.Bool("symbols", defOpts.Symbols, "use symbols")
+	excludeAmbiguous := flagSet.Bool("exclude-ambiguous", defOpts.ExcludeAmbiguous, "exclude similar looking characters")
+	words := flagSet.Int("words", 0, "generate diceware passphrase with given number of words")
+	separator := flagSet.String("sep", passgen.DefaultSeparator, "passphrase words separator")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	var password string
+	var err error
+	if *words > 0 {
+		password, err = passgen.Passphrase(*words, *separator)
+	} else {
+		password, err = passgen.Generate(passgen.Options{
+			Length:           *length,
+			Lower:            *lower,
+			Upper:            *upper,
+			Digits:           *digits,
+			Symbols:          *symbols,
+			ExcludeAmbiguous: *excludeAmbiguous,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout, password)
+	fmt.Fprintf(c.stderr, "Strength: %s\n", passgen.EstimateStrength(password))
+	return nil
+}
+
+// doStrength estimates strength of password, entered in terminal.
+//
+// Usage: strength.
+func (c *CLI) doStrength(_ context.Context, _ []string) error {
+	password, err := c.fnReadSecret("Password: ")
+	if err != nil {
+		return err
+	}
+
+	strength := passgen.EstimateStrength(password)
+	fmt.Fprintf(c.stdout, "Strength: %s\nEntropy: %.1f bits\n", strength, strength.Entropy)
+	return nil
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"context"
+	"strings"
+)
+
+func (c *CLISuite) TestGenerate() {
+	c.Run("random password", func() {
+		c.stdout.Reset()
+		c.NoError(c.cli.Run(context.Background(), []string{"generate", "-length", "32", "-symbols=false"}))
+		c.Len(strings.TrimSpace(c.stdout.String()), 32)
+	})
+
+	c.Run("passphrase", func() {
+		c.stdout.Reset()
+		c.NoError(c.cli.Run(context.Background(), []string{"generate", "-words", "5", "-sep", "_"}))
+		c.Len(strings.Split(strings.TrimSpace(c.stdout.String()), "_"), 5)
+	})
+
+	c.Run("invalid options", func() {
+		c.Error(c.cli.Run(context.Background(), []string{
+			"generate", "-lower=false", "-upper=false", "-digits=false", "-symbols=false",
+		}))
+	})
+}
+
+func (c *CLISuite) TestStrength() {
+	c.secrets["Password: "] = "password"
+	c.stdout.Reset()
+	c.NoError(c.cli.Run(context.Background(), []string{"strength"}))
+	c.Contains(c.stdout.String(), "Very weak")
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devldavydov/gophkeeper/internal/client/gitcred"
+)
+
+// doGitCredential implements git credential helper protocol.
+// Credential description is read from stdin, answer is written to stdout.
+//
+// Usage: git-credential get|store|erase.
+//
+// Git configuration: git config --global credential.helper "!client -u <login> git-credential".
+func (c *CLI) doGitCredential(_ context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: operation", ErrMissingArgument)
+	}
+
+	cred, err := gitcred.ReadCredential(c.stdin)
+	if err != nil {
+		return err
+	}
+
+	token, err := c.login()
+	if err != nil {
+		return err
+	}
+	helper := gitcred.NewHelper(c.tr, token)
+
+	switch args[0] {
+	case "get":
+		var found *gitcred.Credential
+		if found, err = helper.Get(cred); err != nil || found == nil {
+			return err
+		}
+		return found.Write(c.stdout)
+	case "store":
+		return helper.Store(cred)
+	case "erase":
+		return helper.Erase(cred)
+	default:
+		// Unknown operations must be ignored by helper
+		c.logger.Warnf("git-credential: unknown operation [%s]", args[0])
+		return nil
+	}
+}
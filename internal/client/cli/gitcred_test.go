@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/devldavydov/gophkeeper/internal/client/gitcred"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+)
+
+func (c *CLISuite) TestGitCredential() {
+	c.Run("no operation", func() {
+		c.ErrorIs(c.cli.Run(context.Background(), []string{"git-credential"}), ErrMissingArgument)
+	})
+
+	c.Run("invalid input", func() {
+		c.cli.stdin = bytes.NewBufferString("protocol=https\n\n")
+		c.ErrorIs(c.cli.Run(context.Background(), []string{"git-credential", "get"}), gitcred.ErrInvalidCredential)
+	})
+
+	c.Run("get", func() {
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload("john", "token"))
+		c.Require().NoError(err)
+
+		c.secrets["Password for login: "] = _testPassword
+		c.trMock.EXPECT().UserLogin(_testLogin, _testPassword).Return(_testToken, nil)
+		c.trMock.EXPECT().SecretGetList(_testToken).Return([]model.SecretInfo{
+			{Type: model.CredsSecret, Name: "git:github", Version: 1},
+		}, nil)
+		c.trMock.EXPECT().SecretGet(_testToken, "git:github").Return(&model.Secret{
+			Type: model.CredsSecret, Name: "git:github", Meta: "url=https://github.com", Version: 1, PayloadRaw: payloadRaw,
+		}, nil)
+
+		c.cli.stdin = bytes.NewBufferString("protocol=https\nhost=github.com\n\n")
+		c.stdout.Reset()
+		c.NoError(c.cli.Run(context.Background(), []string{"git-credential", "get"}))
+		c.Equal("username=john\npassword=token\n", c.stdout.String())
+	})
+}
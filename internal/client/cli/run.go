@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/secretref"
+)
+
+var ErrInvalidEnv = errors.New("invalid env definition")
+
+// _childWaitDelay - time for child process to exit after interrupt, before it is killed.
+const _childWaitDelay = 10 * time.Second
+
+// envFlag is a repeatable "NAME=secret:<name>#<field>" flag.
+type envFlag []string
+
+func (e *envFlag) String() string {
+	return strings.Join(*e, ",")
+}
+
+func (e *envFlag) Set(value string) error {
+	*e = append(*e, value)
+	return nil
+}
+
+// doRun runs process with environment variables, resolved from secrets.
+// Secrets are passed to process only in environment.
+//
+// Usage: run -env NAME=secret:<name>[#<field>] [-env ...] -- <command> [arguments].
+func (c *CLI) doRun(ctx context.Context, args []string) error {
+	var envs envFlag
+
+	flagSet := flag.NewFlagSet("run", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	flagSet.Var(&envs, "env", "environment variable NAME=secret:<name>[#<field>], can be repeated")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if flagSet.NArg() == 0 {
+		return fmt.Errorf("%w: command", ErrMissingArgument)
+	}
+
+	names := make([]string, 0, len(envs))
+	refs := make([]secretref.Ref, 0, len(envs))
+	for _, env := range envs {
+		name, strRef, ok := strings.Cut(env, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("%w: %s", ErrInvalidEnv, env)
+		}
+
+		ref, err := secretref.Parse(strRef)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, name)
+		refs = append(refs, ref)
+	}
+
+	token, err := c.login()
+	if err != nil {
+		return err
+	}
+
+	resolver := secretref.NewResolver(c.tr, token)
+	cmdEnv := os.Environ()
+	for i, ref := range refs {
+		value, err := resolver.Resolve(ref)
+		if err != nil {
+			return err
+		}
+		cmdEnv = append(cmdEnv, names[i]+"="+value)
+	}
+
+	cmd := exec.CommandContext(ctx, flagSet.Arg(0), flagSet.Args()[1:]...) //nolint:gosec // OK
+	cmd.Env = cmdEnv
+	cmd.Stdin = c.stdin
+	cmd.Stdout = c.stdout
+	cmd.Stderr = c.stderr
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = _childWaitDelay
+
+	c.logger.Infof("run process [%s] with %d secret env variables", flagSet.Arg(0), len(refs))
+	return cmd.Run()
+}
+
+// doInject renders template with secret values to file or stdout.
+// Template function {{ secret "<name>" "<field>" }} returns secret field value.
+//
+// Usage: inject -i <template> [-o <file>].
+func (c *CLI) doInject(_ context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("inject", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	input := flagSet.String("i", "", "template file")
+	output := flagSet.String("o", "", "output file, stdout if not set")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if *input == "" {
+		return fmt.Errorf("%w: -i", ErrMissingArgument)
+	}
+
+	tmplData, err := os.ReadFile(*input)
+	if err != nil {
+		return err
+	}
+
+	var resolver *secretref.Resolver
+	tmpl, err := template.New(*input).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"secret": func(name string, field ...string) (string, error) {
+				ref := secretref.Ref{Secret: name}
+				if len(field) > 0 {
+					ref.Field = field[0]
+				}
+				return resolver.Resolve(ref)
+			},
+		}).
+		Parse(string(tmplData))
+	if err != nil {
+		return err
+	}
+
+	token, err := c.login()
+	if err != nil {
+		return err
+	}
+	resolver = secretref.NewResolver(c.tr, token)
+
+	// Render to memory first, to not leave partial file on error
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, nil); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = io.Copy(c.stdout, &buf)
+		return err
+	}
+
+	return os.WriteFile(*output, buf.Bytes(), 0600)
+}
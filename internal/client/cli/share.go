@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/secretref"
+	"github.com/devldavydov/gophkeeper/internal/client/share"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+// doShare creates one-time share of secret and prints share link for recipient.
+// Key of share is only in link and is not sent to server.
+//
+// Usage: share [-ttl D] [-views N] <name>.
+func (c *CLI) doShare(_ context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("share", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	ttl := flagSet.Duration("ttl", 0, "share lifetime (server default if 0)")
+	views := flagSet.Int("views", 1, "number of allowed views")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if flagSet.NArg() != 1 {
+		return fmt.Errorf("%w: secret name", ErrMissingArgument)
+	}
+
+	token, err := c.login()
+	if err != nil {
+		return err
+	}
+
+	secret, err := c.tr.SecretGet(token, flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	link, expiresAt, err := share.Create(c.tr, token, secret, *ttl, *views)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.stdout, link)
+	fmt.Fprintf(c.stderr, "Share expires at %s\n", expiresAt.Format(time.DateTime))
+	return nil
+}
+
+// doRedeem retrieves one-time share by link and prints secret fields. Login is not required.
+// Binary secret is written to output file or stdout.
+//
+// Usage: redeem [-o <file>] <link>.
+func (c *CLI) doRedeem(_ context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("redeem", flag.ContinueOnError)
+	flagSet.SetOutput(c.stderr)
+	output := flagSet.String("o", "", "output file for binary secret")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if flagSet.NArg() != 1 {
+		return fmt.Errorf("%w: share link", ErrMissingArgument)
+	}
+
+	secret, viewsLeft, err := share.Redeem(c.tr, flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	payload, err := secret.GetPayload()
+	if err != nil {
+		return err
+	}
+
+	if bin, ok := payload.(*model.BinaryPayload); ok {
+		if *output != "" {
+			err = os.WriteFile(*output, bin.Data, 0600)
+		} else {
+			_, err = c.stdout.Write(bin.Data)
+		}
+	} else {
+		c.printFields(secretref.Fields("", payload))
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.stderr, "Share views left: %d\n", viewsLeft)
+	return nil
+}
+
+// printFields prints non empty secret fields sorted by name.
+func (c *CLI) printFields(fields map[string]string) {
+	names := make([]string, 0, len(fields))
+	for name, value := range fields {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(c.stdout, "%s: %s\n", name, fields[name])
+	}
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/share"
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/common/cipher"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+	"github.com/golang/mock/gomock"
+	"github.com/tinylib/msgp/msgp"
+)
+
+func (c *CLISuite) TestShareRedeem() {
+	for _, tt := range []struct {
+		name      string
+		secret    *model.Secret
+		payload   msgp.Encodable
+		expOutput string
+	}{
+		{
+			name:      "creds",
+			secret:    &model.Secret{Type: model.CredsSecret, Name: "db"},
+			payload:   model.NewCredsPayload("admin", "qwerty"),
+			expOutput: "login: admin\npassword: qwerty\n",
+		},
+		{
+			name:      "binary",
+			secret:    &model.Secret{Type: model.BinarySecret, Name: "file"},
+			payload:   model.NewBinaryPayload([]byte("data")),
+			expOutput: "data",
+		},
+	} {
+		tt := tt
+		c.Run(tt.name, func() {
+			payloadRaw, err := gkMsgp.Serialize(tt.payload)
+			c.Require().NoError(err)
+			tt.secret.PayloadRaw = payloadRaw
+
+			c.cli.userPassword = _testPassword
+			defer func() { c.cli.userPassword = "" }()
+
+			var stored *model.Share
+			c.trMock.EXPECT().UserLogin(_testLogin, _testPassword).Return(_testToken, nil)
+			c.trMock.EXPECT().SecretGet(_testToken, tt.secret.Name).Return(tt.secret, nil)
+			c.trMock.EXPECT().
+				SecretCreateOneTimeShare(_testToken, gomock.Any(), time.Hour).
+				DoAndReturn(func(_ string, share *model.Share, _ time.Duration) (string, time.Time, error) {
+					stored = share
+					return "id", time.Now().Add(time.Hour), nil
+				})
+
+			c.stdout.Reset()
+			c.NoError(c.cli.Run(context.Background(), []string{"share", "-ttl", "1h", tt.secret.Name}))
+			link := strings.TrimSpace(c.stdout.String())
+			c.True(strings.HasPrefix(link, "gkshare:id#"))
+			c.Equal(int32(1), stored.ViewsLeft)
+
+			// Recipient has no account, so no login
+			c.trMock.EXPECT().SecretRedeemOneTimeShare("id").Return(&model.Share{
+				ID: "id", Type: stored.Type, PayloadEncrypted: stored.PayloadEncrypted,
+			}, nil)
+
+			c.stdout.Reset()
+			c.NoError(c.cli.Run(context.Background(), []string{"redeem", link}))
+			c.Equal(tt.expOutput, c.stdout.String())
+		})
+	}
+}
+
+func (c *CLISuite) TestRedeemToFile() {
+	payloadRaw, err := gkMsgp.Serialize(model.NewBinaryPayload([]byte("data")))
+	c.Require().NoError(err)
+
+	key := make([]byte, cipher.AESKeyLength)
+	payloadEncrypted, err := cipher.AESGCMEncrypt(payloadRaw, key)
+	c.Require().NoError(err)
+
+	c.trMock.EXPECT().SecretRedeemOneTimeShare("id").Return(&model.Share{
+		ID: "id", Type: model.BinarySecret, PayloadEncrypted: payloadEncrypted, ViewsLeft: 2,
+	}, nil)
+
+	output := filepath.Join(c.T().TempDir(), "file")
+	c.stdout.Reset()
+	c.NoError(c.cli.Run(context.Background(), []string{"redeem", "-o", output, share.FormatLink("id", key)}))
+	c.Empty(c.stdout.String())
+
+	data, err := os.ReadFile(output)
+	c.NoError(err)
+	c.Equal([]byte("data"), data)
+}
+
+func (c *CLISuite) TestShareRedeemErrors() {
+	c.ErrorIs(c.cli.Run(context.Background(), []string{"share"}), ErrMissingArgument)
+	c.ErrorIs(c.cli.Run(context.Background(), []string{"redeem"}), ErrMissingArgument)
+	c.ErrorIs(c.cli.Run(context.Background(), []string{"redeem", "foo"}), share.ErrInvalidLink)
+
+	c.trMock.EXPECT().SecretRedeemOneTimeShare("id").Return(nil, transport.ErrShareNotFound)
+	err := c.cli.Run(context.Background(), []string{"redeem", share.FormatLink("id", make([]byte, cipher.AESKeyLength))})
+	c.ErrorIs(err, transport.ErrShareNotFound)
+}
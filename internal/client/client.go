@@ -4,12 +4,24 @@ package client
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/devldavydov/gophkeeper/internal/client/breach"
+	"github.com/devldavydov/gophkeeper/internal/client/cli"
+	"github.com/devldavydov/gophkeeper/internal/client/clipboard"
 	"github.com/devldavydov/gophkeeper/internal/client/transport"
 	"github.com/devldavydov/gophkeeper/internal/client/ui"
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	_defaultProfileName = "default"
+
+	_tracingServiceName     = "gophkeeper-client"
+	_tracingShutdownTimeout = 5 * time.Second
+)
+
 // Client represents client application.
 type Client struct {
 	settings *Settings
@@ -23,18 +35,37 @@ func NewClient(settngs *Settings, logger *logrus.Logger) *Client {
 
 // Start - starts client application:
 //
-// - initialize transport with server.
+// - initialize tracing, if configured.
+//
+// - initialize transport with server of active profile.
 //
 // - start UI application.
 func (r *Client) Start(ctx context.Context) error {
+	stopTracing, err := r.initTracing(ctx)
+	if err != nil {
+		return err
+	}
+	defer stopTracing()
+
 	// Create Transport
-	tr, err := transport.NewGrpcTransport(r.settings.ServerAddress, r.settings.TLSCACertPath, r.logger)
+	profiles := r.uiProfiles()
+	tr, err := profiles[0].Connect()
 	if err != nil {
 		return err
 	}
 
+	// Create breach lookup, if configured
+	var breachLookup breach.Lookup
+	if r.settings.BreachSource != "" {
+		breachLookup = breach.NewLookup(r.settings.BreachSource)
+	}
+
+	// Clear pending clipboard copy on exit
+	clip := clipboard.NewManager(clipboard.Detect(), r.settings.ClipboardClearTimeout, r.logger)
+	defer clip.Close()
+
 	// Start UI application
-	uiApp := ui.NewApp(tr, r.logger)
+	uiApp := ui.NewApp(tr, profiles, breachLookup, clip, r.settings.IdleLockTimeout, r.logger)
 
 	errChan := make(chan error)
 	go func(ch chan error) {
@@ -55,3 +86,92 @@ func (r *Client) Start(ctx context.Context) error {
 		return nil
 	}
 }
+
+// RunCommand - runs client command without UI.
+//
+// First element of args is a command name, others are command arguments.
+func (r *Client) RunCommand(ctx context.Context, args []string) error {
+	stopTracing, err := r.initTracing(ctx)
+	if err != nil {
+		return err
+	}
+	defer stopTracing()
+
+	tr, err := transport.NewGrpcTransport(
+		r.settings.ServerAddress,
+		r.settings.TLSCACertPath,
+		r.settings.TLSCertPath,
+		r.settings.TLSKeyPath,
+		r.logger,
+	)
+	if err != nil {
+		return err
+	}
+
+	return cli.NewCLI(
+		tr,
+		r.settings.UserLogin,
+		r.settings.UserPassword,
+		r.settings.BreachSource,
+		r.logger,
+	).Run(ctx, args)
+}
+
+// uiProfiles returns server profiles for UI. First is an active profile from settings,
+// it is named "default", if settings are not from named profile.
+func (r *Client) uiProfiles() []ui.Profile {
+	active := NewProfile(
+		r.settings.Profile,
+		r.settings.ServerAddress,
+		r.settings.TLSCACertPath,
+		r.settings.TLSCertPath,
+		r.settings.TLSKeyPath,
+		r.settings.UserLogin,
+	)
+	if active.Name == "" {
+		active.Name = _defaultProfileName
+	}
+
+	profiles := []ui.Profile{r.uiProfile(active)}
+	for _, p := range r.settings.Profiles {
+		if p.Name != active.Name {
+			profiles = append(profiles, r.uiProfile(p))
+		}
+	}
+
+	return profiles
+}
+
+func (r *Client) uiProfile(p *Profile) ui.Profile {
+	return ui.Profile{
+		Name:      p.Name,
+		UserLogin: p.UserLogin,
+		Connect: func() (transport.Transport, error) {
+			return transport.NewGrpcTransport(p.ServerAddress, p.TLSCACertPath, p.TLSCertPath, p.TLSKeyPath, r.logger)
+		},
+	}
+}
+
+// initTracing initializes tracing, if configured.
+// Stdout exporter writes to log, because stdout is used by UI and commands.
+//
+// Returns function to flush not exported spans.
+func (r *Client) initTracing(ctx context.Context) (func(), error) {
+	if r.settings.Tracing == nil {
+		return func() {}, nil
+	}
+
+	tp, err := tracing.Init(ctx, r.settings.Tracing, _tracingServiceName, r.logger.Out, r.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init tracing: %w", err)
+	}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), _tracingShutdownTimeout)
+		defer cancel()
+
+		if err := tp.Shutdown(ctx); err != nil {
+			r.logger.Errorf("tracing shutdown error: %v", err)
+		}
+	}, nil
+}
@@ -0,0 +1,130 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+var ErrReadNotSupported = errors.New("clipboard read not supported")
+
+// _cmdWaitDelay - time to wait for stdin copy after write command exited.
+const _cmdWaitDelay = time.Second
+
+// Backend is an interface for system clipboard access.
+type Backend interface {
+	// Write puts text to clipboard.
+	Write(text string) error
+	// Read returns clipboard text or ErrReadNotSupported.
+	Read() (string, error)
+}
+
+// Detect returns best available backend: wl-copy in Wayland session,
+// xclip in X11 session, OSC 52 terminal escape sequence otherwise.
+func Detect() Backend {
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		if _, err := exec.LookPath("wl-copy"); err == nil {
+			return NewCmdBackend([]string{"wl-copy"}, []string{"wl-paste", "--no-newline"})
+		}
+	}
+
+	if os.Getenv("DISPLAY") != "" {
+		if _, err := exec.LookPath("xclip"); err == nil {
+			return NewCmdBackend(
+				[]string{"xclip", "-in", "-selection", "clipboard"},
+				[]string{"xclip", "-out", "-selection", "clipboard"})
+		}
+	}
+
+	return NewOSC52Backend(nil)
+}
+
+// CmdBackend is a Backend implementation with external commands.
+// Text is passed to write command stdin and taken from read command stdout.
+type CmdBackend struct {
+	writeCmd []string
+	readCmd  []string
+}
+
+var _ Backend = (*CmdBackend)(nil)
+
+// NewCmdBackend creates new CmdBackend object. If readCmd is empty, read is not supported.
+func NewCmdBackend(writeCmd, readCmd []string) *CmdBackend {
+	return &CmdBackend{writeCmd: writeCmd, readCmd: readCmd}
+}
+
+// Write runs write command with text in stdin.
+//
+// xclip and wl-copy fork background child, which serves selection and inherits command stdout and stderr.
+// So stdout is discarded and stderr is captured to temporary file instead of pipe, otherwise
+// Write blocks till child exits, when another program takes clipboard.
+func (c *CmdBackend) Write(text string) error {
+	stderr, err := os.CreateTemp("", "gophkeeper-clipboard-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		stderr.Close()
+		os.Remove(stderr.Name())
+	}()
+
+	cmd := exec.Command(c.writeCmd[0], c.writeCmd[1:]...) //nolint:gosec // OK
+	cmd.Stdin = bytes.NewBufferString(text)
+	cmd.Stderr = stderr
+	cmd.WaitDelay = _cmdWaitDelay
+	if err = cmd.Run(); err != nil {
+		out, _ := os.ReadFile(stderr.Name())
+		return fmt.Errorf("%s: %w: %s", c.writeCmd[0], err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+func (c *CmdBackend) Read() (string, error) {
+	if len(c.readCmd) == 0 {
+		return "", ErrReadNotSupported
+	}
+
+	out, err := exec.Command(c.readCmd[0], c.readCmd[1:]...).Output() //nolint:gosec // OK
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", c.readCmd[0], err)
+	}
+	return string(out), nil
+}
+
+// OSC52Backend is a Backend implementation with OSC 52 terminal escape sequence.
+// Terminal puts text to system clipboard, so it works over SSH too.
+// Read is not supported, because most terminals forbid it.
+type OSC52Backend struct {
+	w io.Writer
+}
+
+var _ Backend = (*OSC52Backend)(nil)
+
+// NewOSC52Backend creates new OSC52Backend object. If w is nil, sequence is written to /dev/tty.
+func NewOSC52Backend(w io.Writer) *OSC52Backend {
+	return &OSC52Backend{w: w}
+}
+
+func (o *OSC52Backend) Write(text string) error {
+	w := o.w
+	if w == nil {
+		tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		defer tty.Close()
+		w = tty
+	}
+
+	_, err := fmt.Fprintf(w, "\x1b]52;c;%s\x07", base64.StdEncoding.EncodeToString([]byte(text)))
+	return err
+}
+
+func (o *OSC52Backend) Read() (string, error) {
+	return "", ErrReadNotSupported
+}
@@ -0,0 +1,100 @@
+// Package clipboard contains system clipboard access with automatic clearing.
+package clipboard
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Manager copies text to clipboard and clears it after timeout.
+//
+// Clipboard is not cleared, if it was changed since copy. If backend can't read
+// clipboard, only copies made by Manager are tracked.
+type Manager struct {
+	backend      Backend
+	clearTimeout time.Duration
+	logger       *logrus.Logger
+	//
+	mu     sync.Mutex
+	timer  *time.Timer
+	copied string
+	seq    int
+}
+
+// NewManager creates new Manager object. If clearTimeout is zero, clipboard is never cleared.
+func NewManager(backend Backend, clearTimeout time.Duration, logger *logrus.Logger) *Manager {
+	return &Manager{backend: backend, clearTimeout: clearTimeout, logger: logger}
+}
+
+// ClearTimeout returns clipboard clear timeout.
+func (m *Manager) ClearTimeout() time.Duration {
+	return m.clearTimeout
+}
+
+// Copy puts text to clipboard and schedules clearing.
+func (m *Manager) Copy(text string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.backend.Write(text); err != nil {
+		return err
+	}
+
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	m.copied = text
+	m.seq++
+
+	if m.clearTimeout > 0 {
+		seq := m.seq
+		m.timer = time.AfterFunc(m.clearTimeout, func() { m.clear(seq) })
+	}
+
+	return nil
+}
+
+// Close clears pending clipboard copy immediately.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	pending := m.timer != nil && m.timer.Stop()
+	seq := m.seq
+	m.mu.Unlock()
+
+	if pending {
+		m.clear(seq)
+	}
+}
+
+// clear clears clipboard, if no other copy was made after copy with seq number.
+func (m *Manager) clear(seq int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if seq != m.seq {
+		return
+	}
+	m.timer = nil
+
+	current, err := m.backend.Read()
+	switch {
+	case errors.Is(err, ErrReadNotSupported):
+	case err != nil:
+		m.logger.Errorf("clipboard read error: %v", err)
+		return
+	case current != m.copied:
+		m.logger.Debug("clipboard changed since copy, not cleared")
+		return
+	}
+
+	if err = m.backend.Write(""); err != nil {
+		m.logger.Errorf("clipboard clear error: %v", err)
+		return
+	}
+	m.copied = ""
+	m.logger.Debug("clipboard cleared")
+}
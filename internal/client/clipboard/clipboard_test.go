@@ -0,0 +1,155 @@
+package clipboard
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBackend struct {
+	mu       sync.Mutex
+	text     string
+	readable bool
+	writes   int
+}
+
+func (t *testBackend) Write(text string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.text = text
+	t.writes++
+	return nil
+}
+
+func (t *testBackend) Read() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.readable {
+		return "", ErrReadNotSupported
+	}
+	return t.text, nil
+}
+
+func (t *testBackend) get() (string, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.text, t.writes
+}
+
+func (t *testBackend) set(text string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.text = text
+}
+
+const _testTimeout = 50 * time.Millisecond
+
+func TestManagerClear(t *testing.T) {
+	for _, readable := range []bool{true, false} {
+		backend := &testBackend{readable: readable}
+		m := NewManager(backend, _testTimeout, logrus.New())
+
+		require.NoError(t, m.Copy("secret"))
+		text, _ := backend.get()
+		assert.Equal(t, "secret", text)
+
+		assert.Eventually(t, func() bool {
+			text, _ := backend.get()
+			return text == ""
+		}, 10*_testTimeout, _testTimeout/5)
+	}
+}
+
+func TestManagerNotClearChanged(t *testing.T) {
+	backend := &testBackend{readable: true}
+	m := NewManager(backend, _testTimeout, logrus.New())
+
+	require.NoError(t, m.Copy("secret"))
+	backend.set("other")
+
+	time.Sleep(3 * _testTimeout)
+	text, writes := backend.get()
+	assert.Equal(t, "other", text)
+	assert.Equal(t, 1, writes)
+}
+
+func TestManagerRecopy(t *testing.T) {
+	backend := &testBackend{}
+	m := NewManager(backend, 3*_testTimeout, logrus.New())
+
+	require.NoError(t, m.Copy("first"))
+	time.Sleep(2 * _testTimeout)
+	require.NoError(t, m.Copy("second"))
+
+	// First copy timeout passed, second copy still in clipboard
+	time.Sleep(2 * _testTimeout)
+	text, _ := backend.get()
+	assert.Equal(t, "second", text)
+}
+
+func TestManagerClose(t *testing.T) {
+	backend := &testBackend{}
+	m := NewManager(backend, time.Hour, logrus.New())
+
+	require.NoError(t, m.Copy("secret"))
+	m.Close()
+	text, writes := backend.get()
+	assert.Equal(t, "", text)
+	assert.Equal(t, 2, writes)
+
+	// Nothing pending
+	m.Close()
+	_, writes = backend.get()
+	assert.Equal(t, 2, writes)
+}
+
+func TestManagerNoTimeout(t *testing.T) {
+	backend := &testBackend{}
+	m := NewManager(backend, 0, logrus.New())
+
+	require.NoError(t, m.Copy("secret"))
+	m.Close()
+	text, _ := backend.get()
+	assert.Equal(t, "secret", text)
+}
+
+func TestOSC52Backend(t *testing.T) {
+	buf := &bytes.Buffer{}
+	backend := NewOSC52Backend(buf)
+
+	require.NoError(t, backend.Write("secret"))
+	assert.Equal(t, "\x1b]52;c;c2VjcmV0\x07", buf.String())
+
+	_, err := backend.Read()
+	assert.ErrorIs(t, err, ErrReadNotSupported)
+}
+
+func TestCmdBackend(t *testing.T) {
+	backend := NewCmdBackend([]string{"cat"}, []string{"echo", "-n", "secret"})
+	require.NoError(t, backend.Write("secret"))
+
+	text, err := backend.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "secret", text)
+
+	_, err = NewCmdBackend([]string{"false"}, nil).Read()
+	assert.ErrorIs(t, err, ErrReadNotSupported)
+	assert.Error(t, NewCmdBackend([]string{"false"}, nil).Write("secret"))
+
+	err = NewCmdBackend([]string{"sh", "-c", "echo no display >&2; exit 1"}, nil).Write("secret")
+	assert.ErrorContains(t, err, "no display")
+}
+
+func TestCmdBackendBackgroundChild(t *testing.T) {
+	// Like xclip, command reads text and leaves child, holding stdout and stderr, to serve selection
+	backend := NewCmdBackend([]string{"sh", "-c", "cat >/dev/null; sleep 10 &"}, nil)
+
+	start := time.Now()
+	require.NoError(t, backend.Write("secret"))
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
@@ -0,0 +1,220 @@
+// Package dockercred contains docker credential helper on top of credentials secrets.
+//
+// Secret is used by docker, if its name starts with "docker:". Registry server is taken from
+// "registry=<server URL>" meta line or, if it is missing, from secret name after prefix.
+// Other secrets are never retrieved from server.
+// Secret login and password are returned as registry username and secret.
+package dockercred
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/vault"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+)
+
+var (
+	// ErrCredentialsNotFound message is defined by docker credential helpers protocol.
+	ErrCredentialsNotFound = errors.New("credentials not found in native keychain")
+	ErrInvalidCredentials  = errors.New("invalid docker credentials")
+)
+
+const (
+	// MetaRegistryKey - key of secret meta line with registry server URL.
+	MetaRegistryKey = "registry"
+
+	_secretNamePrefix = "docker:"
+)
+
+// Credentials represents docker registry credentials in helper protocol format.
+type Credentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+// Helper implements docker credential helper operations with transport.
+type Helper struct {
+	tr    transport.Transport
+	token string
+}
+
+// NewHelper creates new Helper object.
+func NewHelper(tr transport.Transport, token string) *Helper {
+	return &Helper{tr: tr, token: token}
+}
+
+// registrySecret represents credentials secret with registry.
+type registrySecret struct {
+	info     model.SecretInfo
+	meta     string
+	registry string
+	creds    *model.CredsPayload
+}
+
+// Get returns credentials for registry server.
+//
+// Returns credentials or error:
+//
+// - ErrCredentialsNotFound - no secret for server.
+//
+// - transport error.
+func (h *Helper) Get(serverURL string) (*Credentials, error) {
+	secret, err := h.find(serverURL)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil {
+		return nil, ErrCredentialsNotFound
+	}
+
+	return &Credentials{ServerURL: serverURL, Username: secret.creds.Login, Secret: secret.creds.Password}, nil
+}
+
+// Store creates secret for registry server or updates existing one.
+func (h *Helper) Store(creds *Credentials) error {
+	if creds.ServerURL == "" {
+		return fmt.Errorf("%w: server URL required", ErrInvalidCredentials)
+	}
+
+	secret, err := h.find(creds.ServerURL)
+	if err != nil {
+		return err
+	}
+
+	payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload(creds.Username, creds.Secret))
+	if err != nil {
+		return err
+	}
+
+	if secret != nil {
+		if secret.creds.Login == creds.Username && secret.creds.Password == creds.Secret {
+			return nil
+		}
+
+		return h.tr.SecretUpdate(h.token, secret.info.Name, &model.SecretUpdate{
+			Meta:          secret.meta,
+			Version:       secret.info.Version + 1,
+			PayloadRaw:    payloadRaw,
+			UpdatePayload: true,
+			ExpiresAt:     secret.info.ExpiresAt,
+			RotateEvery:   secret.info.RotateEvery,
+		})
+	}
+
+	return h.tr.SecretCreate(h.token, &model.Secret{
+		Type:       model.CredsSecret,
+		Name:       secretName(creds.ServerURL),
+		Meta:       fmt.Sprintf("%s=%s", MetaRegistryKey, creds.ServerURL),
+		PayloadRaw: payloadRaw,
+	})
+}
+
+// Erase deletes secret for registry server.
+//
+// Returns ErrCredentialsNotFound, if no secret for server, or transport error.
+func (h *Helper) Erase(serverURL string) error {
+	secret, err := h.find(serverURL)
+	if err != nil {
+		return err
+	}
+	if secret == nil {
+		return ErrCredentialsNotFound
+	}
+
+	return h.tr.SecretDelete(h.token, secret.info.Name)
+}
+
+// List returns usernames by registry server URLs.
+func (h *Helper) List() (map[string]string, error) {
+	secrets, err := h.registrySecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]string, len(secrets))
+	for _, secret := range secrets {
+		res[secret.registry] = secret.creds.Login
+	}
+
+	return res, nil
+}
+
+// find returns secret for registry server or nil.
+// Secret, named by server, is preferred over others with server in registry meta.
+func (h *Helper) find(serverURL string) (*registrySecret, error) {
+	secrets, err := h.registrySecrets()
+	if err != nil {
+		return nil, err
+	}
+
+	name := secretName(serverURL)
+	for i := range secrets {
+		if secrets[i].info.Name == name {
+			return &secrets[i], nil
+		}
+	}
+
+	server := NormalizeServerURL(serverURL)
+	for i := range secrets {
+		if NormalizeServerURL(secrets[i].registry) == server {
+			return &secrets[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// registrySecrets returns all credentials secrets with docker name prefix.
+func (h *Helper) registrySecrets() ([]registrySecret, error) {
+	var secrets []registrySecret
+	fnSecret := func(secretInfo model.SecretInfo, secret *model.Secret, payload model.Payload) error {
+		registry := metaRegistry(secret.Meta)
+		if registry == "" {
+			registry = strings.TrimPrefix(secret.Name, _secretNamePrefix)
+		}
+
+		creds, _ := payload.(*model.CredsPayload)
+		secrets = append(secrets, registrySecret{info: secretInfo, meta: secret.Meta, registry: registry, creds: creds})
+		return nil
+	}
+
+	if err := vault.ForEachSecret(h.tr, h.token, isDockerSecret, fnSecret); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// isDockerSecret accepts credentials secrets with docker name prefix.
+func isDockerSecret(secretInfo model.SecretInfo) bool {
+	return secretInfo.Type == model.CredsSecret && strings.HasPrefix(secretInfo.Name, _secretNamePrefix)
+}
+
+func secretName(serverURL string) string {
+	return _secretNamePrefix + NormalizeServerURL(serverURL)
+}
+
+// NormalizeServerURL converts registry server URL to "host[/path]" form, so
+// "https://registry.example.com/" and "registry.example.com" are the same server.
+func NormalizeServerURL(serverURL string) string {
+	server := strings.TrimSpace(serverURL)
+	if i := strings.Index(server, "://"); i != -1 {
+		server = server[i+3:]
+	}
+	return strings.ToLower(strings.TrimRight(server, "/"))
+}
+
+func metaRegistry(meta string) string {
+	for _, line := range strings.Split(meta, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if ok && strings.TrimSpace(key) == MetaRegistryKey {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
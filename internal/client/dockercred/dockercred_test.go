@@ -0,0 +1,166 @@
+package dockercred
+
+import (
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport/mocks"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const _testToken = "token"
+
+func TestNormalizeServerURL(t *testing.T) {
+	assert.Equal(t, "index.docker.io/v1", NormalizeServerURL("https://index.docker.io/v1/"))
+	assert.Equal(t, "registry.example.com:5000", NormalizeServerURL(" Registry.example.com:5000 "))
+	assert.Equal(t, "registry.example.com", NormalizeServerURL("http://registry.example.com/"))
+}
+
+type DockerCredSuite struct {
+	suite.Suite
+	gmckCtrl *gomock.Controller
+	trMock   *mocks.MockTransport
+	helper   *Helper
+}
+
+func (d *DockerCredSuite) SetupTest() {
+	d.gmckCtrl = gomock.NewController(d.T())
+	d.trMock = mocks.NewMockTransport(d.gmckCtrl)
+	d.helper = NewHelper(d.trMock, _testToken)
+}
+
+func (d *DockerCredSuite) TearDownTest() {
+	d.gmckCtrl.Finish()
+}
+
+func (d *DockerCredSuite) expectSecrets() {
+	secrets := []struct {
+		name, meta, login, password string
+	}{
+		{"docker:hub", "registry=https://index.docker.io/v1/", "john", "hub-token"},
+		{"docker:private", "CI registry\nregistry = registry.example.com", "ci", "private-token"},
+		{"docker:quay.io", "no registry", "jane", "quay-token"},
+	}
+
+	lst := make([]model.SecretInfo, 0, len(secrets)+1)
+	for _, s := range secrets {
+		lst = append(lst, model.SecretInfo{Type: model.CredsSecret, Name: s.name, Version: 1})
+
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload(s.login, s.password))
+		d.Require().NoError(err)
+		d.trMock.EXPECT().SecretGet(_testToken, s.name).Return(&model.Secret{
+			Type: model.CredsSecret, Name: s.name, Meta: s.meta, Version: 1, PayloadRaw: payloadRaw,
+		}, nil)
+	}
+
+	// Not docker secrets are never retrieved
+	lst = append(lst, model.SecretInfo{Type: model.CredsSecret, Name: "other", Version: 1})
+
+	d.trMock.EXPECT().SecretGetList(_testToken).Return(lst, nil)
+}
+
+func (d *DockerCredSuite) TestGet() {
+	d.Run("found", func() {
+		d.expectSecrets()
+		creds, err := d.helper.Get("https://registry.example.com")
+		d.NoError(err)
+		d.Equal(&Credentials{ServerURL: "https://registry.example.com", Username: "ci", Secret: "private-token"}, creds)
+	})
+
+	d.Run("found by name", func() {
+		d.expectSecrets()
+		creds, err := d.helper.Get("https://quay.io/")
+		d.NoError(err)
+		d.Equal(&Credentials{ServerURL: "https://quay.io/", Username: "jane", Secret: "quay-token"}, creds)
+	})
+
+	d.Run("not found", func() {
+		d.expectSecrets()
+		_, err := d.helper.Get("ghcr.io")
+		d.ErrorIs(err, ErrCredentialsNotFound)
+	})
+}
+
+func (d *DockerCredSuite) TestList() {
+	d.expectSecrets()
+	lst, err := d.helper.List()
+	d.NoError(err)
+	d.Equal(map[string]string{
+		"https://index.docker.io/v1/": "john",
+		"registry.example.com":        "ci",
+		"quay.io":                     "jane",
+	}, lst)
+}
+
+func (d *DockerCredSuite) TestStore() {
+	d.Run("create", func() {
+		d.expectSecrets()
+
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload("jane", "ghcr-token"))
+		d.Require().NoError(err)
+		d.trMock.EXPECT().SecretCreate(_testToken, &model.Secret{
+			Type:       model.CredsSecret,
+			Name:       "docker:ghcr.io",
+			Meta:       "registry=ghcr.io",
+			PayloadRaw: payloadRaw,
+		}).Return(nil)
+
+		d.NoError(d.helper.Store(&Credentials{ServerURL: "ghcr.io", Username: "jane", Secret: "ghcr-token"}))
+	})
+
+	d.Run("update", func() {
+		d.expectSecrets()
+
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload("john", "new-token"))
+		d.Require().NoError(err)
+		d.trMock.EXPECT().SecretUpdate(_testToken, "docker:hub", &model.SecretUpdate{
+			Meta:          "registry=https://index.docker.io/v1/",
+			Version:       2,
+			PayloadRaw:    payloadRaw,
+			UpdatePayload: true,
+		}).Return(nil)
+
+		d.NoError(d.helper.Store(&Credentials{
+			ServerURL: "https://index.docker.io/v1/", Username: "john", Secret: "new-token",
+		}))
+	})
+
+	d.Run("update without registry meta", func() {
+		d.expectSecrets()
+
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload("jane", "new-token"))
+		d.Require().NoError(err)
+		d.trMock.EXPECT().SecretUpdate(_testToken, "docker:quay.io", &model.SecretUpdate{
+			Meta:          "no registry",
+			Version:       2,
+			PayloadRaw:    payloadRaw,
+			UpdatePayload: true,
+		}).Return(nil)
+
+		d.NoError(d.helper.Store(&Credentials{ServerURL: "quay.io", Username: "jane", Secret: "new-token"}))
+	})
+
+	d.Run("invalid", func() {
+		d.ErrorIs(d.helper.Store(&Credentials{}), ErrInvalidCredentials)
+	})
+}
+
+func (d *DockerCredSuite) TestErase() {
+	d.Run("found", func() {
+		d.expectSecrets()
+		d.trMock.EXPECT().SecretDelete(_testToken, "docker:private").Return(nil)
+		d.NoError(d.helper.Erase("registry.example.com"))
+	})
+
+	d.Run("not found", func() {
+		d.expectSecrets()
+		d.ErrorIs(d.helper.Erase("ghcr.io"), ErrCredentialsNotFound)
+	})
+}
+
+func TestDockerCredSuite(t *testing.T) {
+	suite.Run(t, new(DockerCredSuite))
+}
@@ -0,0 +1,294 @@
+// Package gitcred contains git credential helper on top of credentials secrets.
+//
+// Secret is used by git, if its name starts with "git:" and its meta contains
+// "url=<protocol>://<host>[/<path>]" line. Other secrets are never retrieved from server.
+// Secret login and password are returned as git username and password.
+package gitcred
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/vault"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+)
+
+var ErrInvalidCredential = errors.New("invalid git credential")
+
+const (
+	// MetaURLKey - key of secret meta line with URL for git.
+	MetaURLKey = "url"
+
+	_secretNamePrefix = "git:"
+)
+
+// Credential represents git credential description.
+// See https://git-scm.com/docs/git-credential#IOFMT.
+type Credential struct {
+	Protocol string
+	Host     string
+	Path     string
+	Username string
+	Password string
+}
+
+// ReadCredential reads credential description in git "key=value" format
+// till empty line or EOF. Unknown keys are ignored.
+//
+// Returns credential or ErrInvalidCredential, if protocol or host missing.
+func ReadCredential(r io.Reader) (*Credential, error) {
+	cred := &Credential{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCredential, line)
+		}
+
+		switch key {
+		case "protocol":
+			cred.Protocol = value
+		case "host":
+			cred.Host = value
+		case "path":
+			cred.Path = value
+		case "username":
+			cred.Username = value
+		case "password":
+			cred.Password = value
+		case "url":
+			u, err := url.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidCredential, err)
+			}
+			cred.Protocol, cred.Host, cred.Path = u.Scheme, u.Host, strings.TrimPrefix(u.Path, "/")
+			if u.User != nil {
+				cred.Username = u.User.Username()
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if cred.Protocol == "" || cred.Host == "" {
+		return nil, fmt.Errorf("%w: protocol and host required", ErrInvalidCredential)
+	}
+
+	return cred, nil
+}
+
+// Write writes username and password in git format.
+func (c *Credential) Write(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "username=%s\npassword=%s\n", c.Username, c.Password)
+	return err
+}
+
+// URL returns credential URL without user.
+func (c *Credential) URL() string {
+	return c.url(nil)
+}
+
+func (c *Credential) url(user *url.Userinfo) string {
+	u := url.URL{Scheme: c.Protocol, User: user, Host: c.Host, Path: "/" + strings.Trim(c.Path, "/")}
+	return strings.TrimSuffix(u.String(), "/")
+}
+
+// Helper implements git credential helper operations with transport.
+type Helper struct {
+	tr    transport.Transport
+	token string
+}
+
+// NewHelper creates new Helper object.
+func NewHelper(tr transport.Transport, token string) *Helper {
+	return &Helper{tr: tr, token: token}
+}
+
+// match represents credentials secret, matched to git credential.
+type match struct {
+	info      model.SecretInfo
+	creds     *model.CredsPayload
+	meta      string
+	pathScore int
+}
+
+// Get returns credential with username and password from most specific matched secret.
+//
+// Returns nil, if no secret matched.
+func (h *Helper) Get(cred *Credential) (*Credential, error) {
+	matches, err := h.find(cred)
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+
+	return &Credential{
+		Protocol: cred.Protocol,
+		Host:     cred.Host,
+		Path:     cred.Path,
+		Username: matches[0].creds.Login,
+		Password: matches[0].creds.Password,
+	}, nil
+}
+
+// Store creates secret for credential or updates password of matched secret with same username.
+func (h *Helper) Store(cred *Credential) error {
+	if cred.Username == "" || cred.Password == "" {
+		return fmt.Errorf("%w: username and password required", ErrInvalidCredential)
+	}
+
+	matches, err := h.find(cred)
+	if err != nil {
+		return err
+	}
+
+	payload := model.NewCredsPayload(cred.Username, cred.Password)
+	payloadRaw, err := gkMsgp.Serialize(payload)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		if m.creds.Login != cred.Username || !samePath(m, cred) {
+			continue
+		}
+		if m.creds.Password == cred.Password {
+			return nil
+		}
+
+		return h.tr.SecretUpdate(h.token, m.info.Name, &model.SecretUpdate{
+			Meta:          m.meta,
+			Version:       m.info.Version + 1,
+			PayloadRaw:    payloadRaw,
+			UpdatePayload: true,
+			ExpiresAt:     m.info.ExpiresAt,
+			RotateEvery:   m.info.RotateEvery,
+		})
+	}
+
+	return h.tr.SecretCreate(h.token, &model.Secret{
+		Type:       model.CredsSecret,
+		Name:       _secretNamePrefix + cred.url(url.User(cred.Username)),
+		Meta:       fmt.Sprintf("%s=%s", MetaURLKey, cred.URL()),
+		PayloadRaw: payloadRaw,
+	})
+}
+
+// Erase deletes matched secrets with same username and password, if they are set.
+func (h *Helper) Erase(cred *Credential) error {
+	matches, err := h.find(cred)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		if cred.Password != "" && m.creds.Password != cred.Password {
+			continue
+		}
+		if err = h.tr.SecretDelete(h.token, m.info.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// find returns credentials secrets matched to credential, most specific path first.
+// If credential has username, only secrets with same login are matched.
+func (h *Helper) find(cred *Credential) ([]match, error) {
+	var matches []match
+	fnMatch := func(secretInfo model.SecretInfo, secret *model.Secret, payload model.Payload) error {
+		pathScore, ok := matchURL(secret.Meta, cred)
+		if !ok {
+			return nil
+		}
+
+		creds, _ := payload.(*model.CredsPayload)
+		if cred.Username != "" && creds.Login != cred.Username {
+			return nil
+		}
+
+		matches = append(matches, match{info: secretInfo, creds: creds, meta: secret.Meta, pathScore: pathScore})
+		return nil
+	}
+
+	if err := vault.ForEachSecret(h.tr, h.token, isGitSecret, fnMatch); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].pathScore > matches[j].pathScore
+	})
+
+	return matches, nil
+}
+
+// isGitSecret accepts credentials secrets with git name prefix.
+func isGitSecret(secretInfo model.SecretInfo) bool {
+	return secretInfo.Type == model.CredsSecret && strings.HasPrefix(secretInfo.Name, _secretNamePrefix)
+}
+
+// matchURL checks secret meta URLs against credential.
+// Secret URL path must be a prefix of credential path by segments.
+//
+// Returns number of matched path segments of best URL and match flag.
+func matchURL(meta string, cred *Credential) (int, bool) {
+	best, matched := 0, false
+
+	for _, line := range strings.Split(meta, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || strings.TrimSpace(key) != MetaURLKey {
+			continue
+		}
+
+		u, err := url.Parse(strings.TrimSpace(value))
+		if err != nil || !strings.EqualFold(u.Scheme, cred.Protocol) || !strings.EqualFold(u.Host, cred.Host) {
+			continue
+		}
+
+		secretPath := pathSegments(u.Path)
+		credPath := pathSegments(cred.Path)
+		if len(secretPath) > len(credPath) {
+			continue
+		}
+
+		prefix := true
+		for i := range secretPath {
+			if secretPath[i] != credPath[i] {
+				prefix = false
+				break
+			}
+		}
+
+		if prefix && (!matched || len(secretPath) > best) {
+			best, matched = len(secretPath), true
+		}
+	}
+
+	return best, matched
+}
+
+func samePath(m match, cred *Credential) bool {
+	return m.pathScore == len(pathSegments(cred.Path))
+}
+
+func pathSegments(path string) []string {
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
@@ -0,0 +1,185 @@
+package gitcred
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport/mocks"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+const _testToken = "token"
+
+func TestReadCredential(t *testing.T) {
+	for i, tt := range []struct {
+		input   string
+		expCred *Credential
+		isErr   bool
+	}{
+		{
+			input:   "protocol=https\nhost=github.com\npath=org/repo.git\nusername=john\ncapability[]=authtype\n\n",
+			expCred: &Credential{Protocol: "https", Host: "github.com", Path: "org/repo.git", Username: "john"},
+		},
+		{
+			input:   "url=https://john@example.com:8443/repo\r\n",
+			expCred: &Credential{Protocol: "https", Host: "example.com:8443", Path: "repo", Username: "john"},
+		},
+		{input: "protocol=https\n", isErr: true},
+		{input: "protocol=https\nbad line\n", isErr: true},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Run %d", i), func(t *testing.T) {
+			cred, err := ReadCredential(strings.NewReader(tt.input))
+			if tt.isErr {
+				assert.ErrorIs(t, err, ErrInvalidCredential)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expCred, cred)
+		})
+	}
+}
+
+func TestCredentialWrite(t *testing.T) {
+	buf := &bytes.Buffer{}
+	require.NoError(t, (&Credential{Username: "john", Password: "secret"}).Write(buf))
+	assert.Equal(t, "username=john\npassword=secret\n", buf.String())
+
+	assert.Equal(t, "https://github.com/org/repo", (&Credential{Protocol: "https", Host: "github.com", Path: "/org/repo/"}).URL())
+	assert.Equal(t, "https://github.com", (&Credential{Protocol: "https", Host: "github.com"}).URL())
+}
+
+type GitCredSuite struct {
+	suite.Suite
+	gmckCtrl *gomock.Controller
+	trMock   *mocks.MockTransport
+	helper   *Helper
+}
+
+func (g *GitCredSuite) SetupTest() {
+	g.gmckCtrl = gomock.NewController(g.T())
+	g.trMock = mocks.NewMockTransport(g.gmckCtrl)
+	g.helper = NewHelper(g.trMock, _testToken)
+}
+
+func (g *GitCredSuite) TearDownTest() {
+	g.gmckCtrl.Finish()
+}
+
+// expectSecrets sets up list and get of git secrets. Every secret is described by name, meta, login and password.
+func (g *GitCredSuite) expectSecrets(secrets ...[4]string) {
+	lst := make([]model.SecretInfo, 0, len(secrets)+1)
+	for _, s := range secrets {
+		lst = append(lst, model.SecretInfo{Type: model.CredsSecret, Name: s[0], Version: 2})
+
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload(s[2], s[3]))
+		g.Require().NoError(err)
+		g.trMock.EXPECT().SecretGet(_testToken, s[0]).Return(&model.Secret{
+			Type: model.CredsSecret, Name: s[0], Meta: s[1], Version: 2, PayloadRaw: payloadRaw,
+		}, nil)
+	}
+	lst = append(lst, model.SecretInfo{Type: model.TextSecret, Name: "text", Version: 1})
+	// Not git secrets are never retrieved
+	lst = append(lst, model.SecretInfo{Type: model.CredsSecret, Name: "bank", Version: 1})
+
+	g.trMock.EXPECT().SecretGetList(_testToken).Return(lst, nil)
+}
+
+func (g *GitCredSuite) TestGet() {
+	secrets := [][4]string{
+		{"git:github", "work account\nurl=https://github.com", "john", "host-token"},
+		{"git:github-org", "url = https://github.com/org", "john", "org-token"},
+		{"git:gitlab", "url=https://gitlab.com", "john", "gitlab-token"},
+		{"git:other", "no url", "john", "other"},
+	}
+
+	for i, tt := range []struct {
+		cred        *Credential
+		expPassword string
+	}{
+		{cred: &Credential{Protocol: "https", Host: "github.com"}, expPassword: "host-token"},
+		{cred: &Credential{Protocol: "https", Host: "github.com", Path: "org/repo.git"}, expPassword: "org-token"},
+		{cred: &Credential{Protocol: "https", Host: "github.com", Path: "other/repo.git"}, expPassword: "host-token"},
+		{cred: &Credential{Protocol: "http", Host: "github.com"}},
+		{cred: &Credential{Protocol: "https", Host: "github.com", Username: "jane"}},
+		{cred: &Credential{Protocol: "https", Host: "bitbucket.org"}},
+	} {
+		tt := tt
+		g.Run(fmt.Sprintf("Run %d", i), func() {
+			g.expectSecrets(secrets...)
+
+			cred, err := g.helper.Get(tt.cred)
+			g.NoError(err)
+			if tt.expPassword == "" {
+				g.Nil(cred)
+				return
+			}
+			g.Equal("john", cred.Username)
+			g.Equal(tt.expPassword, cred.Password)
+		})
+	}
+}
+
+func (g *GitCredSuite) TestStore() {
+	cred := &Credential{Protocol: "https", Host: "github.com", Username: "john", Password: "new-token"}
+
+	g.Run("create", func() {
+		g.expectSecrets()
+
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload("john", "new-token"))
+		g.Require().NoError(err)
+		g.trMock.EXPECT().SecretCreate(_testToken, &model.Secret{
+			Type:       model.CredsSecret,
+			Name:       "git:https://john@github.com",
+			Meta:       "url=https://github.com",
+			PayloadRaw: payloadRaw,
+		}).Return(nil)
+
+		g.NoError(g.helper.Store(cred))
+	})
+
+	g.Run("update", func() {
+		g.expectSecrets([4]string{"git:github", "url=https://github.com", "john", "old-token"})
+
+		payloadRaw, err := gkMsgp.Serialize(model.NewCredsPayload("john", "new-token"))
+		g.Require().NoError(err)
+		g.trMock.EXPECT().SecretUpdate(_testToken, "git:github", &model.SecretUpdate{
+			Meta:          "url=https://github.com",
+			Version:       3,
+			PayloadRaw:    payloadRaw,
+			UpdatePayload: true,
+		}).Return(nil)
+
+		g.NoError(g.helper.Store(cred))
+	})
+
+	g.Run("unchanged", func() {
+		g.expectSecrets([4]string{"git:github", "url=https://github.com", "john", "new-token"})
+		g.NoError(g.helper.Store(cred))
+	})
+
+	g.Run("no password", func() {
+		g.ErrorIs(g.helper.Store(&Credential{Protocol: "https", Host: "github.com"}), ErrInvalidCredential)
+	})
+}
+
+func (g *GitCredSuite) TestErase() {
+	g.expectSecrets(
+		[4]string{"git:github", "url=https://github.com", "john", "token"},
+		[4]string{"git:github-old", "url=https://github.com", "john", "old-token"},
+	)
+	g.trMock.EXPECT().SecretDelete(_testToken, "git:github").Return(nil)
+
+	g.NoError(g.helper.Erase(&Credential{Protocol: "https", Host: "github.com", Username: "john", Password: "token"}))
+}
+
+func TestGitCredSuite(t *testing.T) {
+	suite.Run(t, new(GitCredSuite))
+}
@@ -0,0 +1,65 @@
+package passgen
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"strings"
+	"sync"
+)
+
+var ErrInvalidWordCount = errors.New("invalid passphrase word count")
+
+const (
+	MinWords = 3
+	MaxWords = 64
+
+	DefaultWords     = 6
+	DefaultSeparator = "-"
+)
+
+// EFF large wordlist, see https://www.eff.org/deeplinks/2016/07/new-wordlists-random-passphrases.
+//
+//go:embed eff_large_wordlist.txt
+var _effLargeWordlist string
+
+var (
+	_wordlist     []string //nolint:gochecknoglobals // parsed once from embedded file
+	_wordlistOnce sync.Once
+)
+
+// Passphrase generates diceware passphrase of given number of words from embedded EFF wordlist.
+//
+// Returns passphrase or error:
+//
+// - ErrInvalidWordCount - words out of [MinWords, MaxWords] range.
+func Passphrase(words int, separator string) (string, error) {
+	if words < MinWords || words > MaxWords {
+		return "", ErrInvalidWordCount
+	}
+
+	wordlist := loadWordlist()
+	phrase := make([]string, 0, words)
+	for i := 0; i < words; i++ {
+		n, err := randomInt(len(wordlist))
+		if err != nil {
+			return "", err
+		}
+		phrase = append(phrase, wordlist[n])
+	}
+
+	return strings.Join(phrase, separator), nil
+}
+
+func loadWordlist() []string {
+	_wordlistOnce.Do(func() {
+		scanner := bufio.NewScanner(strings.NewReader(_effLargeWordlist))
+		for scanner.Scan() {
+			// Line format: <dice rolls>\t<word>
+			if _, word, ok := strings.Cut(scanner.Text(), "\t"); ok {
+				_wordlist = append(_wordlist, word)
+			}
+		}
+	})
+	return _wordlist
+}
@@ -0,0 +1,158 @@
+// Package passgen contains password and passphrase generators and password strength estimation.
+package passgen
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrNoCharClasses = errors.New("no character classes selected")
+	ErrInvalidLength = errors.New("invalid password length")
+)
+
+const (
+	MinLength = 4
+	MaxLength = 1024
+
+	DefaultLength = 20
+
+	_charsLower     = "abcdefghijklmnopqrstuvwxyz"
+	_charsUpper     = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	_charsDigits    = "0123456789"
+	_charsSymbols   = "!#$%&()*+,-./:;<=>?@[]^_{|}~"
+	_charsAmbiguous = "Il1O0o|"
+)
+
+// Options represents random password generator options.
+type Options struct {
+	// Length - password length.
+	Length int
+	// Lower - use lowercase letters.
+	Lower bool
+	// Upper - use uppercase letters.
+	Upper bool
+	// Digits - use digits.
+	Digits bool
+	// Symbols - use punctuation symbols.
+	Symbols bool
+	// ExcludeAmbiguous - exclude similar looking characters, like l, 1, O and 0.
+	ExcludeAmbiguous bool
+}
+
+// DefaultOptions returns options with all character classes and without ambiguous characters.
+func DefaultOptions() Options {
+	return Options{
+		Length:           DefaultLength,
+		Lower:            true,
+		Upper:            true,
+		Digits:           true,
+		Symbols:          true,
+		ExcludeAmbiguous: true,
+	}
+}
+
+// Generate generates random password according to options.
+//
+// Password contains at least one character of every selected class.
+//
+// Returns password or error:
+//
+// - ErrInvalidLength - length out of [MinLength, MaxLength] range.
+//
+// - ErrNoCharClasses - no character classes selected.
+func Generate(opts Options) (string, error) {
+	if opts.Length < MinLength || opts.Length > MaxLength {
+		return "", ErrInvalidLength
+	}
+
+	classes := opts.charClasses()
+	if len(classes) == 0 {
+		return "", ErrNoCharClasses
+	}
+
+	all := strings.Join(classes, "")
+	password := make([]byte, 0, opts.Length)
+
+	// One character from every class
+	for _, class := range classes {
+		ch, err := randomChar(class)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, ch)
+	}
+
+	for len(password) < opts.Length {
+		ch, err := randomChar(all)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, ch)
+	}
+
+	if err := shuffle(password); err != nil {
+		return "", err
+	}
+
+	return string(password), nil
+}
+
+func (o Options) charClasses() []string {
+	var classes []string
+	for _, class := range []struct {
+		use   bool
+		chars string
+	}{
+		{use: o.Lower, chars: _charsLower},
+		{use: o.Upper, chars: _charsUpper},
+		{use: o.Digits, chars: _charsDigits},
+		{use: o.Symbols, chars: _charsSymbols},
+	} {
+		if !class.use {
+			continue
+		}
+
+		chars := class.chars
+		if o.ExcludeAmbiguous {
+			chars = strings.Map(func(r rune) rune {
+				if strings.ContainsRune(_charsAmbiguous, r) {
+					return -1
+				}
+				return r
+			}, chars)
+		}
+		classes = append(classes, chars)
+	}
+
+	return classes
+}
+
+func randomChar(chars string) (byte, error) {
+	i, err := randomInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[i], nil
+}
+
+func randomInt(max int) (int, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		return 0, err
+	}
+	return int(n.Int64()), nil
+}
+
+func shuffle(data []byte) error {
+	for i := len(data) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return err
+		}
+		data[i], data[j] = data[j], data[i]
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package passgen
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	for i, tt := range []struct {
+		opts    Options
+		allowed string
+		classes []string
+	}{
+		{
+			opts:    DefaultOptions(),
+			classes: []string{_charsLower, _charsUpper, _charsDigits, _charsSymbols},
+		},
+		{
+			opts:    Options{Length: 8, Digits: true},
+			allowed: _charsDigits,
+			classes: []string{_charsDigits},
+		},
+		{
+			opts:    Options{Length: 64, Lower: true, Upper: true, ExcludeAmbiguous: true},
+			allowed: "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ",
+			classes: []string{_charsLower, _charsUpper},
+		},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Run %d", i), func(t *testing.T) {
+			password, err := Generate(tt.opts)
+			require.NoError(t, err)
+			assert.Len(t, password, tt.opts.Length)
+
+			for _, class := range tt.classes {
+				assert.True(t, strings.ContainsAny(password, class), "class %s missing", class)
+			}
+
+			if tt.allowed != "" {
+				for _, ch := range password {
+					assert.True(t, strings.ContainsRune(tt.allowed, ch), "unexpected char %c", ch)
+				}
+			}
+
+			if tt.opts.ExcludeAmbiguous {
+				assert.False(t, strings.ContainsAny(password, _charsAmbiguous))
+			}
+		})
+	}
+}
+
+func TestGenerateUnique(t *testing.T) {
+	passwords := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		password, err := Generate(DefaultOptions())
+		require.NoError(t, err)
+		passwords[password] = true
+	}
+	assert.Len(t, passwords, 100)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	_, err := Generate(Options{Length: 10})
+	assert.ErrorIs(t, err, ErrNoCharClasses)
+
+	_, err = Generate(Options{Length: MinLength - 1, Lower: true})
+	assert.ErrorIs(t, err, ErrInvalidLength)
+
+	_, err = Generate(Options{Length: MaxLength + 1, Lower: true})
+	assert.ErrorIs(t, err, ErrInvalidLength)
+}
+
+func TestPassphrase(t *testing.T) {
+	assert.Len(t, loadWordlist(), 7776)
+
+	phrase, err := Passphrase(DefaultWords, DefaultSeparator)
+	require.NoError(t, err)
+
+	words := strings.Split(phrase, DefaultSeparator)
+	assert.Len(t, words, DefaultWords)
+	for _, word := range words {
+		assert.Contains(t, loadWordlist(), word)
+	}
+
+	_, err = Passphrase(MinWords-1, " ")
+	assert.ErrorIs(t, err, ErrInvalidWordCount)
+}
+
+func TestEstimateStrength(t *testing.T) {
+	assert.Equal(t, ScoreVeryWeak, EstimateStrength("").Score)
+	assert.Equal(t, ScoreVeryWeak, EstimateStrength("password").Score)
+	assert.Less(t, EstimateStrength("johnsmith1", "johnsmith").Score, ScoreStrong)
+
+	password, err := Generate(DefaultOptions())
+	require.NoError(t, err)
+	assert.Equal(t, ScoreVeryStrong, EstimateStrength(password).Score)
+
+	phrase, err := Passphrase(DefaultWords, DefaultSeparator)
+	require.NoError(t, err)
+	assert.Equal(t, ScoreVeryStrong, EstimateStrength(phrase).Score)
+}
+
+func TestScoreString(t *testing.T) {
+	assert.Equal(t, "Very weak", ScoreVeryWeak.String())
+	assert.Equal(t, "Weak", ScoreWeak.String())
+	assert.Equal(t, "Fair", ScoreFair.String())
+	assert.Equal(t, "Strong", ScoreStrong.String())
+	assert.Equal(t, "Very strong", ScoreVeryStrong.String())
+	assert.Equal(t, "Unknown", Score(100).String())
+}
@@ -0,0 +1,64 @@
+package passgen
+
+import (
+	"github.com/nbutton23/zxcvbn-go"
+)
+
+// Score is a password strength score from ScoreVeryWeak to ScoreVeryStrong.
+type Score int
+
+const (
+	ScoreVeryWeak Score = iota
+	ScoreWeak
+	ScoreFair
+	ScoreStrong
+	ScoreVeryStrong
+)
+
+func (s Score) String() string {
+	switch s {
+	case ScoreVeryWeak:
+		return "Very weak"
+	case ScoreWeak:
+		return "Weak"
+	case ScoreFair:
+		return "Fair"
+	case ScoreStrong:
+		return "Strong"
+	case ScoreVeryStrong:
+		return "Very strong"
+	default:
+		return "Unknown"
+	}
+}
+
+// Strength represents password strength estimation.
+type Strength struct {
+	// Score - strength score.
+	Score Score
+	// Entropy - estimated entropy in bits.
+	Entropy float64
+	// CrackTime - human readable estimated time to crack password.
+	CrackTime string
+}
+
+func (s Strength) String() string {
+	return s.Score.String() + " (crack time: " + s.CrackTime + ")"
+}
+
+// EstimateStrength estimates password strength with zxcvbn algorithm:
+// dictionary words, keyboard patterns, sequences, repeats and dates are taken into account.
+//
+// userInputs - user specific words (login, secret name), that make password weaker.
+func EstimateStrength(password string, userInputs ...string) Strength {
+	if password == "" {
+		return Strength{Score: ScoreVeryWeak, CrackTime: "instant"}
+	}
+
+	res := zxcvbn.PasswordStrength(password, userInputs)
+	return Strength{
+		Score:     Score(res.Score),
+		Entropy:   res.Entropy,
+		CrackTime: res.CrackTimeDisplay,
+	}
+}
@@ -0,0 +1,196 @@
+// Package secretref contains resolving of references to secret fields.
+//
+// Reference format is "secret:<name>#<field>". If field is omitted,
+// default field of secret type is used. Secret name can contain "#":
+// text after last "#" is a field only if it is a known field name.
+package secretref
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+var (
+	ErrInvalidReference = errors.New("invalid secret reference")
+	ErrUnknownField     = errors.New("unknown secret field")
+)
+
+const _refPrefix = "secret:"
+
+// Secret fields.
+const (
+	FieldMeta      = "meta"
+	FieldLogin     = "login"
+	FieldPassword  = "password"
+	FieldText      = "text"
+	FieldData      = "data"
+	FieldNumber    = "number"
+	FieldHolder    = "holder"
+	FieldValidThru = "valid_thru"
+	FieldCVV       = "cvv"
+)
+
+// Ref represents reference to secret field.
+type Ref struct {
+	Secret string
+	Field  string
+}
+
+func (r Ref) String() string {
+	if r.Field == "" {
+		return _refPrefix + r.Secret
+	}
+	return _refPrefix + r.Secret + "#" + r.Field
+}
+
+// Parse parses reference "secret:<name>[#<field>]".
+// If text after last "#" is not a known field name, whole reference is a secret name.
+//
+// Returns ErrInvalidReference for malformed reference.
+func Parse(s string) (Ref, error) {
+	if !strings.HasPrefix(s, _refPrefix) {
+		return Ref{}, fmt.Errorf("%w: %s", ErrInvalidReference, s)
+	}
+
+	// Secret name can contain "#", so field is taken after last one
+	body := strings.TrimPrefix(s, _refPrefix)
+	ref := Ref{Secret: body}
+	if i := strings.LastIndex(body, "#"); i != -1 && isField(body[i+1:]) {
+		ref = Ref{Secret: body[:i], Field: body[i+1:]}
+	}
+
+	if ref.Secret == "" {
+		return Ref{}, fmt.Errorf("%w: %s", ErrInvalidReference, s)
+	}
+
+	return ref, nil
+}
+
+// Resolver resolves references with transport. Secrets are requested from server once.
+type Resolver struct {
+	tr      transport.Transport
+	token   string
+	secrets map[string]*model.Secret
+}
+
+// NewResolver creates new Resolver object.
+func NewResolver(tr transport.Transport, token string) *Resolver {
+	return &Resolver{tr: tr, token: token, secrets: make(map[string]*model.Secret)}
+}
+
+// Resolve returns value of secret field. If secret is not found or has no such field,
+// field is tried as a part of secret name with "#".
+//
+// Returns value or error:
+//
+// - ErrUnknownField - secret type has no such field.
+//
+// - transport error.
+func (r *Resolver) Resolve(ref Ref) (string, error) {
+	value, err := r.resolve(ref)
+	if err == nil || ref.Field == "" {
+		return value, err
+	}
+	if !errors.Is(err, transport.ErrSecretNotFound) && !errors.Is(err, ErrUnknownField) {
+		return "", err
+	}
+
+	if value, errName := r.resolve(Ref{Secret: ref.Secret + "#" + ref.Field}); errName == nil {
+		return value, nil
+	}
+
+	return "", err
+}
+
+func (r *Resolver) resolve(ref Ref) (string, error) {
+	secret, ok := r.secrets[ref.Secret]
+	if !ok {
+		var err error
+		if secret, err = r.tr.SecretGet(r.token, ref.Secret); err != nil {
+			return "", fmt.Errorf("secret [%s]: %w", ref.Secret, err)
+		}
+		r.secrets[ref.Secret] = secret
+	}
+
+	payload, err := secret.GetPayload()
+	if err != nil {
+		return "", fmt.Errorf("secret [%s]: %w", ref.Secret, err)
+	}
+
+	fields := Fields(secret.Meta, payload)
+
+	field := ref.Field
+	if field == "" {
+		field = DefaultField(secret.Type)
+	}
+
+	value, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("%w: %s (available: %s)", ErrUnknownField, ref, strings.Join(fieldNames(fields), ", "))
+	}
+
+	return value, nil
+}
+
+// Fields returns secret fields by name.
+func Fields(meta string, payload model.Payload) map[string]string {
+	fields := map[string]string{FieldMeta: meta}
+
+	switch p := payload.(type) {
+	case *model.CredsPayload:
+		fields[FieldLogin] = p.Login
+		fields[FieldPassword] = p.Password
+	case *model.TextPayload:
+		fields[FieldText] = p.Data
+	case *model.BinaryPayload:
+		fields[FieldData] = string(p.Data)
+	case *model.CardPayload:
+		fields[FieldNumber] = p.CardNum
+		fields[FieldHolder] = p.CardHolder
+		fields[FieldValidThru] = p.ValidThru
+		fields[FieldCVV] = p.CVV
+	}
+
+	return fields
+}
+
+// DefaultField returns field, used when reference has no field.
+func DefaultField(secretType model.SecretType) string {
+	switch secretType {
+	case model.CredsSecret:
+		return FieldPassword
+	case model.TextSecret:
+		return FieldText
+	case model.BinarySecret:
+		return FieldData
+	case model.CardSecret:
+		return FieldNumber
+	case model.UnknownSecret:
+		return ""
+	default:
+		return ""
+	}
+}
+
+func isField(name string) bool {
+	switch name {
+	case FieldMeta, FieldLogin, FieldPassword, FieldText, FieldData, FieldNumber, FieldHolder, FieldValidThru, FieldCVV:
+		return true
+	default:
+		return false
+	}
+}
+
+func fieldNames(fields map[string]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,101 @@
+package secretref
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/transport/mocks"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+)
+
+func TestParse(t *testing.T) {
+	for i, tt := range []struct {
+		s      string
+		expRef Ref
+		expErr error
+	}{
+		{s: "secret:prod-db#password", expRef: Ref{Secret: "prod-db", Field: "password"}},
+		{s: "secret:prod-db", expRef: Ref{Secret: "prod-db"}},
+		{s: "secret:team#1#login", expRef: Ref{Secret: "team#1", Field: "login"}},
+		{s: "secret:team#1", expRef: Ref{Secret: "team#1"}},
+		{s: "secret:team#", expRef: Ref{Secret: "team#"}},
+		{s: "secret:#team", expRef: Ref{Secret: "#team"}},
+		{s: "secret:#password", expErr: ErrInvalidReference},
+		{s: "secret:", expErr: ErrInvalidReference},
+		{s: "prod-db#password", expErr: ErrInvalidReference},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Run %d", i), func(t *testing.T) {
+			ref, err := Parse(tt.s)
+			if tt.expErr != nil {
+				assert.ErrorIs(t, err, tt.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expRef, ref)
+			assert.Equal(t, tt.s, ref.String())
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	gmckCtrl := gomock.NewController(t)
+	defer gmckCtrl.Finish()
+	trMock := mocks.NewMockTransport(gmckCtrl)
+
+	trMock.EXPECT().SecretGet("token", "db").
+		Return(newSecret(t, model.CredsSecret, "db", "host=localhost", model.NewCredsPayload("admin", "pass")), nil)
+	trMock.EXPECT().SecretGet("token", "card").
+		Return(newSecret(t, model.CardSecret, "card", "", model.NewCardPayload("2202", "JOHN", "11/26", "777")), nil)
+	trMock.EXPECT().SecretGet("token", "missing").Return(nil, transport.ErrSecretNotFound)
+	trMock.EXPECT().SecretGet("token", "db#cvv").Return(nil, transport.ErrSecretNotFound)
+	trMock.EXPECT().SecretGet("token", "team#1").
+		Return(newSecret(t, model.TextSecret, "team#1", "", model.NewTextPayload("team text")), nil)
+	// Name with field name after "#"
+	trMock.EXPECT().SecretGet("token", "api").Return(nil, transport.ErrSecretNotFound)
+	trMock.EXPECT().SecretGet("token", "api#password").
+		Return(newSecret(t, model.CredsSecret, "api#password", "", model.NewCredsPayload("bot", "api-token")), nil)
+
+	resolver := NewResolver(trMock, "token")
+
+	for i, tt := range []struct {
+		ref      Ref
+		expValue string
+		expErr   error
+	}{
+		{ref: Ref{Secret: "db", Field: FieldLogin}, expValue: "admin"},
+		{ref: Ref{Secret: "db", Field: FieldPassword}, expValue: "pass"},
+		{ref: Ref{Secret: "db"}, expValue: "pass"},
+		{ref: Ref{Secret: "db", Field: FieldMeta}, expValue: "host=localhost"},
+		{ref: Ref{Secret: "db", Field: FieldCVV}, expErr: ErrUnknownField},
+		{ref: Ref{Secret: "card", Field: FieldCVV}, expValue: "777"},
+		{ref: Ref{Secret: "card", Field: FieldValidThru}, expValue: "11/26"},
+		{ref: Ref{Secret: "card"}, expValue: "2202"},
+		{ref: Ref{Secret: "missing"}, expErr: transport.ErrSecretNotFound},
+		{ref: Ref{Secret: "team#1"}, expValue: "team text"},
+		{ref: Ref{Secret: "api", Field: FieldPassword}, expValue: "api-token"},
+	} {
+		tt := tt
+		t.Run(fmt.Sprintf("Run %d", i), func(t *testing.T) {
+			value, err := resolver.Resolve(tt.ref)
+			if tt.expErr != nil {
+				assert.ErrorIs(t, err, tt.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expValue, value)
+		})
+	}
+}
+
+func newSecret(t *testing.T, secretType model.SecretType, name, meta string, payload msgp.Encodable) *model.Secret {
+	payloadRaw, err := gkMsgp.Serialize(payload)
+	require.NoError(t, err)
+	return &model.Secret{Type: secretType, Name: name, Meta: meta, Version: 1, PayloadRaw: payloadRaw}
+}
@@ -1,6 +1,11 @@
 package client
 
-import "github.com/devldavydov/gophkeeper/internal/common/nettools"
+import (
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/common/nettools"
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
+)
 
 // Settings rerpesents client application settings.
 type Settings struct {
@@ -9,9 +14,98 @@ type Settings struct {
 
 	// TLSCACertPath - path to TLS Certificate Authority file.
 	TLSCACertPath string
+
+	// TLSCertPath - path to TLS client certificate file. If empty, client certificate is not used.
+	TLSCertPath string
+
+	// TLSKeyPath - path to TLS client certificate key file.
+	TLSKeyPath string
+
+	// UserLogin - user login for command mode.
+	UserLogin string
+
+	// UserPassword - user password for command mode. If empty, asked in terminal.
+	UserPassword string
+
+	// BreachSource - Have I Been Pwned hash dump file or range API mirror URL.
+	// If empty, breach check is disabled.
+	BreachSource string
+
+	// ClipboardClearTimeout - timeout to clear copied secret from clipboard. Zero - never clear.
+	ClipboardClearTimeout time.Duration
+
+	// IdleLockTimeout - UI is locked after this period of inactivity. Zero - never lock.
+	IdleLockTimeout time.Duration
+
+	// Tracing - tracing exporter settings. If nil, tracing is disabled.
+	Tracing *tracing.Settings
+
+	// Profile - name of active server profile, server connection settings above are taken from it.
+	// If empty, settings are not from named profile.
+	Profile string
+
+	// Profiles - other named server profiles, available for switching in UI.
+	Profiles []*Profile
+}
+
+// Profile represents named server connection settings.
+type Profile struct {
+	// Name - profile name.
+	Name string
+
+	// ServerAddress - address of server to connect.
+	ServerAddress *nettools.Address
+
+	// TLSCACertPath - path to TLS Certificate Authority file.
+	TLSCACertPath string
+
+	// TLSCertPath - path to TLS client certificate file. If empty, client certificate is not used.
+	TLSCertPath string
+
+	// TLSKeyPath - path to TLS client certificate key file.
+	TLSKeyPath string
+
+	// UserLogin - default user login.
+	UserLogin string
 }
 
 // NewSettings creates new Settings object.
-func NewSettings(serverAddress *nettools.Address, tlsCACertPath string) *Settings {
-	return &Settings{ServerAddress: serverAddress, TLSCACertPath: tlsCACertPath}
+func NewSettings(
+	serverAddress *nettools.Address,
+	tlsCACertPath, tlsCertPath, tlsKeyPath, userLogin, userPassword, breachSource string,
+	clipboardClearTimeout, idleLockTimeout time.Duration,
+	tracingSettings *tracing.Settings,
+	profile string,
+	profiles []*Profile,
+) *Settings {
+	return &Settings{
+		ServerAddress:         serverAddress,
+		TLSCACertPath:         tlsCACertPath,
+		TLSCertPath:           tlsCertPath,
+		TLSKeyPath:            tlsKeyPath,
+		UserLogin:             userLogin,
+		UserPassword:          userPassword,
+		BreachSource:          breachSource,
+		ClipboardClearTimeout: clipboardClearTimeout,
+		IdleLockTimeout:       idleLockTimeout,
+		Tracing:               tracingSettings,
+		Profile:               profile,
+		Profiles:              profiles,
+	}
+}
+
+// NewProfile creates new Profile object.
+func NewProfile(
+	name string,
+	serverAddress *nettools.Address,
+	tlsCACertPath, tlsCertPath, tlsKeyPath, userLogin string,
+) *Profile {
+	return &Profile{
+		Name:          name,
+		ServerAddress: serverAddress,
+		TLSCACertPath: tlsCACertPath,
+		TLSCertPath:   tlsCertPath,
+		TLSKeyPath:    tlsKeyPath,
+		UserLogin:     userLogin,
+	}
 }
@@ -0,0 +1,111 @@
+// Package share contains one-time sharing of secrets with recipients without account.
+//
+// Secret payload is encrypted with random key, which is put only to fragment of share link
+// "gkshare:<id>#<key>" and is never sent to server.
+package share
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/common/cipher"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+var (
+	ErrInvalidLink = errors.New("invalid share link, expected gkshare:<id>#<key>")
+	ErrWrongKey    = errors.New("share key does not match payload")
+)
+
+const _linkScheme = "gkshare"
+
+// Create encrypts secret payload with random key and creates one-time share on server.
+//
+// Zero ttl and maxViews mean server defaults.
+//
+// Returns share link with key and share expiration time or error.
+func Create(
+	tr transport.Transport,
+	token string,
+	secret *model.Secret,
+	ttl time.Duration,
+	maxViews int,
+) (string, time.Time, error) {
+	key := make([]byte, cipher.AESKeyLength)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", time.Time{}, err
+	}
+
+	payloadEncrypted, err := cipher.AESGCMEncrypt(secret.PayloadRaw, key)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	id, expiresAt, err := tr.SecretCreateOneTimeShare(token, &model.Share{
+		SecretName:       secret.Name,
+		Type:             secret.Type,
+		PayloadEncrypted: payloadEncrypted,
+		ViewsLeft:        int32(maxViews),
+	}, ttl)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return FormatLink(id, key), expiresAt, nil
+}
+
+// Redeem retrieves one-time share by link and decrypts its payload with key from link.
+//
+// Returns secret with type and payload and number of remaining views or error:
+//
+// - ErrInvalidLink - link malformed.
+//
+// - ErrWrongKey - payload can't be decrypted with key from link.
+//
+// - transport error.
+func Redeem(tr transport.Transport, link string) (*model.Secret, int32, error) {
+	id, key, err := ParseLink(link)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	share, err := tr.SecretRedeemOneTimeShare(id)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	payloadRaw, err := cipher.AESGCMDecrypt(share.PayloadEncrypted, key)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrWrongKey, err)
+	}
+
+	return &model.Secret{Type: share.Type, PayloadRaw: payloadRaw}, share.ViewsLeft, nil
+}
+
+// FormatLink returns share link with id and key in fragment.
+func FormatLink(id string, key []byte) string {
+	return (&url.URL{Scheme: _linkScheme, Opaque: id, Fragment: base64.RawURLEncoding.EncodeToString(key)}).String()
+}
+
+// ParseLink parses share link.
+//
+// Returns share id and key or ErrInvalidLink.
+func ParseLink(link string) (string, []byte, error) {
+	u, err := url.Parse(link)
+	if err != nil || u.Scheme != _linkScheme || u.Opaque == "" {
+		return "", nil, ErrInvalidLink
+	}
+
+	key, err := base64.RawURLEncoding.DecodeString(u.Fragment)
+	if err != nil || len(key) != cipher.AESKeyLength {
+		return "", nil, ErrInvalidLink
+	}
+
+	return u.Opaque, key, nil
+}
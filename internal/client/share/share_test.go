@@ -0,0 +1,91 @@
+package share
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/transport/mocks"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRedeem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	trMock := mocks.NewMockTransport(ctrl)
+
+	secret := &model.Secret{Type: model.TextSecret, Name: "foo", PayloadRaw: []byte("payload")}
+	expiresAt := time.Unix(1700000000, 0)
+
+	var stored *model.Share
+	trMock.EXPECT().
+		SecretCreateOneTimeShare("token", gomock.Any(), time.Hour).
+		DoAndReturn(func(_ string, share *model.Share, _ time.Duration) (string, time.Time, error) {
+			stored = share
+			return "id", expiresAt, nil
+		})
+
+	link, linkExpiresAt, err := Create(trMock, "token", secret, time.Hour, 2)
+	require.NoError(t, err)
+	assert.Equal(t, expiresAt, linkExpiresAt)
+	assert.True(t, strings.HasPrefix(link, "gkshare:id#"))
+
+	// Server never sees key or plain payload
+	assert.Equal(t, "foo", stored.SecretName)
+	assert.Equal(t, int32(2), stored.ViewsLeft)
+	assert.NotContains(t, string(stored.PayloadEncrypted), "payload")
+	_, key, err := ParseLink(link)
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored.PayloadEncrypted), string(key))
+
+	trMock.EXPECT().SecretRedeemOneTimeShare("id").Return(&model.Share{
+		ID: "id", Type: model.TextSecret, PayloadEncrypted: stored.PayloadEncrypted, ViewsLeft: 1,
+	}, nil)
+
+	redeemed, viewsLeft, err := Redeem(trMock, link)
+	require.NoError(t, err)
+	assert.Equal(t, &model.Secret{Type: model.TextSecret, PayloadRaw: []byte("payload")}, redeemed)
+	assert.Equal(t, int32(1), viewsLeft)
+
+	t.Run("wrong key", func(t *testing.T) {
+		trMock.EXPECT().SecretRedeemOneTimeShare("id").Return(&model.Share{
+			ID: "id", Type: model.TextSecret, PayloadEncrypted: stored.PayloadEncrypted,
+		}, nil)
+
+		_, _, err = Redeem(trMock, FormatLink("id", make([]byte, 32)))
+		assert.ErrorIs(t, err, ErrWrongKey)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		trMock.EXPECT().SecretRedeemOneTimeShare("id").Return(nil, transport.ErrShareNotFound)
+
+		_, _, err = Redeem(trMock, link)
+		assert.ErrorIs(t, err, transport.ErrShareNotFound)
+	})
+}
+
+func TestParseLink(t *testing.T) {
+	key := make([]byte, 32)
+	key[0] = 1
+
+	id, parsedKey, err := ParseLink(FormatLink("abc", key))
+	require.NoError(t, err)
+	assert.Equal(t, "abc", id)
+	assert.Equal(t, key, parsedKey)
+
+	for _, link := range []string{
+		"",
+		"abc",
+		"https://abc#AQ",
+		"gkshare:#" + strings.Repeat("A", 43),
+		"gkshare:abc",
+		"gkshare:abc#AQ",
+		"gkshare:abc#!!!",
+	} {
+		_, _, err = ParseLink(link)
+		assert.ErrorIs(t, err, ErrInvalidLink, link)
+	}
+}
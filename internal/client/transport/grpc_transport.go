@@ -2,6 +2,7 @@ package transport
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/devldavydov/gophkeeper/internal/common/model"
@@ -11,36 +12,52 @@ import (
 	gkTLS "github.com/devldavydov/gophkeeper/internal/common/tls"
 	"github.com/devldavydov/gophkeeper/internal/common/token"
 	pb "github.com/devldavydov/gophkeeper/internal/grpc"
+	"github.com/devldavydov/gophkeeper/internal/grpc/interceptor"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-const _serverRequestTimeout = 15 * time.Second
+const (
+	_serverRequestTimeout = 15 * time.Second
+	_userAgent            = "gophkeeper-client"
+)
 
 // GrpcTransport is a gRPC implementation of Transport interface.
 type GrpcTransport struct {
 	gClt   pb.GophKeeperServiceClient
+	conn   *grpc.ClientConn
 	logger *logrus.Logger
 }
 
 var _ Transport = (*GrpcTransport)(nil)
 
 // NewGrpcTransport creates new GrpcTransport object.
+//
+// If tlsCertPath is not empty, client certificate is sent to server.
 func NewGrpcTransport(
 	serverAddress *nettools.Address,
-	tlsCACertPath string,
+	tlsCACertPath, tlsCertPath, tlsKeyPath string,
 	logger *logrus.Logger,
 ) (*GrpcTransport, error) {
-	tlsCredentials, err := gkTLS.LoadCACert(tlsCACertPath, "")
+	tlsCredentials, err := gkTLS.LoadClientCert(tlsCACertPath, "", tlsCertPath, tlsKeyPath)
 	if err != nil {
 		return nil, err
 	}
 
+	// Requests are traced with global tracer provider, no-op if tracing not configured
+	tracingInterceptor := interceptor.NewTracingClientInterceptor()
+	// Failed requests are logged with request ID, sent to server
+	requestIDInterceptor := interceptor.NewRequestIDClientInterceptor(logger)
+
 	conn, err := grpc.Dial(
 		serverAddress.String(),
 		grpc.WithTransportCredentials(tlsCredentials),
+		grpc.WithUserAgent(_userAgent),
+		grpc.WithChainUnaryInterceptor(tracingInterceptor.Handle, requestIDInterceptor.Handle),
+		grpc.WithChainStreamInterceptor(tracingInterceptor.HandleStream, requestIDInterceptor.HandleStream),
 		grpc.WithDefaultCallOptions(
 			grpc.MaxCallRecvMsgSize(model.MaxPayloadSizeBytes+1024),
 			grpc.MaxCallSendMsgSize(model.MaxPayloadSizeBytes+1024),
@@ -50,13 +67,24 @@ func NewGrpcTransport(
 		return nil, err
 	}
 
-	return newGrpcTransport(pb.NewGophKeeperServiceClient(conn), logger), nil
+	gt := newGrpcTransport(pb.NewGophKeeperServiceClient(conn), logger)
+	gt.conn = conn
+	return gt, nil
 }
 
 func newGrpcTransport(clt pb.GophKeeperServiceClient, logger *logrus.Logger) *GrpcTransport {
 	return &GrpcTransport{gClt: clt, logger: logger}
 }
 
+// Close closes connection with server.
+func (gt *GrpcTransport) Close() error {
+	if gt.conn == nil {
+		return nil
+	}
+
+	return gt.conn.Close()
+}
+
 // UserCreate is a gRPC implemention of user creation method. Accepts user login and password.
 //
 // Returns user token or error:
@@ -72,7 +100,6 @@ func (gt *GrpcTransport) UserCreate(userLogin, userPassword string) (string, err
 
 	pbToken, err := gt.gClt.UserCreate(ctx, &pb.User{Login: userLogin, Password: userPassword})
 	if err != nil {
-		gt.logger.Errorf("gRPC user create request error: %v", err)
 		status, ok := status.FromError(err)
 		if !ok {
 			return "", ErrInternalServerError
@@ -106,7 +133,6 @@ func (gt *GrpcTransport) UserLogin(userLogin, userPassword string) (string, erro
 
 	pbToken, err := gt.gClt.UserLogin(ctx, &pb.User{Login: userLogin, Password: userPassword})
 	if err != nil {
-		gt.logger.Errorf("gRPC user login request error: %v", err)
 		status, ok := status.FromError(err)
 		if !ok {
 			return "", ErrInternalServerError
@@ -125,6 +151,95 @@ func (gt *GrpcTransport) UserLogin(userLogin, userPassword string) (string, erro
 	return pbToken.Token, nil
 }
 
+// UserGetAuditLog is a gRPC implemention of user's audit log method.
+//
+// Accepts authenticated user token and time range, zero from or to time is not limited.
+//
+// Returns audit events, newest first, or error:
+//
+// - ErrUserPermissionDenied - provided token not valid and permission denied.
+//
+// - ErrAuditLogInvalidTimeRange - provided time range not valid.
+//
+// - ErrInternalServerError - unexpected server error.
+func (gt *GrpcTransport) UserGetAuditLog(token string, from, to time.Time) ([]model.AuditEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), _serverRequestTimeout)
+	defer cancel()
+
+	req := &pb.UserGetAuditLogRequest{}
+	if !from.IsZero() {
+		req.From = from.Unix()
+	}
+	if !to.IsZero() {
+		req.To = to.Unix()
+	}
+
+	resp, err := gt.gClt.UserGetAuditLog(contextWithToken(ctx, token), req)
+	if err != nil {
+		status, ok := status.FromError(err)
+		if !ok {
+			return nil, ErrInternalServerError
+		}
+
+		switch status.Code() { //nolint:exhaustive // OK
+		case codes.PermissionDenied:
+			return nil, ErrUserPermissionDenied
+		case codes.InvalidArgument:
+			return nil, ErrAuditLogInvalidTimeRange
+		default:
+			return nil, ErrInternalServerError
+		}
+	}
+
+	events := make([]model.AuditEvent, 0, len(resp.Items))
+	for _, pbEvent := range resp.Items {
+		events = append(events, model.AuditEvent{
+			Type:        model.AuditEventType(pbEvent.EventType),
+			SecretName:  pbEvent.SecretName,
+			PeerAddress: pbEvent.PeerAddress,
+			UserAgent:   pbEvent.UserAgent,
+			Outcome:     pbEvent.Outcome,
+			CreatedAt:   time.Unix(pbEvent.CreatedAt, 0),
+		})
+	}
+
+	return events, nil
+}
+
+// UserGetUsage is a gRPC implemention of user's get usage method. Accepts authenticated user token.
+//
+// Returns user's storage usage and quota or error:
+//
+// - ErrUserPermissionDenied - provided token not valid and permission denied.
+//
+// - ErrInternalServerError - unexpected server error.
+func (gt *GrpcTransport) UserGetUsage(token string) (*model.Usage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), _serverRequestTimeout)
+	defer cancel()
+
+	resp, err := gt.gClt.UserGetUsage(contextWithToken(ctx, token), &pb.Empty{})
+	if err != nil {
+		status, ok := status.FromError(err)
+		if !ok {
+			return nil, ErrInternalServerError
+		}
+
+		switch status.Code() { //nolint:exhaustive // OK
+		case codes.PermissionDenied:
+			return nil, ErrUserPermissionDenied
+		default:
+			return nil, ErrInternalServerError
+		}
+	}
+
+	return &model.Usage{
+		Bytes:      resp.Bytes,
+		Secrets:    resp.Secrets,
+		MaxBytes:   resp.MaxBytes,
+		MaxSecrets: resp.MaxSecrets,
+	}, nil
+}
+
 // SecretGetList is a gRPC implemention of user's secret list method. Accepts authenticated user token.
 //
 // Returns list of secrets or error:
@@ -133,12 +248,30 @@ func (gt *GrpcTransport) UserLogin(userLogin, userPassword string) (string, erro
 //
 // - ErrInternalServerError - unexpected server error.
 func (gt *GrpcTransport) SecretGetList(token string) ([]model.SecretInfo, error) {
+	return gt.secretGetList(token, &pb.SecretGetListRequest{})
+}
+
+// SecretGetExpiring is a gRPC implemention of user's expiring secret list method.
+//
+// Accepts authenticated user token and number of days.
+//
+// Returns list of secrets expired or due to rotation within given days or error:
+//
+// - ErrUserPermissionDenied - provided token not valid and permission denied.
+//
+// - ErrSecretInvalid - provided days not valid.
+//
+// - ErrInternalServerError - unexpected server error.
+func (gt *GrpcTransport) SecretGetExpiring(token string, days int) ([]model.SecretInfo, error) {
+	return gt.secretGetList(token, &pb.SecretGetListRequest{ExpiringWithinDays: int32(days)})
+}
+
+func (gt *GrpcTransport) secretGetList(token string, req *pb.SecretGetListRequest) ([]model.SecretInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), _serverRequestTimeout)
 	defer cancel()
 
-	lstSrvSecrets, err := gt.gClt.SecretGetList(contextWithToken(ctx, token), &pb.Empty{})
+	lstSrvSecrets, err := gt.gClt.SecretGetList(contextWithToken(ctx, token), req)
 	if err != nil {
-		gt.logger.Errorf("gRPC secret get list request error: %v", err)
 		status, ok := status.FromError(err)
 		if !ok {
 			return nil, ErrInternalServerError
@@ -149,6 +282,8 @@ func (gt *GrpcTransport) SecretGetList(token string) ([]model.SecretInfo, error)
 			return make([]model.SecretInfo, 0), nil
 		case codes.PermissionDenied:
 			return nil, ErrUserPermissionDenied
+		case codes.InvalidArgument:
+			return nil, ErrSecretInvalid
 		default:
 			return nil, ErrInternalServerError
 		}
@@ -157,9 +292,13 @@ func (gt *GrpcTransport) SecretGetList(token string) ([]model.SecretInfo, error)
 	lstSecretInfo := make([]model.SecretInfo, 0, len(lstSrvSecrets.Items))
 	for _, srvSecret := range lstSrvSecrets.Items {
 		secretInfo := model.SecretInfo{
-			Name:    srvSecret.Name,
-			Version: srvSecret.Version,
-			Type:    model.SecretType(srvSecret.Type),
+			Name:             srvSecret.Name,
+			Version:          srvSecret.Version,
+			Type:             model.SecretType(srvSecret.Type),
+			UpdatedAt:        time.Unix(srvSecret.UpdatedAt, 0),
+			PayloadUpdatedAt: fromUnix(srvSecret.PayloadUpdatedAt),
+			ExpiresAt:        fromUnix(srvSecret.ExpiresAt),
+			RotateEvery:      time.Duration(srvSecret.RotateEvery) * time.Second,
 		}
 		lstSecretInfo = append(lstSecretInfo, secretInfo)
 	}
@@ -182,7 +321,6 @@ func (gt *GrpcTransport) SecretGet(token, name string) (*model.Secret, error) {
 
 	pbSecret, err := gt.gClt.SecretGet(contextWithToken(ctx, token), &pb.SecretGetRequest{Name: name})
 	if err != nil {
-		gt.logger.Errorf("gRPC secret get request error: %v", err)
 		status, ok := status.FromError(err)
 		if !ok {
 			return nil, ErrInternalServerError
@@ -199,11 +337,13 @@ func (gt *GrpcTransport) SecretGet(token, name string) (*model.Secret, error) {
 	}
 
 	return &model.Secret{
-		Type:       model.SecretType(pbSecret.Type),
-		Name:       pbSecret.Name,
-		Meta:       pbSecret.Meta,
-		Version:    pbSecret.Version,
-		PayloadRaw: pbSecret.PayloadRaw,
+		Type:        model.SecretType(pbSecret.Type),
+		Name:        pbSecret.Name,
+		Meta:        pbSecret.Meta,
+		Version:     pbSecret.Version,
+		PayloadRaw:  pbSecret.PayloadRaw,
+		ExpiresAt:   fromUnix(pbSecret.ExpiresAt),
+		RotateEvery: time.Duration(pbSecret.RotateEvery) * time.Second,
 	}, nil
 }
 
@@ -217,6 +357,8 @@ func (gt *GrpcTransport) SecretGet(token, name string) (*model.Secret, error) {
 //
 // - ErrSecretPayloadSizeExceeded - provided file in binary secret too big.
 //
+// - ErrQuotaExceeded - user's quota exceeded, wrapped with violation description.
+//
 // - ErrSecretInvalid - provided secret not valid.
 //
 // - ErrInternalServerError - unexpected server error.
@@ -226,17 +368,18 @@ func (gt *GrpcTransport) SecretCreate(token string, secret *model.Secret) error
 
 	secretReq := &pb.SecretCreateRequest{
 		Secret: &pb.Secret{
-			Name:       secret.Name,
-			Meta:       secret.Meta,
-			Type:       pb.SecretType(secret.Type),
-			PayloadRaw: secret.PayloadRaw,
-			Version:    1,
+			Name:        secret.Name,
+			Meta:        secret.Meta,
+			Type:        pb.SecretType(secret.Type),
+			PayloadRaw:  secret.PayloadRaw,
+			Version:     1,
+			ExpiresAt:   toUnix(secret.ExpiresAt),
+			RotateEvery: int64(secret.RotateEvery / time.Second),
 		},
 	}
 
 	_, err := gt.gClt.SecretCreate(contextWithToken(ctx, token), secretReq)
 	if err != nil {
-		gt.logger.Errorf("gRPC secret create request error: %v", err)
 		status, ok := status.FromError(err)
 		if !ok {
 			return ErrInternalServerError
@@ -246,7 +389,7 @@ func (gt *GrpcTransport) SecretCreate(token string, secret *model.Secret) error
 		case codes.AlreadyExists:
 			return ErrSecretAlreadyExists
 		case codes.ResourceExhausted:
-			return ErrSecretPayloadSizeExceeded
+			return resourceExhaustedError(status)
 		case codes.PermissionDenied:
 			return ErrUserPermissionDenied
 		case codes.InvalidArgument:
@@ -271,6 +414,8 @@ func (gt *GrpcTransport) SecretCreate(token string, secret *model.Secret) error
 //
 // - ErrSecretPayloadSizeExceeded - provided file in binary secret too big.
 //
+// - ErrQuotaExceeded - user's quota exceeded, wrapped with violation description.
+//
 // - ErrSecretInvalid - provided secret not valid.
 //
 // - ErrSecretOutdated - secret outdated (was changed in another session).
@@ -286,11 +431,12 @@ func (gt *GrpcTransport) SecretUpdate(token, name string, updSecret *model.Secre
 		Version:       updSecret.Version,
 		PayloadRaw:    updSecret.PayloadRaw,
 		UpdatePayload: updSecret.UpdatePayload,
+		ExpiresAt:     toUnix(updSecret.ExpiresAt),
+		RotateEvery:   int64(updSecret.RotateEvery / time.Second),
 	}
 
 	_, err := gt.gClt.SecretUpdate(contextWithToken(ctx, token), updReq)
 	if err != nil {
-		gt.logger.Errorf("gRPC secret update request error: %v", err)
 		status, ok := status.FromError(err)
 		if !ok {
 			return ErrInternalServerError
@@ -306,7 +452,7 @@ func (gt *GrpcTransport) SecretUpdate(token, name string, updSecret *model.Secre
 		case codes.PermissionDenied:
 			return ErrUserPermissionDenied
 		case codes.ResourceExhausted:
-			return ErrSecretPayloadSizeExceeded
+			return resourceExhaustedError(status)
 		default:
 			return ErrInternalServerError
 		}
@@ -330,7 +476,6 @@ func (gt *GrpcTransport) SecretDelete(token, name string) error {
 
 	_, err := gt.gClt.SecretDelete(contextWithToken(ctx, token), &pb.SecretDeleteRequest{Name: name})
 	if err != nil {
-		gt.logger.Errorf("gRPC secret delete request error: %v", err)
 		status, ok := status.FromError(err)
 		if !ok {
 			return ErrInternalServerError
@@ -347,7 +492,198 @@ func (gt *GrpcTransport) SecretDelete(token, name string) error {
 	return nil
 }
 
+// SecretWatch is a gRPC implemention of user's secret watch method.
+//
+// Accepts context, authenticated user token, callback to be called when watch established
+// and callback to be called for every secret event. Blocks till context canceled or watch failed.
+//
+// Returns nil, if context canceled, or error:
+//
+// - ErrUserPermissionDenied - provided user token not valid and permission denied.
+//
+// - ErrSecretWatchInterrupted - watch interrupted by server, events could be lost.
+//
+// - ErrInternalServerError - unexpected server error.
+func (gt *GrpcTransport) SecretWatch(
+	ctx context.Context,
+	token string,
+	fnReady func(),
+	fnEvent func(model.SecretEvent),
+) error {
+	stream, err := gt.gClt.SecretWatch(contextWithToken(ctx, token), &pb.Empty{})
+	if err != nil {
+		return gt.secretWatchError(ctx, err)
+	}
+
+	// Server sends headers, when subscription is ready.
+	// No headers received, if watch failed before, error is returned by Recv.
+	md, err := stream.Header()
+	if err != nil {
+		return gt.secretWatchError(ctx, err)
+	}
+	if md != nil {
+		fnReady()
+	}
+
+	for {
+		pbEvent, err := stream.Recv()
+		if err != nil {
+			return gt.secretWatchError(ctx, err)
+		}
+
+		fnEvent(model.SecretEvent{
+			EventType:  model.SecretEventType(pbEvent.EventType),
+			Name:       pbEvent.Name,
+			SecretType: model.SecretType(pbEvent.Type),
+			Version:    pbEvent.Version,
+		})
+	}
+}
+
+// SecretCreateOneTimeShare is a gRPC implemention of one-time share create method.
+//
+// Accepts authenticated user token, share with payload, encrypted on client, and max views in ViewsLeft,
+// and share TTL. Zero TTL and views mean server defaults.
+//
+// Returns share id and expiration time or error:
+//
+// - ErrUserPermissionDenied - provided user token not valid and permission denied.
+//
+// - ErrSecretPayloadSizeExceeded - payload too big.
+//
+// - ErrQuotaExceeded - user's storage quota exceeded, wrapped with violation description.
+//
+// - ErrSecretInvalid - provided share not valid.
+//
+// - ErrInternalServerError - unexpected server error.
+func (gt *GrpcTransport) SecretCreateOneTimeShare(
+	token string,
+	share *model.Share,
+	ttl time.Duration,
+) (string, time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), _serverRequestTimeout)
+	defer cancel()
+
+	resp, err := gt.gClt.SecretCreateOneTimeShare(contextWithToken(ctx, token), &pb.SecretCreateOneTimeShareRequest{
+		Name:             share.SecretName,
+		Type:             pb.SecretType(share.Type),
+		PayloadEncrypted: share.PayloadEncrypted,
+		Ttl:              int64(ttl / time.Second),
+		MaxViews:         share.ViewsLeft,
+	})
+	if err != nil {
+		status, ok := status.FromError(err)
+		if !ok {
+			return "", time.Time{}, ErrInternalServerError
+		}
+
+		switch status.Code() { //nolint:exhaustive // OK
+		case codes.PermissionDenied:
+			return "", time.Time{}, ErrUserPermissionDenied
+		case codes.ResourceExhausted:
+			return "", time.Time{}, resourceExhaustedError(status)
+		case codes.InvalidArgument:
+			return "", time.Time{}, ErrSecretInvalid
+		default:
+			return "", time.Time{}, ErrInternalServerError
+		}
+	}
+
+	return resp.Id, time.Unix(resp.ExpiresAt, 0), nil
+}
+
+// SecretRedeemOneTimeShare is a gRPC implemention of one-time share redeem method.
+// Authentication is not required. Accepts share id.
+//
+// Returns share with encrypted payload and remaining views or error:
+//
+// - ErrShareNotFound - share not found, expired or already viewed.
+//
+// - ErrTooManyRequests - redeem rate limit exceeded.
+//
+// - ErrInternalServerError - unexpected server error.
+func (gt *GrpcTransport) SecretRedeemOneTimeShare(id string) (*model.Share, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), _serverRequestTimeout)
+	defer cancel()
+
+	resp, err := gt.gClt.SecretRedeemOneTimeShare(ctx, &pb.SecretRedeemOneTimeShareRequest{Id: id})
+	if err != nil {
+		status, ok := status.FromError(err)
+		if !ok {
+			return nil, ErrInternalServerError
+		}
+
+		switch status.Code() { //nolint:exhaustive // OK
+		case codes.NotFound:
+			return nil, ErrShareNotFound
+		case codes.ResourceExhausted:
+			return nil, ErrTooManyRequests
+		default:
+			return nil, ErrInternalServerError
+		}
+	}
+
+	return &model.Share{
+		ID:               id,
+		Type:             model.SecretType(resp.Type),
+		PayloadEncrypted: resp.PayloadEncrypted,
+		ViewsLeft:        resp.ViewsLeft,
+	}, nil
+}
+
+func (gt *GrpcTransport) secretWatchError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	status, ok := status.FromError(err)
+	if !ok {
+		return ErrInternalServerError
+	}
+
+	switch status.Code() { //nolint:exhaustive // OK
+	case codes.PermissionDenied:
+		return ErrUserPermissionDenied
+	case codes.Unavailable:
+		return ErrSecretWatchInterrupted
+	default:
+		return ErrInternalServerError
+	}
+}
+
+// toUnix converts time to unix seconds, zero time is 0.
+func toUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// fromUnix converts unix seconds to time, 0 is zero time.
+func fromUnix(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
 func contextWithToken(ctx context.Context, cltToken string) context.Context {
 	md := metadata.New(map[string]string{token.HeaderName: cltToken})
 	return metadata.NewOutgoingContext(ctx, md)
 }
+
+// resourceExhaustedError returns ErrQuotaExceeded if status has quota failure details,
+// otherwise secret payload is too big.
+func resourceExhaustedError(st *status.Status) error {
+	for _, detail := range st.Details() {
+		quotaFailure, ok := detail.(*errdetails.QuotaFailure)
+		if !ok || len(quotaFailure.Violations) == 0 {
+			continue
+		}
+
+		violation := quotaFailure.Violations[0]
+		return fmt.Errorf("%w: %s %s", ErrQuotaExceeded, violation.Subject, violation.Description)
+	}
+
+	return ErrSecretPayloadSizeExceeded
+}
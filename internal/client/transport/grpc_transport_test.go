@@ -1,17 +1,24 @@
 package transport
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/devldavydov/gophkeeper/internal/common/nettools"
 	pb "github.com/devldavydov/gophkeeper/internal/grpc"
 	"github.com/devldavydov/gophkeeper/internal/grpc/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -136,6 +143,181 @@ func (gt *GrpcTransportSuite) TestUserLogin() {
 	}
 }
 
+func (gt *GrpcTransportSuite) TestUserGetAuditLog() {
+	from, to := time.Unix(1681000000, 0), time.Unix(1682000000, 0)
+
+	fGetAuditLog := func() ([]model.AuditEvent, error) {
+		return gt.tr.UserGetAuditLog("token", from, to)
+	}
+
+	fMock := func(args ...any) {
+		gt.gCltMock.EXPECT().
+			UserGetAuditLog(gomock.Any(), &pb.UserGetAuditLogRequest{From: 1681000000, To: 1682000000}).
+			Return(args...)
+	}
+
+	for i, tt := range []struct {
+		fMockArgs []any
+		expEvents []model.AuditEvent
+		expErr    error
+	}{
+		{
+			fMockArgs: []any{nil, errors.New("Not gRPC error")},
+			expErr:    ErrInternalServerError,
+		},
+		{
+			fMockArgs: []any{nil, status.Error(codes.Internal, "")},
+			expErr:    ErrInternalServerError,
+		},
+		{
+			fMockArgs: []any{nil, status.Error(codes.PermissionDenied, "")},
+			expErr:    ErrUserPermissionDenied,
+		},
+		{
+			fMockArgs: []any{nil, status.Error(codes.InvalidArgument, "")},
+			expErr:    ErrAuditLogInvalidTimeRange,
+		},
+		{
+			fMockArgs: []any{&pb.UserGetAuditLogResponse{}, nil},
+			expEvents: make([]model.AuditEvent, 0),
+		},
+		{
+			fMockArgs: []any{
+				&pb.UserGetAuditLogResponse{Items: []*pb.AuditEvent{
+					{
+						EventType:   pb.AuditEventType_AUDIT_SECRET_READ,
+						SecretName:  "foo",
+						PeerAddress: "127.0.0.1:12345",
+						UserAgent:   "gophkeeper-client",
+						Outcome:     "OK",
+						CreatedAt:   1681500000,
+					},
+					{
+						EventType:   pb.AuditEventType_AUDIT_USER_LOGIN,
+						PeerAddress: "127.0.0.1:12346",
+						Outcome:     "PermissionDenied",
+						CreatedAt:   1681400000,
+					},
+				}},
+				nil},
+			expEvents: []model.AuditEvent{
+				{
+					Type:        model.AuditSecretRead,
+					SecretName:  "foo",
+					PeerAddress: "127.0.0.1:12345",
+					UserAgent:   "gophkeeper-client",
+					Outcome:     "OK",
+					CreatedAt:   time.Unix(1681500000, 0),
+				},
+				{
+					Type:        model.AuditUserLogin,
+					PeerAddress: "127.0.0.1:12346",
+					Outcome:     "PermissionDenied",
+					CreatedAt:   time.Unix(1681400000, 0),
+				},
+			},
+		},
+	} {
+		tt := tt
+		gt.Run(fmt.Sprintf("Run %d", i), func() {
+			fMock(tt.fMockArgs...)
+			events, err := fGetAuditLog()
+			gt.Equal(tt.expEvents, events)
+			if tt.expErr != nil {
+				gt.ErrorIs(err, tt.expErr)
+			}
+		})
+	}
+
+	gt.Run("not limited time range", func() {
+		gt.gCltMock.EXPECT().
+			UserGetAuditLog(gomock.Any(), &pb.UserGetAuditLogRequest{}).
+			Return(&pb.UserGetAuditLogResponse{}, nil)
+		_, err := gt.tr.UserGetAuditLog("token", time.Time{}, time.Time{})
+		gt.NoError(err)
+	})
+}
+
+func (gt *GrpcTransportSuite) TestUserGetUsage() {
+	fMock := func(args ...any) {
+		gt.gCltMock.EXPECT().UserGetUsage(gomock.Any(), &pb.Empty{}).Return(args...)
+	}
+
+	for i, tt := range []struct {
+		fMockArgs []any
+		expUsage  *model.Usage
+		expErr    error
+	}{
+		{
+			fMockArgs: []any{nil, errors.New("Not gRPC error")},
+			expErr:    ErrInternalServerError,
+		},
+		{
+			fMockArgs: []any{nil, status.Error(codes.Internal, "")},
+			expErr:    ErrInternalServerError,
+		},
+		{
+			fMockArgs: []any{nil, status.Error(codes.PermissionDenied, "")},
+			expErr:    ErrUserPermissionDenied,
+		},
+		{
+			fMockArgs: []any{&pb.UserUsage{Bytes: 10, Secrets: 1, MaxBytes: 100, MaxSecrets: 5}, nil},
+			expUsage:  &model.Usage{Bytes: 10, Secrets: 1, MaxBytes: 100, MaxSecrets: 5},
+		},
+	} {
+		tt := tt
+		gt.Run(fmt.Sprintf("Run %d", i), func() {
+			fMock(tt.fMockArgs...)
+			usage, err := gt.tr.UserGetUsage("token")
+			gt.Equal(tt.expUsage, usage)
+			if tt.expErr != nil {
+				gt.ErrorIs(err, tt.expErr)
+			}
+		})
+	}
+}
+
+func (gt *GrpcTransportSuite) TestQuotaExceededDescription() {
+	gt.gCltMock.EXPECT().SecretUpdate(gomock.Any(), gomock.Any()).Return(nil, quotaExceededStatus())
+
+	err := gt.tr.SecretUpdate("token", "name", &model.SecretUpdate{Version: 2})
+	gt.ErrorIs(err, ErrQuotaExceeded)
+	gt.EqualError(err, "quota exceeded: secrets used 5, requested 1, limit 5")
+}
+
+func (gt *GrpcTransportSuite) TestSecretGetExpiring() {
+	gt.gCltMock.EXPECT().
+		SecretGetList(gomock.Any(), &pb.SecretGetListRequest{ExpiringWithinDays: 30}).
+		Return(&pb.SecretGetListResponse{Items: []*pb.SecretListItem{
+			{Name: "foo", Version: 1, Type: pb.SecretType_CARD, UpdatedAt: 1681000000, ExpiresAt: 1682000000},
+			{
+				Name: "bar", Version: 2, Type: pb.SecretType_CREDS,
+				UpdatedAt: 1681000000, PayloadUpdatedAt: 1680000000, RotateEvery: 86400,
+			},
+		}}, nil)
+
+	lst, err := gt.tr.SecretGetExpiring("token", 30)
+	gt.NoError(err)
+	gt.Equal([]model.SecretInfo{
+		{
+			Type: model.CardSecret, Name: "foo", Version: 1,
+			UpdatedAt: time.Unix(1681000000, 0), ExpiresAt: time.Unix(1682000000, 0),
+		},
+		{
+			Type: model.CredsSecret, Name: "bar", Version: 2,
+			UpdatedAt: time.Unix(1681000000, 0), PayloadUpdatedAt: time.Unix(1680000000, 0),
+			RotateEvery: 24 * time.Hour,
+		},
+	}, lst)
+
+	gt.gCltMock.EXPECT().
+		SecretGetList(gomock.Any(), &pb.SecretGetListRequest{ExpiringWithinDays: -1}).
+		Return(nil, status.Error(codes.InvalidArgument, ""))
+
+	_, err = gt.tr.SecretGetExpiring("token", -1)
+	gt.ErrorIs(err, ErrSecretInvalid)
+}
+
 func (gt *GrpcTransportSuite) TestSecretGetList() {
 	fGetList := func() ([]model.SecretInfo, error) {
 		return gt.tr.SecretGetList("token")
@@ -143,7 +325,7 @@ func (gt *GrpcTransportSuite) TestSecretGetList() {
 
 	fMock := func(args ...any) {
 		gt.gCltMock.EXPECT().
-			SecretGetList(gomock.Any(), &pb.Empty{}).
+			SecretGetList(gomock.Any(), &pb.SecretGetListRequest{}).
 			Return(args...)
 	}
 
@@ -175,17 +357,23 @@ func (gt *GrpcTransportSuite) TestSecretGetList() {
 		{
 			fMockArgs: []any{
 				&pb.SecretGetListResponse{Items: []*pb.SecretListItem{
-					{Name: "foo", Version: 1, Type: pb.SecretType_BINARY},
-					{Name: "bar", Version: 2, Type: pb.SecretType_TEXT},
-					{Name: "fuzz", Version: 3, Type: pb.SecretType_CARD},
-					{Name: "buzz", Version: 4, Type: pb.SecretType_CREDS},
+					{Name: "foo", Version: 1, Type: pb.SecretType_BINARY, UpdatedAt: 1681000000},
+					{Name: "bar", Version: 2, Type: pb.SecretType_TEXT, UpdatedAt: 1682000000},
+					{Name: "fuzz", Version: 3, Type: pb.SecretType_CARD, UpdatedAt: 1683000000},
+					{
+						Name: "buzz", Version: 4, Type: pb.SecretType_CREDS,
+						UpdatedAt: 1684000000, PayloadUpdatedAt: 1680000000,
+					},
 				}},
 				nil},
 			expList: []model.SecretInfo{
-				{Type: model.BinarySecret, Name: "foo", Version: 1},
-				{Type: model.TextSecret, Name: "bar", Version: 2},
-				{Type: model.CardSecret, Name: "fuzz", Version: 3},
-				{Type: model.CredsSecret, Name: "buzz", Version: 4},
+				{Type: model.BinarySecret, Name: "foo", Version: 1, UpdatedAt: time.Unix(1681000000, 0)},
+				{Type: model.TextSecret, Name: "bar", Version: 2, UpdatedAt: time.Unix(1682000000, 0)},
+				{Type: model.CardSecret, Name: "fuzz", Version: 3, UpdatedAt: time.Unix(1683000000, 0)},
+				{
+					Type: model.CredsSecret, Name: "buzz", Version: 4,
+					UpdatedAt: time.Unix(1684000000, 0), PayloadUpdatedAt: time.Unix(1680000000, 0),
+				},
 			},
 			expErr: nil,
 		},
@@ -311,6 +499,10 @@ func (gt *GrpcTransportSuite) TestSecretCreate() {
 			fMockArgs: []any{nil, status.Error(codes.ResourceExhausted, "")},
 			expErr:    ErrSecretPayloadSizeExceeded,
 		},
+		{
+			fMockArgs: []any{nil, quotaExceededStatus()},
+			expErr:    ErrQuotaExceeded,
+		},
 		{
 			fMockArgs: []any{nil, status.Error(codes.PermissionDenied, "")},
 			expErr:    ErrUserPermissionDenied,
@@ -381,6 +573,10 @@ func (gt *GrpcTransportSuite) TestSecretUpdate() {
 			fMockArgs: []any{nil, status.Error(codes.ResourceExhausted, "")},
 			expErr:    ErrSecretPayloadSizeExceeded,
 		},
+		{
+			fMockArgs: []any{nil, quotaExceededStatus()},
+			expErr:    ErrQuotaExceeded,
+		},
 		{
 			fMockArgs: []any{nil, status.Error(codes.InvalidArgument, "")},
 			expErr:    ErrSecretInvalid,
@@ -444,6 +640,157 @@ func (gt *GrpcTransportSuite) TestSecretDelete() {
 	}
 }
 
+func (gt *GrpcTransportSuite) TestSecretWatch() {
+	gt.Run("open stream error", func() {
+		gt.gCltMock.EXPECT().
+			SecretWatch(gomock.Any(), &pb.Empty{}).
+			Return(nil, status.Error(codes.PermissionDenied, ""))
+
+		err := gt.tr.SecretWatch(context.Background(), "token", func() {}, func(model.SecretEvent) {})
+		gt.ErrorIs(err, ErrUserPermissionDenied)
+	})
+
+	for i, tt := range []struct {
+		recvErr error
+		expErr  error
+	}{
+		{recvErr: errors.New("Not gRPC error"), expErr: ErrInternalServerError},
+		{recvErr: status.Error(codes.Internal, ""), expErr: ErrInternalServerError},
+		{recvErr: status.Error(codes.PermissionDenied, ""), expErr: ErrUserPermissionDenied},
+		{recvErr: status.Error(codes.Unavailable, ""), expErr: ErrSecretWatchInterrupted},
+	} {
+		tt := tt
+		gt.Run(fmt.Sprintf("Run %d", i), func() {
+			streamMock := mocks.NewMockGophKeeperService_SecretWatchClient(gt.gmckCtrl)
+			gt.gCltMock.EXPECT().SecretWatch(gomock.Any(), &pb.Empty{}).Return(streamMock, nil)
+			gomock.InOrder(
+				streamMock.EXPECT().Header().Return(metadata.MD{}, nil),
+				streamMock.EXPECT().Recv().Return(&pb.SecretEvent{
+					EventType: pb.SecretEventType_SECRET_CREATED,
+					Name:      "foo",
+					Type:      pb.SecretType_TEXT,
+					Version:   1,
+				}, nil),
+				streamMock.EXPECT().Recv().Return(nil, tt.recvErr),
+			)
+
+			var ready bool
+			var events []model.SecretEvent
+			err := gt.tr.SecretWatch(
+				context.Background(),
+				"token",
+				func() { ready = true },
+				func(event model.SecretEvent) { events = append(events, event) },
+			)
+			gt.ErrorIs(err, tt.expErr)
+			gt.True(ready)
+			gt.Equal([]model.SecretEvent{{
+				EventType:  model.SecretCreated,
+				Name:       "foo",
+				SecretType: model.TextSecret,
+				Version:    1,
+			}}, events)
+		})
+	}
+
+	gt.Run("context canceled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		streamMock := mocks.NewMockGophKeeperService_SecretWatchClient(gt.gmckCtrl)
+		gt.gCltMock.EXPECT().SecretWatch(gomock.Any(), &pb.Empty{}).Return(streamMock, nil)
+		streamMock.EXPECT().Header().Return(metadata.MD{}, nil)
+		streamMock.EXPECT().Recv().DoAndReturn(func() (*pb.SecretEvent, error) {
+			cancel()
+			return nil, status.Error(codes.Canceled, "")
+		})
+
+		err := gt.tr.SecretWatch(ctx, "token", func() {}, func(model.SecretEvent) {})
+		gt.NoError(err)
+	})
+}
+
+func (gt *GrpcTransportSuite) TestClose() {
+	gt.NoError(gt.tr.(*GrpcTransport).Close())
+
+	_, this, _, _ := runtime.Caller(0)
+	addr, err := nettools.NewAddress("127.0.0.1:8080")
+	gt.Require().NoError(err)
+	tr, err := NewGrpcTransport(
+		addr,
+		filepath.Join(this, "../../../../tls/ca-cert.pem"),
+		"",
+		"",
+		logrus.New(),
+	)
+	gt.Require().NoError(err)
+	gt.NoError(tr.Close())
+}
+
+func quotaExceededStatus() error {
+	st, _ := status.New(codes.ResourceExhausted, "").WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "secrets", Description: "used 5, requested 1, limit 5"},
+		},
+	})
+	return st.Err()
+}
+
 func TestGrpcTransportSuite(t *testing.T) {
 	suite.Run(t, new(GrpcTransportSuite))
 }
+
+func (gt *GrpcTransportSuite) TestSecretCreateOneTimeShare() {
+	share := &model.Share{SecretName: "foo", Type: model.TextSecret, PayloadEncrypted: []byte("bar"), ViewsLeft: 2}
+
+	gt.gCltMock.EXPECT().
+		SecretCreateOneTimeShare(gomock.Any(), &pb.SecretCreateOneTimeShareRequest{
+			Name: "foo", Type: pb.SecretType_TEXT, PayloadEncrypted: []byte("bar"), Ttl: 3600, MaxViews: 2,
+		}).
+		Return(&pb.SecretCreateOneTimeShareResponse{Id: "id", ExpiresAt: 1700000000}, nil)
+
+	id, expiresAt, err := gt.tr.SecretCreateOneTimeShare("token", share, time.Hour)
+	gt.NoError(err)
+	gt.Equal("id", id)
+	gt.Equal(time.Unix(1700000000, 0), expiresAt)
+
+	for _, tt := range []struct {
+		err    error
+		expErr error
+	}{
+		{err: errors.New("Not gRPC error"), expErr: ErrInternalServerError},
+		{err: status.Error(codes.PermissionDenied, ""), expErr: ErrUserPermissionDenied},
+		{err: status.Error(codes.ResourceExhausted, ""), expErr: ErrSecretPayloadSizeExceeded},
+		{err: quotaExceededStatus(), expErr: ErrQuotaExceeded},
+		{err: status.Error(codes.InvalidArgument, ""), expErr: ErrSecretInvalid},
+		{err: status.Error(codes.Internal, ""), expErr: ErrInternalServerError},
+	} {
+		gt.gCltMock.EXPECT().SecretCreateOneTimeShare(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+		_, _, err = gt.tr.SecretCreateOneTimeShare("token", share, 0)
+		gt.ErrorIs(err, tt.expErr)
+	}
+}
+
+func (gt *GrpcTransportSuite) TestSecretRedeemOneTimeShare() {
+	gt.gCltMock.EXPECT().
+		SecretRedeemOneTimeShare(gomock.Any(), &pb.SecretRedeemOneTimeShareRequest{Id: "id"}).
+		Return(&pb.SecretRedeemOneTimeShareResponse{
+			Type: pb.SecretType_CREDS, PayloadEncrypted: []byte("bar"), ViewsLeft: 1,
+		}, nil)
+
+	share, err := gt.tr.SecretRedeemOneTimeShare("id")
+	gt.NoError(err)
+	gt.Equal(&model.Share{ID: "id", Type: model.CredsSecret, PayloadEncrypted: []byte("bar"), ViewsLeft: 1}, share)
+
+	for _, tt := range []struct {
+		err    error
+		expErr error
+	}{
+		{err: errors.New("Not gRPC error"), expErr: ErrInternalServerError},
+		{err: status.Error(codes.NotFound, ""), expErr: ErrShareNotFound},
+		{err: status.Error(codes.ResourceExhausted, ""), expErr: ErrTooManyRequests},
+		{err: status.Error(codes.Internal, ""), expErr: ErrInternalServerError},
+	} {
+		gt.gCltMock.EXPECT().SecretRedeemOneTimeShare(gomock.Any(), gomock.Any()).Return(nil, tt.err)
+		_, err = gt.tr.SecretRedeemOneTimeShare("id")
+		gt.ErrorIs(err, tt.expErr)
+	}
+}
@@ -0,0 +1,229 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/devldavydov/gophkeeper/internal/client/transport (interfaces: Transport)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/devldavydov/gophkeeper/internal/common/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTransport is a mock of Transport interface.
+type MockTransport struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransportMockRecorder
+}
+
+// MockTransportMockRecorder is the mock recorder for MockTransport.
+type MockTransportMockRecorder struct {
+	mock *MockTransport
+}
+
+// NewMockTransport creates a new mock instance.
+func NewMockTransport(ctrl *gomock.Controller) *MockTransport {
+	mock := &MockTransport{ctrl: ctrl}
+	mock.recorder = &MockTransportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransport) EXPECT() *MockTransportMockRecorder {
+	return m.recorder
+}
+
+// SecretCreate mocks base method.
+func (m *MockTransport) SecretCreate(arg0 string, arg1 *model.Secret) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretCreate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecretCreate indicates an expected call of SecretCreate.
+func (mr *MockTransportMockRecorder) SecretCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretCreate", reflect.TypeOf((*MockTransport)(nil).SecretCreate), arg0, arg1)
+}
+
+// SecretCreateOneTimeShare mocks base method.
+func (m *MockTransport) SecretCreateOneTimeShare(arg0 string, arg1 *model.Share, arg2 time.Duration) (string, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretCreateOneTimeShare", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SecretCreateOneTimeShare indicates an expected call of SecretCreateOneTimeShare.
+func (mr *MockTransportMockRecorder) SecretCreateOneTimeShare(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretCreateOneTimeShare", reflect.TypeOf((*MockTransport)(nil).SecretCreateOneTimeShare), arg0, arg1, arg2)
+}
+
+// SecretDelete mocks base method.
+func (m *MockTransport) SecretDelete(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecretDelete indicates an expected call of SecretDelete.
+func (mr *MockTransportMockRecorder) SecretDelete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretDelete", reflect.TypeOf((*MockTransport)(nil).SecretDelete), arg0, arg1)
+}
+
+// SecretGet mocks base method.
+func (m *MockTransport) SecretGet(arg0, arg1 string) (*model.Secret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretGet", arg0, arg1)
+	ret0, _ := ret[0].(*model.Secret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretGet indicates an expected call of SecretGet.
+func (mr *MockTransportMockRecorder) SecretGet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretGet", reflect.TypeOf((*MockTransport)(nil).SecretGet), arg0, arg1)
+}
+
+// SecretGetExpiring mocks base method.
+func (m *MockTransport) SecretGetExpiring(arg0 string, arg1 int) ([]model.SecretInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretGetExpiring", arg0, arg1)
+	ret0, _ := ret[0].([]model.SecretInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretGetExpiring indicates an expected call of SecretGetExpiring.
+func (mr *MockTransportMockRecorder) SecretGetExpiring(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretGetExpiring", reflect.TypeOf((*MockTransport)(nil).SecretGetExpiring), arg0, arg1)
+}
+
+// SecretGetList mocks base method.
+func (m *MockTransport) SecretGetList(arg0 string) ([]model.SecretInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretGetList", arg0)
+	ret0, _ := ret[0].([]model.SecretInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretGetList indicates an expected call of SecretGetList.
+func (mr *MockTransportMockRecorder) SecretGetList(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretGetList", reflect.TypeOf((*MockTransport)(nil).SecretGetList), arg0)
+}
+
+// SecretRedeemOneTimeShare mocks base method.
+func (m *MockTransport) SecretRedeemOneTimeShare(arg0 string) (*model.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretRedeemOneTimeShare", arg0)
+	ret0, _ := ret[0].(*model.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretRedeemOneTimeShare indicates an expected call of SecretRedeemOneTimeShare.
+func (mr *MockTransportMockRecorder) SecretRedeemOneTimeShare(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretRedeemOneTimeShare", reflect.TypeOf((*MockTransport)(nil).SecretRedeemOneTimeShare), arg0)
+}
+
+// SecretUpdate mocks base method.
+func (m *MockTransport) SecretUpdate(arg0, arg1 string, arg2 *model.SecretUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretUpdate", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecretUpdate indicates an expected call of SecretUpdate.
+func (mr *MockTransportMockRecorder) SecretUpdate(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretUpdate", reflect.TypeOf((*MockTransport)(nil).SecretUpdate), arg0, arg1, arg2)
+}
+
+// SecretWatch mocks base method.
+func (m *MockTransport) SecretWatch(arg0 context.Context, arg1 string, arg2 func(), arg3 func(model.SecretEvent)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretWatch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SecretWatch indicates an expected call of SecretWatch.
+func (mr *MockTransportMockRecorder) SecretWatch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretWatch", reflect.TypeOf((*MockTransport)(nil).SecretWatch), arg0, arg1, arg2, arg3)
+}
+
+// UserCreate mocks base method.
+func (m *MockTransport) UserCreate(arg0, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserCreate", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserCreate indicates an expected call of UserCreate.
+func (mr *MockTransportMockRecorder) UserCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCreate", reflect.TypeOf((*MockTransport)(nil).UserCreate), arg0, arg1)
+}
+
+// UserGetAuditLog mocks base method.
+func (m *MockTransport) UserGetAuditLog(arg0 string, arg1, arg2 time.Time) ([]model.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserGetAuditLog", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserGetAuditLog indicates an expected call of UserGetAuditLog.
+func (mr *MockTransportMockRecorder) UserGetAuditLog(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetAuditLog", reflect.TypeOf((*MockTransport)(nil).UserGetAuditLog), arg0, arg1, arg2)
+}
+
+// UserGetUsage mocks base method.
+func (m *MockTransport) UserGetUsage(arg0 string) (*model.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserGetUsage", arg0)
+	ret0, _ := ret[0].(*model.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserGetUsage indicates an expected call of UserGetUsage.
+func (mr *MockTransportMockRecorder) UserGetUsage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetUsage", reflect.TypeOf((*MockTransport)(nil).UserGetUsage), arg0)
+}
+
+// UserLogin mocks base method.
+func (m *MockTransport) UserLogin(arg0, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserLogin", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserLogin indicates an expected call of UserLogin.
+func (mr *MockTransportMockRecorder) UserLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLogin", reflect.TypeOf((*MockTransport)(nil).UserLogin), arg0, arg1)
+}
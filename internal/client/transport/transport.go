@@ -2,7 +2,9 @@
 package transport
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/devldavydov/gophkeeper/internal/common/model"
 )
@@ -18,7 +20,12 @@ var (
 	ErrSecretNotFound            = errors.New("secret not found")
 	ErrSecretOutdated            = errors.New("secret outdated")
 	ErrSecretPayloadSizeExceeded = errors.New("secret payload size exceeded")
+	ErrQuotaExceeded             = errors.New("quota exceeded")
 	ErrSecretInvalid             = errors.New("invalid secret")
+	ErrSecretWatchInterrupted    = errors.New("secret watch interrupted")
+	ErrAuditLogInvalidTimeRange  = errors.New("invalid audit log time range")
+	ErrShareNotFound             = errors.New("share not found or already viewed")
+	ErrTooManyRequests           = errors.New("too many requests")
 )
 
 // Transport is a common interface to connect with server.
@@ -28,9 +35,15 @@ type Transport interface {
 	UserCreate(userLogin, userPassword string) (string, error)
 	// Login existing user on server.
 	UserLogin(userLogin, userPassword string) (string, error)
+	// Retrieve user's audit log in time range.
+	UserGetAuditLog(token string, from, to time.Time) ([]model.AuditEvent, error)
+	// Retrieve user's storage usage and quota.
+	UserGetUsage(token string) (*model.Usage, error)
 
 	// Retreive users's secret list.
 	SecretGetList(token string) ([]model.SecretInfo, error)
+	// Retrieve user's secrets expired or due to rotation within days.
+	SecretGetExpiring(token string, days int) ([]model.SecretInfo, error)
 	// Retrieve user secret.
 	SecretGet(token, name string) (*model.Secret, error)
 	// Create user secret.
@@ -39,4 +52,10 @@ type Transport interface {
 	SecretUpdate(token, name string, updSecret *model.SecretUpdate) error
 	// Delete user secret.
 	SecretDelete(token, name string) error
+	// Watch user secret changes till context canceled.
+	SecretWatch(ctx context.Context, token string, fnReady func(), fnEvent func(model.SecretEvent)) error
+	// Create one-time share of encrypted secret payload.
+	SecretCreateOneTimeShare(token string, share *model.Share, ttl time.Duration) (string, time.Time, error)
+	// Retrieve one-time share without authentication.
+	SecretRedeemOneTimeShare(id string) (*model.Share, error)
 }
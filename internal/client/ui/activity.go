@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/rivo/tview"
+)
+
+// _activityPeriods - periods of activity page, zero period is not limited.
+var _activityPeriods = []struct { //nolint:gochecknoglobals // OK
+	title  string
+	period time.Duration
+}{
+	{title: "Last 24 hours", period: 24 * time.Hour},
+	{title: "Last 7 days", period: 7 * 24 * time.Hour},
+	{title: "Last 30 days", period: 30 * 24 * time.Hour},
+	{title: "All time"},
+}
+
+func (r *App) createActivityPage() {
+	r.wdgActivity = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWordWrap(true)
+	r.wdgActivity.SetBorder(true).SetTitle("Activity")
+
+	periods := make([]string, 0, len(_activityPeriods))
+	for _, p := range _activityPeriods {
+		periods = append(periods, p.title)
+	}
+
+	formActions := tview.NewForm().
+		AddDropDown("Period", periods, 1, nil).
+		AddButton("Back", r.doReloadUserSecrets)
+	r.ddActivityPeriod, _ = formActions.GetFormItemByLabel("Period").(*tview.DropDown)
+	r.ddActivityPeriod.SetSelectedFunc(func(string, int) {
+		if err := r.loadActivity(); err != nil {
+			r.showError(_msgInternalServerError, r.doReloadUserSecrets)
+		}
+	})
+
+	flexActivity := tview.NewFlex().SetDirection(tview.FlexRow)
+	flexActivity.AddItem(r.wdgActivity, 0, 1, true)
+	flexActivity.AddItem(formActions, 5, 1, false)
+
+	r.uiPages.AddPage(_pageActivity, uiCenteredWidget(flexActivity, 0, 10), true, false)
+}
+
+func (r *App) doActivity() {
+	if err := r.loadActivity(); err != nil {
+		r.showError(_msgInternalServerError, r.doReloadUserSecrets)
+		return
+	}
+
+	r.uiPages.SwitchToPage(_pageActivity)
+}
+
+// loadActivity loads user's audit log of selected period from server.
+func (r *App) loadActivity() error {
+	var from time.Time
+	if idx, _ := r.ddActivityPeriod.GetCurrentOption(); idx >= 0 && _activityPeriods[idx].period != 0 {
+		from = time.Now().Add(-_activityPeriods[idx].period)
+	}
+
+	events, err := r.tr.UserGetAuditLog(r.cltToken, from, time.Time{})
+	if err != nil {
+		r.logger.Errorf("activity load error: %v", err)
+		return err
+	}
+
+	r.wdgActivity.SetText(uiActivityText(events)).ScrollToBeginning()
+	return nil
+}
+
+func uiActivityText(events []model.AuditEvent) string {
+	if len(events) == 0 {
+		return "No activity\n"
+	}
+
+	var sb strings.Builder
+	for _, event := range events {
+		color := "green"
+		if event.Outcome != model.AuditOutcomeOK {
+			color = "red"
+		}
+
+		fmt.Fprintf(&sb, "%s [yellow]%s[-]", event.CreatedAt.Local().Format(time.DateTime), event.Type)
+		if event.SecretName != "" {
+			fmt.Fprintf(&sb, " %s", tview.Escape(event.SecretName))
+		}
+		fmt.Fprintf(&sb, " [%s]%s[-]\n", color, tview.Escape(event.Outcome))
+		fmt.Fprintf(&sb, "  from %s", tview.Escape(event.PeerAddress))
+		if event.UserAgent != "" {
+			fmt.Fprintf(&sb, " (%s)", tview.Escape(event.UserAgent))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// uiCopyField describes secret form field, which can be copied to clipboard
+// with button or Alt+key shortcut.
+type uiCopyField struct {
+	label  string
+	button string
+	key    rune
+}
+
+func uiCopyFields(secretType model.SecretType) []uiCopyField {
+	switch secretType {
+	case model.CredsSecret:
+		return []uiCopyField{
+			{label: "Login", button: "Copy login", key: 'l'},
+			{label: "Password", button: "Copy password", key: 'p'},
+		}
+	case model.CardSecret:
+		return []uiCopyField{
+			{label: "Card number", button: "Copy number", key: 'n'},
+			{label: "CVV", button: "Copy CVV", key: 'v'},
+		}
+	default:
+		return nil
+	}
+}
+
+// uiCopyFieldsTitle returns form title with copy shortcuts hint.
+func uiCopyFieldsTitle(title string, secretType model.SecretType) string {
+	var hints []string
+	for _, field := range uiCopyFields(secretType) {
+		hints = append(hints, fmt.Sprintf("Alt+%c: copy %s", unicode.ToUpper(field.key), strings.ToLower(field.label)))
+	}
+
+	if len(hints) == 0 {
+		return title
+	}
+	return fmt.Sprintf("%s - %s", title, strings.Join(hints, ", "))
+}
+
+// addCopyButtons adds copy buttons for secret type fields to form.
+func (r *App) addCopyButtons(frm *tview.Form, secretType model.SecretType, fnBack func()) {
+	for _, field := range uiCopyFields(secretType) {
+		field := field
+		frm.AddButton(field.button, func() {
+			r.doCopyField(frm, field.label, fnBack)
+		})
+	}
+}
+
+// uiCopyShortcutsCapture returns form input capture, which copies fields on Alt+key.
+func (r *App) uiCopyShortcutsCapture(frm *tview.Form, fnBack func()) func(event *tcell.EventKey) *tcell.EventKey {
+	return func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyRune || event.Modifiers()&tcell.ModAlt == 0 {
+			return event
+		}
+
+		for _, secretType := range []model.SecretType{model.CredsSecret, model.CardSecret} {
+			for _, field := range uiCopyFields(secretType) {
+				if unicode.ToLower(event.Rune()) == field.key && frm.GetFormItemByLabel(field.label) != nil {
+					r.doCopyField(frm, field.label, fnBack)
+					return nil
+				}
+			}
+		}
+
+		return event
+	}
+}
+
+func (r *App) doCopyField(frm *tview.Form, label string, fnBack func()) {
+	wdgField, ok := frm.GetFormItemByLabel(label).(*tview.InputField)
+	if !ok {
+		return
+	}
+
+	if err := r.clip.Copy(wdgField.GetText()); err != nil {
+		r.logger.Errorf("clipboard copy error: %v", err)
+		r.showError(fmt.Sprintf("Clipboard copy error: %v", err), fnBack)
+		return
+	}
+
+	msg := fmt.Sprintf("%s copied to clipboard", label)
+	if timeout := r.clip.ClearTimeout(); timeout > 0 {
+		msg += fmt.Sprintf(", it will be cleared in %s", timeout)
+	}
+	r.showNotice(msg, fnBack)
+}
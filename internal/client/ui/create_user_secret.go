@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/devldavydov/gophkeeper/internal/client/transport"
 	"github.com/devldavydov/gophkeeper/internal/common/model"
@@ -27,7 +28,9 @@ func (r *App) createCreateUserSecretPage() {
 			0,
 			r.uiChangeSecretPayloadFields,
 		).
-		AddInputField("Name", "", 0, nil, nil).
+		AddInputField("Name", "", 0, nil, nil)
+	addExpirationFields(r.frmCreateUserSecret)
+	r.frmCreateUserSecret.
 		AddTextArea("Meta", "", 0, 3, 0, nil).
 		AddButton("Create", r.doCreateUserSecret).
 		AddButton("Back to list", r.doReloadUserSecrets)
@@ -39,11 +42,16 @@ func (r *App) createCreateUserSecretPage() {
 }
 
 func (r *App) showCreateUserSecretCleared() {
-	r.frmCreateUserSecret.GetFormItemByLabel("Type").(*tview.DropDown).SetCurrentOption(0)
+	r.clearCreateUserSecretForm()
 	r.frmCreateUserSecret.SetFocus(r.frmCreateUser.GetFormItemIndex("Type"))
+	r.uiPages.SwitchToPage(_pageCreateUserSecret)
+}
+
+func (r *App) clearCreateUserSecretForm() {
+	r.frmCreateUserSecret.GetFormItemByLabel("Type").(*tview.DropDown).SetCurrentOption(0)
 	r.frmCreateUserSecret.GetFormItemByLabel("Name").(*tview.InputField).SetText("")
+	setExpirationFields(r.frmCreateUserSecret, model.UnknownSecret, time.Time{}, 0)
 	r.frmCreateUserSecret.GetFormItemByLabel("Meta").(*tview.TextArea).SetText("", true)
-	r.uiPages.SwitchToPage(_pageCreateUserSecret)
 }
 
 func (r *App) showCreateUserSecret() {
@@ -71,6 +79,7 @@ func (r *App) doCreateUserSecret() {
 	}
 
 	var payload model.Payload
+	var card *model.CardPayload
 
 	switch fType {
 	case model.CredsSecret.String():
@@ -94,12 +103,20 @@ func (r *App) doCreateUserSecret() {
 		payload = model.NewBinaryPayload(fileData)
 	case model.CardSecret.String():
 		secret.Type = model.CardSecret
-		payload = model.NewCardPayload(
+		card = model.NewCardPayload(
 			r.frmCreateUserSecret.GetFormItemByLabel("Card number").(*tview.InputField).GetText(),
 			r.frmCreateUserSecret.GetFormItemByLabel("Card holder").(*tview.InputField).GetText(),
 			r.frmCreateUserSecret.GetFormItemByLabel("Valid thru").(*tview.InputField).GetText(),
 			r.frmCreateUserSecret.GetFormItemByLabel("CVV").(*tview.InputField).GetText(),
 		)
+		payload = card
+	}
+
+	var err error
+	secret.ExpiresAt, secret.RotateEvery, err = parseExpirationFields(r.frmCreateUserSecret, card)
+	if err != nil {
+		r.showError(err.Error(), r.showCreateUserSecret)
+		return
 	}
 
 	payloadRaw, err := gkMsgp.Serialize(payload.(msgp.Encodable))
@@ -121,6 +138,9 @@ func (r *App) doCreateUserSecret() {
 			r.showError(_msgInternalServerError, r.showCreateUserSecret)
 		case errors.Is(err, transport.ErrSecretPayloadSizeExceeded):
 			r.showError(_msgSecretPayloadSizeExceeded, r.showCreateUserSecret)
+		case errors.Is(err, transport.ErrQuotaExceeded):
+			r.logger.Errorf("quota exceeded: %v", err)
+			r.showError(_msgQuotaExceeded, r.showCreateUserSecret)
 		case errors.Is(err, transport.ErrSecretInvalid):
 			r.showError(_msgSecretInvalid, r.showCreateUserSecret)
 		}
@@ -132,17 +152,32 @@ func (r *App) doCreateUserSecret() {
 
 func (r *App) uiChangeSecretPayloadFields(choosenType string, _ int) {
 	metaIndex := r.frmCreateUserSecret.GetFormItemIndex("Meta")
+	// Initial option is selected, while form is created
+	if metaIndex == -1 {
+		return
+	}
+
 	i := r.frmCreateUserSecret.GetFormItemCount() - 1
 	for i != metaIndex {
 		r.frmCreateUserSecret.RemoveFormItem(i)
 		i--
 	}
+	// Remove secret type specific buttons
+	btnBackIndex := r.frmCreateUserSecret.GetButtonIndex("Back to list")
+	for r.frmCreateUserSecret.GetButtonCount()-1 > btnBackIndex {
+		r.frmCreateUserSecret.RemoveButton(btnBackIndex + 1)
+	}
 
 	switch choosenType {
 	case model.CredsSecret.String():
 		r.frmCreateUserSecret.
 			AddInputField("Login", "", 0, nil, nil).
-			AddInputField("Password", "", 0, nil, nil)
+			AddInputField("Password", "", 0, nil, r.uiPasswordStrengthUpdater(r.frmCreateUserSecret)).
+			AddTextView("Strength", "", 0, 1, true, false).
+			AddButton("Generate password", func() {
+				r.showGeneratePassword(r.frmCreateUserSecret, r.showCreateUserSecret)
+			})
+		r.addRevealButtons(r.frmCreateUserSecret, model.CredsSecret)
 	case model.TextSecret.String():
 		r.frmCreateUserSecret.
 			AddTextArea("Text", "", 0, 3, 0, nil)
@@ -155,5 +190,12 @@ func (r *App) uiChangeSecretPayloadFields(choosenType string, _ int) {
 			AddInputField("Card holder", "", 0, nil, nil).
 			AddInputField("Valid thru", "", 0, nil, nil).
 			AddInputField("CVV", "", 0, nil, nil)
+		r.addRevealButtons(r.frmCreateUserSecret, model.CardSecret)
+	}
+
+	placeholder := _placeholderExpires
+	if choosenType == model.CardSecret.String() {
+		placeholder = _placeholderCard
 	}
+	r.frmCreateUserSecret.GetFormItemByLabel(_labelExpires).(*tview.InputField).SetPlaceholder(placeholder)
 }
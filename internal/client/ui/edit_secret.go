@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/devldavydov/gophkeeper/internal/client/transport"
 	"github.com/devldavydov/gophkeeper/internal/common/model"
@@ -17,7 +18,9 @@ func (r *App) createEditUserSecretPage() {
 	r.frmEditUserSecret = tview.NewForm()
 	r.frmEditUserSecret.
 		AddInputField("Type", "", 0, nil, nil).
-		AddInputField("Name", "", 0, nil, nil).
+		AddInputField("Name", "", 0, nil, nil)
+	addExpirationFields(r.frmEditUserSecret)
+	r.frmEditUserSecret.
 		AddTextArea("Meta", "", 0, 3, 0, nil).
 		AddButton("Save", r.doSaveSecret).
 		AddButton("Delete", r.doDeleteUserSecret).
@@ -28,6 +31,7 @@ func (r *App) createEditUserSecretPage() {
 
 	r.frmEditUserSecret.GetFormItemByLabel("Type").SetDisabled(true)
 	r.frmEditUserSecret.GetFormItemByLabel("Name").SetDisabled(true)
+	r.frmEditUserSecret.SetInputCapture(r.uiCopyShortcutsCapture(r.frmEditUserSecret, r.showEditUserSecretPage))
 
 	r.uiPages.AddPage(_pageEditUserSecret, uiCenteredWidget(r.frmEditUserSecret, 0, 10), true, false)
 }
@@ -38,26 +42,33 @@ func (r *App) showEditUserSecretPageClear(secret *model.Secret) {
 		r.showError(_msgClientError, r.doReloadUserSecrets)
 	}
 
-	metaIndex := r.frmEditUserSecret.GetFormItemIndex("Meta")
-	i := r.frmEditUserSecret.GetFormItemCount() - 1
-	for i != metaIndex {
-		r.frmEditUserSecret.RemoveFormItem(i)
-		i--
-	}
-	if btnIndex := r.frmEditUserSecret.GetButtonIndex("Copy file"); btnIndex != -1 {
-		r.frmEditUserSecret.RemoveButton(btnIndex)
-	}
-	r.frmEditUserSecret.SetFocus(metaIndex)
+	r.clearEditUserSecretForm()
+	r.editSecret = model.SecretInfo{Type: secret.Type, Name: secret.Name, Version: secret.Version}
+	r.frmEditUserSecret.SetFocus(r.frmEditUserSecret.GetFormItemIndex("Meta"))
+	r.frmEditUserSecret.SetTitle(uiCopyFieldsTitle("Edit secret", secret.Type))
 
 	r.frmEditUserSecret.GetFormItemByLabel("Type").(*tview.InputField).SetText(secret.Type.String())
 	r.frmEditUserSecret.GetFormItemByLabel("Name").(*tview.InputField).SetText(secret.Name)
 	r.frmEditUserSecret.GetFormItemByLabel("Meta").(*tview.TextArea).SetText(secret.Meta, false)
 
+	expiresAt := secret.ExpiresAt
+	if card, ok := payload.(*model.CardPayload); ok {
+		expiresAt = cardExpiresAt(card, expiresAt)
+	}
+	setExpirationFields(r.frmEditUserSecret, secret.Type, expiresAt, secret.RotateEvery)
+
 	switch secret.Type {
 	case model.CredsSecret:
 		creds, _ := payload.(*model.CredsPayload)
-		r.frmEditUserSecret.AddInputField("Login", creds.Login, 0, nil, nil)
-		r.frmEditUserSecret.AddInputField("Password", creds.Password, 0, nil, nil)
+		fnUpdateStrength := r.uiPasswordStrengthUpdater(r.frmEditUserSecret)
+		r.frmEditUserSecret.
+			AddInputField("Login", creds.Login, 0, nil, nil).
+			AddInputField("Password", creds.Password, 0, nil, fnUpdateStrength).
+			AddTextView("Strength", "", 0, 1, true, false).
+			AddButton("Generate password", func() {
+				r.showGeneratePassword(r.frmEditUserSecret, r.showEditUserSecretPage)
+			})
+		fnUpdateStrength(creds.Password)
 	case model.TextSecret:
 		text, _ := payload.(*model.TextPayload)
 		r.frmEditUserSecret.AddTextArea("Text", text.Data, 0, 3, 0, nil)
@@ -83,10 +94,34 @@ func (r *App) showEditUserSecretPageClear(secret *model.Secret) {
 			AddInputField("Valid thru", card.ValidThru, 0, nil, nil).
 			AddInputField("CVV", card.CVV, 0, nil, nil)
 	}
+	r.addRevealButtons(r.frmEditUserSecret, secret.Type)
+	r.addCopyButtons(r.frmEditUserSecret, secret.Type, r.showEditUserSecretPage)
 
 	r.uiPages.SwitchToPage(_pageEditUserSecret)
 }
 
+// clearEditUserSecretForm removes secret type specific fields and buttons from form
+// and clears common fields.
+func (r *App) clearEditUserSecretForm() {
+	metaIndex := r.frmEditUserSecret.GetFormItemIndex("Meta")
+	i := r.frmEditUserSecret.GetFormItemCount() - 1
+	for i != metaIndex {
+		r.frmEditUserSecret.RemoveFormItem(i)
+		i--
+	}
+
+	btnBackIndex := r.frmEditUserSecret.GetButtonIndex("Back to list")
+	for r.frmEditUserSecret.GetButtonCount()-1 > btnBackIndex {
+		r.frmEditUserSecret.RemoveButton(btnBackIndex + 1)
+	}
+
+	r.frmEditUserSecret.GetFormItemByLabel("Type").(*tview.InputField).SetText("")
+	r.frmEditUserSecret.GetFormItemByLabel("Name").(*tview.InputField).SetText("")
+	setExpirationFields(r.frmEditUserSecret, model.UnknownSecret, time.Time{}, 0)
+	r.frmEditUserSecret.GetFormItemByLabel("Meta").(*tview.TextArea).SetText("", false)
+	r.editSecret = model.SecretInfo{}
+}
+
 func (r *App) showEditUserSecretPage() {
 	r.uiPages.SwitchToPage(_pageEditUserSecret)
 }
@@ -118,7 +153,8 @@ func (r *App) doDeleteUserSecret() {
 }
 
 func (r *App) doSaveSecret() {
-	curSecret := r.lstSecrets[r.wdgLstSecrets.GetCurrentItem()]
+	// Use secret loaded to form, list could be already refreshed by watch
+	curSecret := r.editSecret
 
 	updSecret := &model.SecretUpdate{
 		Meta:          r.frmEditUserSecret.GetFormItemByLabel("Meta").(*tview.TextArea).GetText(),
@@ -127,6 +163,7 @@ func (r *App) doSaveSecret() {
 	}
 
 	var payload model.Payload
+	var card *model.CardPayload
 	switch curSecret.Type {
 	case model.CredsSecret:
 		payload = model.NewCredsPayload(
@@ -149,15 +186,21 @@ func (r *App) doSaveSecret() {
 			payload = model.NewBinaryPayload(fileData)
 		}
 	case model.CardSecret:
-		payload = model.NewCardPayload(
+		card = model.NewCardPayload(
 			r.frmEditUserSecret.GetFormItemByLabel("Card number").(*tview.InputField).GetText(),
 			r.frmEditUserSecret.GetFormItemByLabel("Card holder").(*tview.InputField).GetText(),
 			r.frmEditUserSecret.GetFormItemByLabel("Valid thru").(*tview.InputField).GetText(),
 			r.frmEditUserSecret.GetFormItemByLabel("CVV").(*tview.InputField).GetText(),
 		)
+		payload = card
 	}
 
 	var err error
+	updSecret.ExpiresAt, updSecret.RotateEvery, err = parseExpirationFields(r.frmEditUserSecret, card)
+	if err != nil {
+		r.showError(err.Error(), r.showEditUserSecretPage)
+		return
+	}
 	if payload != nil {
 		var payloadRaw []byte
 		payloadRaw, err = gkMsgp.Serialize(payload.(msgp.Encodable))
@@ -182,6 +225,9 @@ func (r *App) doSaveSecret() {
 			r.showError(_msgSecretOutdated, r.doReloadUserSecrets)
 		case errors.Is(err, transport.ErrSecretPayloadSizeExceeded):
 			r.showError(_msgSecretPayloadSizeExceeded, r.showEditUserSecretPage)
+		case errors.Is(err, transport.ErrQuotaExceeded):
+			r.logger.Errorf("quota exceeded: %v", err)
+			r.showError(_msgQuotaExceeded, r.showEditUserSecretPage)
 		case errors.Is(err, transport.ErrSecretInvalid):
 			r.showError(_msgSecretPayloadSizeExceeded, r.showEditUserSecretPage)
 		}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/audit"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/rivo/tview"
+)
+
+const (
+	_labelExpires     = "Expires"
+	_labelRotateEvery = "Rotate every (days)"
+
+	_expirationWarnDays = 30
+	_placeholderExpires = "YYYY-MM-DD"
+	_placeholderCard    = "from valid thru"
+)
+
+var (
+	errInvalidExpires     = errors.New("invalid expiration date, expected YYYY-MM-DD")
+	errInvalidRotateEvery = errors.New("invalid rotation period, expected number of days")
+)
+
+// addExpirationFields adds expiration fields to form.
+func addExpirationFields(frm *tview.Form) {
+	frm.
+		AddInputField(_labelExpires, "", 0, nil, nil).
+		AddInputField(_labelRotateEvery, "", 0, nil, nil)
+	frm.GetFormItemByLabel(_labelExpires).(*tview.InputField).SetPlaceholder(_placeholderExpires)
+}
+
+// setExpirationFields sets expiration fields of form. If secret is card, expiration is
+// left empty, when it is derived from card valid thru.
+func setExpirationFields(frm *tview.Form, secretType model.SecretType, expiresAt time.Time, rotateEvery time.Duration) {
+	fldExpires := frm.GetFormItemByLabel(_labelExpires).(*tview.InputField)
+	fldExpires.SetPlaceholder(_placeholderExpires)
+	if secretType == model.CardSecret {
+		fldExpires.SetPlaceholder(_placeholderCard)
+	}
+
+	expires := ""
+	if !expiresAt.IsZero() {
+		expires = expiresAt.Local().Format(time.DateOnly)
+	}
+	fldExpires.SetText(expires)
+
+	rotate := ""
+	if rotateEvery > 0 {
+		rotate = strconv.Itoa(int(rotateEvery / (24 * time.Hour)))
+	}
+	frm.GetFormItemByLabel(_labelRotateEvery).(*tview.InputField).SetText(rotate)
+}
+
+// parseExpirationFields returns expiration and rotation period from form.
+//
+// Empty expiration of card defaults to card valid thru, invalid valid thru means no expiration.
+func parseExpirationFields(frm *tview.Form, card *model.CardPayload) (time.Time, time.Duration, error) {
+	var expiresAt time.Time
+	var rotateEvery time.Duration
+
+	expires := strings.TrimSpace(frm.GetFormItemByLabel(_labelExpires).(*tview.InputField).GetText())
+	switch {
+	case expires != "":
+		var err error
+		if expiresAt, err = time.ParseInLocation(time.DateOnly, expires, time.Local); err != nil {
+			return time.Time{}, 0, errInvalidExpires
+		}
+	case card != nil:
+		expiresAt, _ = audit.ParseValidThru(card.ValidThru)
+	}
+
+	rotate := strings.TrimSpace(frm.GetFormItemByLabel(_labelRotateEvery).(*tview.InputField).GetText())
+	if rotate != "" {
+		days, err := strconv.Atoi(rotate)
+		if err != nil || days < 0 {
+			return time.Time{}, 0, errInvalidRotateEvery
+		}
+		rotateEvery = time.Duration(days) * 24 * time.Hour
+	}
+
+	return expiresAt, rotateEvery, nil
+}
+
+// cardExpiresAt returns expiration, which is stored explicitly, or zero time, if it is derived from card valid thru.
+func cardExpiresAt(card *model.CardPayload, expiresAt time.Time) time.Time {
+	if validThru, err := audit.ParseValidThru(card.ValidThru); err == nil && validThru.Equal(expiresAt) {
+		return time.Time{}
+	}
+
+	return expiresAt
+}
+
+// formatDueBadge returns list badge for expired or soon expiring secret and for secret due to rotation.
+//
+// Returns empty string, if secret is not due within warning period.
+func formatDueBadge(info *model.SecretInfo, now time.Time) string {
+	dueAt := info.DueAt()
+	if dueAt.IsZero() {
+		return ""
+	}
+
+	days := int(dueAt.Sub(now).Hours() / 24)
+	if days >= _expirationWarnDays {
+		return ""
+	}
+
+	if !info.ExpiresAt.IsZero() && dueAt.Equal(info.ExpiresAt) {
+		if !now.Before(dueAt) {
+			return "[red]EXPIRED[-]"
+		}
+		return fmt.Sprintf("[yellow]EXPIRES IN %dd[-]", days)
+	}
+
+	if !now.Before(dueAt) {
+		return "[yellow]ROTATE[-]"
+	}
+	return fmt.Sprintf("[yellow]ROTATE IN %dd[-]", days)
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/devldavydov/gophkeeper/internal/client/passgen"
+	"github.com/rivo/tview"
+)
+
+const (
+	_genModeRandom     = "Random characters"
+	_genModePassphrase = "Diceware passphrase"
+)
+
+func (r *App) createGeneratePasswordPage() {
+	r.frmGeneratePassword = tview.NewForm().
+		AddDropDown("Mode", []string{_genModeRandom, _genModePassphrase}, 0, nil).
+		AddInputField("Length", strconv.Itoa(passgen.DefaultLength), 5, tview.InputFieldInteger, nil).
+		AddCheckbox("Lowercase", true, nil).
+		AddCheckbox("Uppercase", true, nil).
+		AddCheckbox("Digits", true, nil).
+		AddCheckbox("Symbols", true, nil).
+		AddCheckbox("Exclude ambiguous", true, nil).
+		AddInputField("Words", strconv.Itoa(passgen.DefaultWords), 5, tview.InputFieldInteger, nil).
+		AddInputField("Separator", passgen.DefaultSeparator, 5, nil, nil).
+		AddInputField("Password", "", 0, nil, nil).
+		AddTextView("Strength", "", 0, 1, true, false).
+		AddButton("Generate", r.doGeneratePassword).
+		AddButton("Use", nil).
+		AddButton("Cancel", nil)
+	r.frmGeneratePassword.
+		SetBorder(true).
+		SetTitle("Generate password")
+
+	r.frmGeneratePassword.GetFormItemByLabel("Password").(*tview.InputField).
+		SetChangedFunc(r.uiPasswordStrengthUpdater(r.frmGeneratePassword))
+
+	r.uiPages.AddPage(_pageGeneratePassword, uiCenteredWidget(r.frmGeneratePassword, 0, 10), true, false)
+}
+
+// showGeneratePassword shows password generator. On "Use" generated password
+// is set to "Password" field of frmTarget and fnBack is called.
+func (r *App) showGeneratePassword(frmTarget *tview.Form, fnBack func()) {
+	r.frmGeneratePassword.GetButton(r.frmGeneratePassword.GetButtonIndex("Use")).SetSelectedFunc(func() {
+		password := r.frmGeneratePassword.GetFormItemByLabel("Password").(*tview.InputField).GetText()
+		if password != "" {
+			frmTarget.GetFormItemByLabel("Password").(*tview.InputField).SetText(password)
+		}
+		fnBack()
+	})
+	r.frmGeneratePassword.GetButton(r.frmGeneratePassword.GetButtonIndex("Cancel")).SetSelectedFunc(fnBack)
+
+	r.doGeneratePassword()
+	r.uiPages.SwitchToPage(_pageGeneratePassword)
+}
+
+func (r *App) doGeneratePassword() {
+	var password string
+	var err error
+
+	_, mode := r.frmGeneratePassword.GetFormItemByLabel("Mode").(*tview.DropDown).GetCurrentOption()
+	switch mode {
+	case _genModePassphrase:
+		words, _ := strconv.Atoi(r.frmGeneratePassword.GetFormItemByLabel("Words").(*tview.InputField).GetText())
+		password, err = passgen.Passphrase(
+			words,
+			r.frmGeneratePassword.GetFormItemByLabel("Separator").(*tview.InputField).GetText(),
+		)
+	default:
+		length, _ := strconv.Atoi(r.frmGeneratePassword.GetFormItemByLabel("Length").(*tview.InputField).GetText())
+		password, err = passgen.Generate(passgen.Options{
+			Length:           length,
+			Lower:            r.frmGeneratePassword.GetFormItemByLabel("Lowercase").(*tview.Checkbox).IsChecked(),
+			Upper:            r.frmGeneratePassword.GetFormItemByLabel("Uppercase").(*tview.Checkbox).IsChecked(),
+			Digits:           r.frmGeneratePassword.GetFormItemByLabel("Digits").(*tview.Checkbox).IsChecked(),
+			Symbols:          r.frmGeneratePassword.GetFormItemByLabel("Symbols").(*tview.Checkbox).IsChecked(),
+			ExcludeAmbiguous: r.frmGeneratePassword.GetFormItemByLabel("Exclude ambiguous").(*tview.Checkbox).IsChecked(),
+		})
+	}
+
+	if err != nil {
+		r.frmGeneratePassword.GetFormItemByLabel("Password").(*tview.InputField).SetText("")
+		r.frmGeneratePassword.GetFormItemByLabel("Strength").(*tview.TextView).
+			SetText(fmt.Sprintf("[red]%v[-]", err))
+		return
+	}
+
+	r.frmGeneratePassword.GetFormItemByLabel("Password").(*tview.InputField).SetText(password)
+}
+
+// uiPasswordStrengthUpdater returns function, that updates "Strength" field of form
+// with strength of given password. "Login" field of form, if exists, is taken into account.
+func (r *App) uiPasswordStrengthUpdater(frm *tview.Form) func(password string) {
+	return func(password string) {
+		wdgStrength, ok := frm.GetFormItemByLabel("Strength").(*tview.TextView)
+		if !ok {
+			return
+		}
+
+		var userInputs []string
+		if wdgLogin, ok := frm.GetFormItemByLabel("Login").(*tview.InputField); ok {
+			userInputs = append(userInputs, wdgLogin.GetText())
+		}
+
+		wdgStrength.SetText(uiStrengthText(passgen.EstimateStrength(password, userInputs...)))
+	}
+}
+
+func uiStrengthText(strength passgen.Strength) string {
+	var color string
+	switch strength.Score {
+	case passgen.ScoreVeryWeak, passgen.ScoreWeak:
+		color = "red"
+	case passgen.ScoreFair:
+		color = "yellow"
+	default:
+		color = "green"
+	}
+
+	return fmt.Sprintf("[%s]%s[-]", color, tview.Escape(strength.String()))
+}
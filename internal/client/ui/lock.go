@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const _idleCheckInterval = time.Second
+
+func (r *App) createLockPage() {
+	r.frmLock = tview.NewForm().
+		AddTextView("User", "", 0, 1, true, false).
+		AddPasswordField("Password", "", 0, '*', nil).
+		AddButton("Unlock", r.doUnlock).
+		AddButton("Logout", r.showLogin)
+	r.frmLock.
+		SetBorder(true).
+		SetTitle("Locked")
+
+	r.uiPages.AddPage(_pageLock, uiCenteredWidget(r.frmLock, 10, 1), true, false)
+}
+
+func (r *App) showLock() {
+	r.frmLock.GetFormItemByLabel("User").(*tview.TextView).SetText(r.wdgUser.GetText(true))
+	r.frmLock.GetFormItemByLabel("Password").(*tview.InputField).SetText("")
+	r.frmLock.SetFocus(r.frmLock.GetFormItemIndex("Password"))
+	r.uiPages.SwitchToPage(_pageLock)
+}
+
+// doLock wipes user token, secrets, audit log and usage from memory and shows lock page.
+// Does nothing, if user is not logged in.
+func (r *App) doLock() {
+	if r.cltToken == "" {
+		return
+	}
+
+	r.stopWatch()
+	r.cltToken = ""
+	r.lstSecrets = nil
+	r.wdgLstSecrets.Clear()
+	r.clearEditUserSecretForm()
+	r.clearCreateUserSecretForm()
+	r.frmGeneratePassword.GetFormItemByLabel("Password").(*tview.InputField).SetText("")
+	r.frmGeneratePassword.GetFormItemByLabel("Strength").(*tview.TextView).SetText("")
+	r.wdgSecurityAudit.SetText("")
+	r.wdgActivity.SetText("")
+	r.wdgUsage.SetText("")
+	r.breachedSecrets = make(map[string]int)
+	r.clip.Close()
+
+	r.logger.Info("client locked")
+	r.showLock()
+}
+
+func (r *App) doUnlock() {
+	userLogin := r.frmLock.GetFormItemByLabel("User").(*tview.TextView).GetText(true)
+	userPassword := r.frmLock.GetFormItemByLabel("Password").(*tview.InputField).GetText()
+
+	token, err := r.tr.UserLogin(userLogin, userPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, transport.ErrInternalServerError):
+			r.showError(_msgInternalServerError, r.showLock)
+		case errors.Is(err, transport.ErrUserNotFound):
+			r.showError(_msgUserNotFound, r.showLogin)
+		case errors.Is(err, transport.ErrUserPermissionDenied):
+			r.showError(_msgUserLoginFailed, r.showLock)
+		}
+		return
+	}
+
+	r.cltToken = token
+	r.doReloadUserSecrets()
+	r.startWatch()
+}
+
+// trackActivity registers user input as activity for idle lock.
+func (r *App) trackActivity() {
+	r.touchActivity()
+	r.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		r.touchActivity()
+		return event
+	})
+	r.app.SetMouseCapture(func(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+		r.touchActivity()
+		return event, action
+	})
+}
+
+func (r *App) touchActivity() {
+	r.lastActivity.Store(time.Now().UnixNano())
+}
+
+// watchIdle locks application, when there is no user activity longer than idle lock timeout.
+func (r *App) watchIdle(done <-chan struct{}) {
+	if r.idleLockTimeout <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(_idleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if time.Since(time.Unix(0, r.lastActivity.Load())) < r.idleLockTimeout {
+				continue
+			}
+			r.logger.Infof("no activity for %s, locking", r.idleLockTimeout)
+			r.touchActivity()
+			r.app.QueueUpdateDraw(r.doLock)
+		}
+	}
+}
+
+// watchLockSignals locks application on terminal hangup or suspend signals.
+func (r *App) watchLockSignals(done <-chan struct{}) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, _lockSignals...)
+	defer signal.Stop(sigCh)
+
+	for {
+		select {
+		case <-done:
+			return
+		case sig := <-sigCh:
+			r.logger.Infof("signal [%s] received, locking", sig)
+
+			locked := make(chan struct{})
+			r.app.QueueUpdateDraw(func() {
+				r.doLock()
+				close(locked)
+			})
+			select {
+			case <-locked:
+			case <-done:
+				return
+			}
+
+			if isSuspendSignal(sig) {
+				r.app.Suspend(func() { suspendProcess(sigCh) })
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"io"
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/client/clipboard"
+	"github.com/devldavydov/gophkeeper/internal/client/transport/mocks"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/golang/mock/gomock"
+	"github.com/rivo/tview"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoLock(t *testing.T) {
+	gmckCtrl := gomock.NewController(t)
+	defer gmckCtrl.Finish()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	clip := clipboard.NewManager(clipboard.NewOSC52Backend(io.Discard), 0, logger)
+	r := NewApp(mocks.NewMockTransport(gmckCtrl), nil, nil, clip, 0, logger)
+	r.uiPages = tview.NewPages()
+	r.createUserSecretsPage()
+	r.createCreateUserSecretPage()
+	r.createEditUserSecretPage()
+	r.createGeneratePasswordPage()
+	r.createSecurityAuditPage()
+	r.createActivityPage()
+	r.createLockPage()
+
+	r.cltToken = "token"
+	r.lstSecrets = []model.SecretInfo{{Type: model.CredsSecret, Name: "bank", Version: 1}}
+	r.breachedSecrets["bank"] = 10
+	r.wdgLstSecrets.AddItem("bank", "", 0, nil)
+	r.wdgSecurityAudit.SetText("bank: weak password")
+	r.wdgActivity.SetText("secret read bank from 127.0.0.1:1234")
+	r.wdgUsage.SetText("1 KB of 1 MB")
+
+	r.doLock()
+
+	assert.Empty(t, r.cltToken)
+	assert.Empty(t, r.lstSecrets)
+	assert.Empty(t, r.breachedSecrets)
+	assert.Equal(t, 0, r.wdgLstSecrets.GetItemCount())
+	assert.Empty(t, r.wdgSecurityAudit.GetText(false))
+	assert.Empty(t, r.wdgActivity.GetText(false))
+	assert.Empty(t, r.wdgUsage.GetText(false))
+
+	name, _ := r.uiPages.GetFrontPage()
+	assert.Equal(t, _pageLock, name)
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+
+package ui
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+//nolint:gochecknoglobals // OK
+var _lockSignals = []os.Signal{syscall.SIGTSTP, syscall.SIGHUP}
+
+func isSuspendSignal(sig os.Signal) bool {
+	return sig == syscall.SIGTSTP
+}
+
+// suspendProcess stops process with default SIGTSTP action and
+// continues to notify sigCh about SIGTSTP after resume.
+func suspendProcess(sigCh chan<- os.Signal) {
+	signal.Reset(syscall.SIGTSTP)
+	_ = syscall.Kill(syscall.Getpid(), syscall.SIGTSTP)
+	signal.Notify(sigCh, syscall.SIGTSTP)
+}
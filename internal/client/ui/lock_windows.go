@@ -0,0 +1,17 @@
+//go:build windows
+
+package ui
+
+import (
+	"os"
+	"syscall"
+)
+
+//nolint:gochecknoglobals // OK
+var _lockSignals = []os.Signal{syscall.SIGHUP}
+
+func isSuspendSignal(_ os.Signal) bool {
+	return false
+}
+
+func suspendProcess(_ chan<- os.Signal) {}
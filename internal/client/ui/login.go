@@ -9,8 +9,17 @@ import (
 )
 
 func (r *App) createLoginPage() {
-	r.frmLogin = tview.NewForm().
-		AddInputField("Login", "", 0, nil, nil).
+	r.frmLogin = tview.NewForm()
+	height := 10
+
+	// Profile selector is shown only if there is something to select
+	if len(r.profiles) > 1 {
+		r.frmLogin.AddDropDown("Profile", r.profileNames(), r.profileIdx, r.doSwitchProfile)
+		height += 2
+	}
+
+	r.frmLogin.
+		AddInputField("Login", r.currentProfile().UserLogin, 0, nil, nil).
 		AddPasswordField("Password", "", 0, '*', nil).
 		AddButton("Login", r.doLogin).
 		AddButton("Create user", r.showCreateUser).
@@ -19,14 +28,21 @@ func (r *App) createLoginPage() {
 		SetBorder(true).
 		SetTitle("Login")
 
-	flex := uiCenteredWidget(r.frmLogin, 10, 1)
+	flex := uiCenteredWidget(r.frmLogin, height, 1)
 	r.uiPages.AddPage(_pageLogin, flex, true, true)
 }
 
 func (r *App) showLogin() {
-	r.frmLogin.GetFormItemByLabel("Login").(*tview.InputField).SetText("")
+	r.stopWatch()
+	r.breachedSecrets = make(map[string]int)
+	userLogin := r.currentProfile().UserLogin
+	r.frmLogin.GetFormItemByLabel("Login").(*tview.InputField).SetText(userLogin)
 	r.frmLogin.GetFormItemByLabel("Password").(*tview.InputField).SetText("")
-	r.frmLogin.SetFocus(r.frmLogin.GetFormItemIndex("Login"))
+	if userLogin == "" {
+		r.frmLogin.SetFocus(r.frmLogin.GetFormItemIndex("Login"))
+	} else {
+		r.frmLogin.SetFocus(r.frmLogin.GetFormItemIndex("Password"))
+	}
 	r.uiPages.SwitchToPage(_pageLogin)
 }
 
@@ -50,4 +66,5 @@ func (r *App) doLogin() {
 	r.cltToken = token
 	r.wdgUser.SetText(userLogin)
 	r.doReloadUserSecrets()
+	r.startWatch()
 }
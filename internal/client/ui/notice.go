@@ -0,0 +1,24 @@
+package ui
+
+import "github.com/rivo/tview"
+
+func (r *App) createNoticePage() {
+	r.frmNotice = tview.NewForm().
+		AddTextView("Info", "", 0, 1, true, true).
+		AddButton("Ok", nil)
+	r.frmNotice.
+		SetBorder(true).
+		SetTitle("Notice")
+
+	flex := uiCenteredWidget(r.frmNotice, 10, 1)
+	r.uiPages.AddPage(_pageNotice, flex, true, false)
+}
+
+func (r *App) showNotice(msg string, fnCallback func()) {
+	r.frmNotice.GetFormItemByLabel("Info").(*tview.TextView).SetText(msg)
+	r.frmNotice.SetFocus(r.frmNotice.GetFormItemIndex("Info"))
+
+	btnBackInd := r.frmNotice.GetButtonIndex("Ok")
+	r.frmNotice.GetButton(btnBackInd).SetSelectedFunc(fnCallback)
+	r.uiPages.SwitchToPage(_pageNotice)
+}
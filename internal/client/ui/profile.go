@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/rivo/tview"
+)
+
+// Profile represents server profile, available for selection on login page.
+type Profile struct {
+	// Name - profile name.
+	Name string
+	// UserLogin - default user login, filled on login page.
+	UserLogin string
+	// Connect - creates transport with profile server.
+	Connect func() (transport.Transport, error)
+}
+
+func (r *App) profileNames() []string {
+	names := make([]string, 0, len(r.profiles))
+	for _, p := range r.profiles {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// currentProfile returns selected profile, empty profile if there are no profiles.
+func (r *App) currentProfile() Profile {
+	if r.profileIdx >= len(r.profiles) {
+		return Profile{}
+	}
+	return r.profiles[r.profileIdx]
+}
+
+// doSwitchProfile connects with server of selected profile and replaces transport.
+// On connection error previous profile is kept.
+func (r *App) doSwitchProfile(_ string, idx int) {
+	if idx == r.profileIdx || idx < 0 || idx >= len(r.profiles) {
+		return
+	}
+
+	tr, err := r.profiles[idx].Connect()
+	if err != nil {
+		r.logger.Errorf("profile [%s] connect error: %v", r.profiles[idx].Name, err)
+		r.frmLogin.GetFormItemByLabel("Profile").(*tview.DropDown).SetCurrentOption(r.profileIdx)
+		r.showError(_msgProfileConnectFailed, r.showLogin)
+		return
+	}
+
+	if closer, ok := r.tr.(io.Closer); ok {
+		if err = closer.Close(); err != nil {
+			r.logger.Errorf("profile [%s] close error: %v", r.currentProfile().Name, err)
+		}
+	}
+
+	r.tr = tr
+	r.profileIdx = idx
+	r.cltToken = ""
+	r.logger.Infof("switched to profile [%s]", r.currentProfile().Name)
+
+	r.frmLogin.GetFormItemByLabel("Login").(*tview.InputField).SetText(r.currentProfile().UserLogin)
+	r.wdgLstSecrets.SetTitle(r.secretsTitle())
+}
+
+func (r *App) secretsTitle() string {
+	if len(r.profiles) < 2 {
+		return "Secrets"
+	}
+	return fmt.Sprintf("Secrets [%s]", tview.Escape(r.currentProfile().Name))
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/rivo/tview"
+)
+
+const (
+	_maskCharacter = '*'
+	_revealTimeout = 15 * time.Second
+)
+
+// uiSensitiveField describes secret form field, masked by default.
+type uiSensitiveField struct {
+	label string
+	name  string
+}
+
+func uiSensitiveFields(secretType model.SecretType) []uiSensitiveField {
+	switch secretType {
+	case model.CredsSecret:
+		return []uiSensitiveField{{label: "Password", name: "password"}}
+	case model.CardSecret:
+		return []uiSensitiveField{
+			{label: "Card number", name: "number"},
+			{label: "CVV", name: "CVV"},
+		}
+	default:
+		return nil
+	}
+}
+
+// addRevealButtons masks sensitive fields of form and adds reveal toggle button for every field.
+// Revealed field is masked again after timeout.
+func (r *App) addRevealButtons(frm *tview.Form, secretType model.SecretType) {
+	for _, field := range uiSensitiveFields(secretType) {
+		wdgField, ok := frm.GetFormItemByLabel(field.label).(*tview.InputField)
+		if !ok {
+			continue
+		}
+		wdgField.SetMaskCharacter(_maskCharacter)
+
+		revealLabel, hideLabel := "Reveal "+field.name, "Hide "+field.name
+		frm.AddButton(revealLabel, nil)
+		btn := frm.GetButton(frm.GetButtonCount() - 1)
+
+		var timer *time.Timer
+		fnMask := func() {
+			if timer != nil {
+				timer.Stop()
+				timer = nil
+			}
+			wdgField.SetMaskCharacter(_maskCharacter)
+			btn.SetLabel(revealLabel)
+		}
+
+		btn.SetSelectedFunc(func() {
+			if timer != nil {
+				fnMask()
+				return
+			}
+
+			wdgField.SetMaskCharacter(0)
+			btn.SetLabel(hideLabel)
+
+			var revealTimer *time.Timer
+			revealTimer = time.AfterFunc(_revealTimeout, func() {
+				r.app.QueueUpdateDraw(func() {
+					// Field could be masked and revealed again meanwhile
+					if timer == revealTimer {
+						fnMask()
+					}
+				})
+			})
+			timer = revealTimer
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/devldavydov/gophkeeper/internal/client/audit"
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/rivo/tview"
+)
+
+func (r *App) createSecurityAuditPage() {
+	r.wdgSecurityAudit = tview.NewTextView().
+		SetDynamicColors(true).
+		SetScrollable(true).
+		SetWordWrap(true)
+	r.wdgSecurityAudit.SetBorder(true).SetTitle("Security audit")
+
+	flexAudit := tview.NewFlex().SetDirection(tview.FlexRow)
+	flexAudit.AddItem(r.wdgSecurityAudit, 0, 1, true)
+	flexAudit.AddItem(tview.NewForm().AddButton("Back", r.doReloadUserSecrets), 3, 1, false)
+
+	r.uiPages.AddPage(_pageSecurityAudit, uiCenteredWidget(flexAudit, 0, 10), true, false)
+}
+
+func (r *App) doSecurityAudit() {
+	report, err := audit.Run(r.tr, r.cltToken, audit.DefaultOptions())
+	if err != nil {
+		r.logger.Errorf("security audit error: %v", err)
+		switch {
+		case errors.Is(err, transport.ErrInternalServerError):
+			r.showError(_msgInternalServerError, r.doReloadUserSecrets)
+		case errors.Is(err, transport.ErrUserPermissionDenied):
+			r.showError(_msgUserNotFound, r.showLogin)
+		case errors.Is(err, transport.ErrSecretInvalid):
+			r.showError(_msgSecretInvalid, r.doReloadUserSecrets)
+		default:
+			r.showError(_msgClientError, r.doReloadUserSecrets)
+		}
+		return
+	}
+
+	r.wdgSecurityAudit.SetText(uiAuditReportText(report)).ScrollToBeginning()
+	r.uiPages.SwitchToPage(_pageSecurityAudit)
+}
+
+func uiAuditReportText(report *audit.Report) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Checked secrets: %d\n", report.Checked)
+	if len(report.Issues) == 0 {
+		sb.WriteString("[green]No issues found[-]\n")
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "[red]Issues found: %d[-]\n\n", len(report.Issues))
+	for _, issue := range report.Issues {
+		fmt.Fprintf(&sb, "[yellow]%s[-] %s: %s\n",
+			tview.Escape(issue.Secret),
+			uiAuditIssueTitle(issue.Type),
+			tview.Escape(issue.Details))
+	}
+
+	return sb.String()
+}
+
+func uiAuditIssueTitle(issueType audit.IssueType) string {
+	switch issueType {
+	case audit.IssueReusedPassword:
+		return "reused password"
+	case audit.IssueWeakPassword:
+		return "weak password"
+	case audit.IssueOldPassword:
+		return "old password"
+	case audit.IssueCardExpired:
+		return "card expired"
+	case audit.IssueCardExpiring:
+		return "card expiring soon"
+	case audit.IssueCardInvalidExpiry:
+		return "invalid card expiry"
+	default:
+		return string(issueType)
+	}
+}
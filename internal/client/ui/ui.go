@@ -4,6 +4,12 @@
 package ui
 
 import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/breach"
+	"github.com/devldavydov/gophkeeper/internal/client/clipboard"
 	"github.com/devldavydov/gophkeeper/internal/client/transport"
 	"github.com/devldavydov/gophkeeper/internal/common/model"
 	"github.com/rivo/tview"
@@ -16,7 +22,12 @@ const (
 	_pageUserSecrets      = "user secrets"
 	_pageCreateUserSecret = "create user secret"
 	_pageEditUserSecret   = "edit user secret"
+	_pageGeneratePassword = "generate password"
+	_pageSecurityAudit    = "security audit"
+	_pageActivity         = "activity"
+	_pageLock             = "lock"
 	_pageError            = "error"
+	_pageNotice           = "notice"
 
 	_msgInternalServerError       = "Internal server error"
 	_msgClientError               = "Internal client error"
@@ -29,6 +40,12 @@ const (
 	_msgSecretOutdated            = "Secret outdated. It was changed in another session."
 	_msgSecretInvalid             = "Secret invalid"
 	_msgSecretPayloadSizeExceeded = "Secret payload too big"
+	_msgQuotaExceeded             = "Storage quota exceeded"
+	_msgBreachCheckNotConfigured  = "Breach check source not configured"
+	_msgBreachCheckFailed         = "Breach check failed"
+	_msgSecretChangedWarning      = "changed in another session, reopen to get latest version"
+	_msgSecretDeletedWarning      = "deleted in another session"
+	_msgProfileConnectFailed      = "Failed to connect with profile server"
 )
 
 // App represents user interface application.
@@ -41,14 +58,32 @@ const (
 //
 // - edit sercet.
 //
+// - generate password.
+//
+// - security audit.
+//
+// - activity.
+//
+// - lock.
+//
 // - user login.
 //
 // - secrets list.
 type App struct {
-	cltToken   string
-	tr         transport.Transport
-	lstSecrets []model.SecretInfo
-	logger     *logrus.Logger
+	cltToken    string
+	tr          transport.Transport
+	profiles    []Profile
+	profileIdx  int
+	lstSecrets  []model.SecretInfo
+	editSecret  model.SecretInfo
+	watchCancel context.CancelFunc
+	logger      *logrus.Logger
+	//
+	breachLookup    breach.Lookup
+	breachedSecrets map[string]int
+	clip            *clipboard.Manager
+	idleLockTimeout time.Duration
+	lastActivity    atomic.Int64
 	//
 	app                 *tview.Application
 	uiPages             *tview.Pages
@@ -56,14 +91,41 @@ type App struct {
 	frmCreateUser       *tview.Form
 	frmCreateUserSecret *tview.Form
 	frmEditUserSecret   *tview.Form
+	frmGeneratePassword *tview.Form
 	frmError            *tview.Form
+	frmNotice           *tview.Form
+	frmLock             *tview.Form
 	wdgLstSecrets       *tview.List
 	wdgUser             *tview.TextView
+	wdgUsage            *tview.TextView
+	wdgSecurityAudit    *tview.TextView
+	wdgActivity         *tview.TextView
+	ddActivityPeriod    *tview.DropDown
 }
 
 // NewApp creates instance of App.
-func NewApp(tr transport.Transport, logger *logrus.Logger) *App {
-	return &App{tr: tr, app: tview.NewApplication(), logger: logger}
+//
+// tr is a transport with server of first profile. Profiles could be switched on login page.
+// If breachLookup is nil, breach check is disabled.
+// If idleLockTimeout is zero, application is not locked on inactivity.
+func NewApp(
+	tr transport.Transport,
+	profiles []Profile,
+	breachLookup breach.Lookup,
+	clip *clipboard.Manager,
+	idleLockTimeout time.Duration,
+	logger *logrus.Logger,
+) *App {
+	return &App{
+		tr:              tr,
+		profiles:        profiles,
+		breachLookup:    breachLookup,
+		breachedSecrets: make(map[string]int),
+		clip:            clip,
+		idleLockTimeout: idleLockTimeout,
+		app:             tview.NewApplication(),
+		logger:          logger,
+	}
 }
 
 // Run starts UI application.
@@ -75,13 +137,27 @@ func (r *App) Run() error {
 	r.createUserSecretsPage()
 	r.createCreateUserSecretPage()
 	r.createEditUserSecretPage()
+	r.createGeneratePasswordPage()
+	r.createSecurityAuditPage()
+	r.createActivityPage()
+	r.createLockPage()
 	r.createErrorPage()
+	r.createNoticePage()
 
 	r.app.
 		SetRoot(r.uiPages, true).
 		SetFocus(r.uiPages).
 		EnableMouse(true)
 
+	// Lock on inactivity and signals
+	done := make(chan struct{})
+	defer close(done)
+	defer r.stopWatch()
+
+	r.trackActivity()
+	go r.watchIdle(done)
+	go r.watchLockSignals(done)
+
 	return r.app.Run()
 }
 
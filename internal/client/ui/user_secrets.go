@@ -2,48 +2,186 @@ package ui
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/devldavydov/gophkeeper/internal/client/breach"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
 	"github.com/rivo/tview"
 )
 
+const (
+	_usageBarWidth    = 20
+	_usageWarnRatio   = 0.7
+	_usageAlertRatio  = 0.9
+	_usageUnavailable = "unavailable"
+)
+
 func (r *App) createUserSecretsPage() {
 	r.wdgLstSecrets = tview.NewList().ShowSecondaryText(false)
-	r.wdgLstSecrets.SetBorder(true).SetTitle("Secrets")
+	r.wdgLstSecrets.SetBorder(true).SetTitle(r.secretsTitle())
 
 	flexSecrets := tview.NewFlex().SetDirection(tview.FlexRow)
 	flexSecrets.AddItem(r.wdgLstSecrets, 0, 1, true)
 
 	formActions := tview.NewForm().
 		AddTextView("User", "", 30, 1, false, false).
+		AddTextView("Usage", "", 0, 1, true, false).
 		AddButton("Create secret", r.showCreateUserSecretCleared).
 		AddButton("Reload", r.doReloadUserSecrets).
+		AddButton("Audit", r.doSecurityAudit).
+		AddButton("Activity", r.doActivity).
+		AddButton("Breach check", r.doBreachCheck).
 		AddButton("Logout", r.showLogin)
 	r.wdgUser, _ = formActions.GetFormItemByLabel("User").(*tview.TextView)
+	r.wdgUsage, _ = formActions.GetFormItemByLabel("Usage").(*tview.TextView)
 
-	flexSecrets.AddItem(formActions, 5, 1, false)
+	flexSecrets.AddItem(formActions, 7, 1, false)
 
 	r.uiPages.AddPage(_pageUserSecrets, uiCenteredWidget(flexSecrets, 0, 10), true, false)
 }
 
 func (r *App) doReloadUserSecrets() {
-	r.wdgLstSecrets.Clear()
+	if err := r.loadUserSecrets(); err != nil {
+		r.wdgLstSecrets.Clear()
+		r.showError(_msgInternalServerError, r.showCreateUser)
+		return
+	}
+
+	r.uiPages.SwitchToPage(_pageUserSecrets)
+}
+
+// refreshUserSecrets reloads secrets list without switching page.
+func (r *App) refreshUserSecrets() {
+	if r.cltToken == "" {
+		return
+	}
+
+	if err := r.loadUserSecrets(); err != nil {
+		r.logger.Errorf("secrets list refresh error: %v", err)
+	}
+}
 
-	// Load secrets from server
+// loadUserSecrets loads secrets from server to list, keeping selected secret.
+func (r *App) loadUserSecrets() error {
 	lstSecrets, err := r.tr.SecretGetList(r.cltToken)
 	if err != nil {
-		r.showError(_msgInternalServerError, r.showCreateUser)
-		return
+		return err
+	}
+
+	var selected string
+	if cur := r.wdgLstSecrets.GetCurrentItem(); cur >= 0 && cur < len(r.lstSecrets) {
+		selected = r.lstSecrets[cur].Name
 	}
 
-	// Store internal
+	// Store internal, most urgent first
+	model.SortByUrgency(lstSecrets)
+	r.wdgLstSecrets.Clear()
 	r.lstSecrets = lstSecrets
-	for _, scrt := range r.lstSecrets {
+	now := time.Now()
+	for i, scrt := range r.lstSecrets {
+		title := fmt.Sprintf("%s (%s)", scrt.Name, scrt.Type)
+		if _, ok := r.breachedSecrets[scrt.Name]; ok {
+			title += " [red]BREACHED[-]"
+		}
+		if badge := formatDueBadge(&r.lstSecrets[i], now); badge != "" {
+			title += " " + badge
+		}
+
 		r.wdgLstSecrets.AddItem(
-			fmt.Sprintf("%s (%s)", scrt.Name, scrt.Type),
+			title,
 			"",
 			0,
 			r.doEditUserSecret)
+		if scrt.Name == selected {
+			r.wdgLstSecrets.SetCurrentItem(i)
+		}
 	}
 
-	r.uiPages.SwitchToPage(_pageUserSecrets)
+	r.loadUserUsage()
+
+	return nil
+}
+
+// loadUserUsage loads user's storage usage to usage bar. Usage is informational, so errors are only logged.
+func (r *App) loadUserUsage() {
+	usage, err := r.tr.UserGetUsage(r.cltToken)
+	if err != nil {
+		r.logger.Errorf("usage load error: %v", err)
+		r.wdgUsage.SetText(_usageUnavailable)
+		return
+	}
+
+	r.wdgUsage.SetText(formatUsage(usage))
+}
+
+// formatUsage returns usage bar of most used quota resource with usage totals.
+func formatUsage(usage *model.Usage) string {
+	ratio := usage.BytesRatio()
+	if secretsRatio := usage.SecretsRatio(); secretsRatio > ratio {
+		ratio = secretsRatio
+	}
+
+	color := "green"
+	switch {
+	case ratio >= _usageAlertRatio:
+		color = "red"
+	case ratio >= _usageWarnRatio:
+		color = "yellow"
+	}
+
+	filled := int(ratio * _usageBarWidth)
+	bar := fmt.Sprintf("[%s]%s[-]%s",
+		color, strings.Repeat("■", filled), strings.Repeat("□", _usageBarWidth-filled))
+
+	return fmt.Sprintf("%s %s of %s, %d of %s secrets",
+		bar,
+		formatBytes(usage.Bytes), formatLimit(usage.MaxBytes, formatBytes),
+		usage.Secrets, formatLimit(usage.MaxSecrets, func(v int64) string { return fmt.Sprint(v) }))
+}
+
+// formatLimit returns quota limit with given format, zero limit is unlimited.
+func formatLimit(limit int64, fnFormat func(int64) string) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+
+	return fnFormat(limit)
+}
+
+// formatBytes returns size in binary units.
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func (r *App) doBreachCheck() {
+	if r.breachLookup == nil {
+		r.showError(_msgBreachCheckNotConfigured, r.doReloadUserSecrets)
+		return
+	}
+
+	results, err := breach.CheckVault(r.tr, r.cltToken, r.breachLookup)
+	if err != nil {
+		r.logger.Errorf("breach check error: %v", err)
+		r.showError(_msgBreachCheckFailed, r.doReloadUserSecrets)
+		return
+	}
+
+	r.breachedSecrets = make(map[string]int, len(results))
+	for _, res := range results {
+		r.breachedSecrets[res.Secret] = res.Count
+	}
+
+	r.doReloadUserSecrets()
 }
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+const _watchRetryInterval = 5 * time.Second
+
+// startWatch starts watching secret changes of logged in user.
+func (r *App) startWatch() {
+	r.stopWatch()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.watchCancel = cancel
+	go r.watchSecrets(ctx, r.tr, r.cltToken)
+}
+
+// stopWatch stops watching secret changes. Events, already queued to UI, are ignored.
+func (r *App) stopWatch() {
+	if r.watchCancel != nil {
+		r.watchCancel()
+		r.watchCancel = nil
+	}
+}
+
+// watchSecrets watches secret changes and reconnects on errors till context canceled.
+// Transport is passed from UI goroutine, because it is replaced on profile switch.
+func (r *App) watchSecrets(ctx context.Context, tr transport.Transport, token string) {
+	fnQueue := func(f func()) {
+		r.app.QueueUpdateDraw(func() {
+			if ctx.Err() == nil {
+				f()
+			}
+		})
+	}
+
+	for {
+		err := tr.SecretWatch(
+			ctx,
+			token,
+			// Events could be missed, while watch was not established
+			func() { fnQueue(r.refreshUserSecrets) },
+			func(event model.SecretEvent) { fnQueue(func() { r.onSecretEvent(event) }) },
+		)
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, transport.ErrUserPermissionDenied) {
+			r.logger.Errorf("secret watch stopped: %v", err)
+			return
+		}
+
+		r.logger.Errorf("secret watch error: %v, retry in %s", err, _watchRetryInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(_watchRetryInterval):
+		}
+	}
+}
+
+// onSecretEvent refreshes secrets list and warns, if edited secret was changed.
+func (r *App) onSecretEvent(event model.SecretEvent) {
+	r.logger.Infof("secret [%s] %s", event.Name, event.EventType)
+	r.refreshUserSecrets()
+
+	if event.Name != r.editSecret.Name {
+		return
+	}
+
+	var warning string
+	switch {
+	case event.EventType == model.SecretDeleted:
+		warning = _msgSecretDeletedWarning
+	case event.Version > r.editSecret.Version:
+		warning = _msgSecretChangedWarning
+	default:
+		return
+	}
+
+	r.frmEditUserSecret.SetTitle(fmt.Sprintf("%s [red](%s)[-]", uiCopyFieldsTitle("Edit secret", r.editSecret.Type), warning))
+}
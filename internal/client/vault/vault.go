@@ -0,0 +1,67 @@
+// Package vault contains helpers to process user's secrets on client side.
+package vault
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+// Filter decides by secret list item, whether secret should be retrieved from server.
+type Filter func(model.SecretInfo) bool
+
+// ByType returns Filter, accepting secrets of given types.
+func ByType(types ...model.SecretType) Filter {
+	return func(secretInfo model.SecretInfo) bool {
+		for _, secretType := range types {
+			if secretInfo.Type == secretType {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ForEachSecret reads user's secret list from server and calls fn for every secret, accepted by filter.
+// Nil filter accepts all secrets. Secrets, not accepted by filter, are not retrieved from server.
+//
+// Returns transport error, payload decode error or error returned by fn.
+func ForEachSecret(
+	tr transport.Transport,
+	token string,
+	filter Filter,
+	fn func(model.SecretInfo, *model.Secret, model.Payload) error,
+) error {
+	lstSecrets, err := tr.SecretGetList(token)
+	if err != nil {
+		return err
+	}
+
+	for _, secretInfo := range lstSecrets {
+		if filter != nil && !filter(secretInfo) {
+			continue
+		}
+
+		secret, err := tr.SecretGet(token, secretInfo.Name)
+		if err != nil {
+			// Secret was deleted in another session after list was retrieved
+			if errors.Is(err, transport.ErrSecretNotFound) {
+				continue
+			}
+			return err
+		}
+
+		payload, err := secret.GetPayload()
+		if err != nil {
+			return fmt.Errorf("secret [%s]: %w", secret.Name, err)
+		}
+
+		if err = fn(secretInfo, secret, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/client/transport"
+	"github.com/devldavydov/gophkeeper/internal/client/transport/mocks"
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+)
+
+const _testToken = "token"
+
+func TestForEachSecret(t *testing.T) {
+	gmckCtrl := gomock.NewController(t)
+	defer gmckCtrl.Finish()
+	trMock := mocks.NewMockTransport(gmckCtrl)
+
+	trMock.EXPECT().SecretGetList(_testToken).Return([]model.SecretInfo{
+		{Type: model.CredsSecret, Name: "creds", Version: 1},
+		{Type: model.CredsSecret, Name: "deleted", Version: 1},
+		{Type: model.TextSecret, Name: "text", Version: 1},
+	}, nil)
+	trMock.EXPECT().
+		SecretGet(_testToken, "creds").
+		Return(newSecret(t, model.CredsSecret, "creds", model.NewCredsPayload("john", "pwd")), nil)
+	trMock.EXPECT().SecretGet(_testToken, "deleted").Return(nil, transport.ErrSecretNotFound)
+
+	var names []string
+	err := ForEachSecret(trMock, _testToken, ByType(model.CredsSecret),
+		func(secretInfo model.SecretInfo, secret *model.Secret, payload model.Payload) error {
+			assert.Equal(t, secretInfo.Name, secret.Name)
+			assert.Equal(t, model.NewCredsPayload("john", "pwd"), payload)
+			names = append(names, secret.Name)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"creds"}, names)
+}
+
+func TestForEachSecretError(t *testing.T) {
+	gmckCtrl := gomock.NewController(t)
+	defer gmckCtrl.Finish()
+	trMock := mocks.NewMockTransport(gmckCtrl)
+
+	fnErr := errors.New("fn error")
+	fn := func(model.SecretInfo, *model.Secret, model.Payload) error { return fnErr }
+
+	t.Run("list error", func(t *testing.T) {
+		trMock.EXPECT().SecretGetList(_testToken).Return(nil, transport.ErrInternalServerError)
+		assert.ErrorIs(t, ForEachSecret(trMock, _testToken, nil, fn), transport.ErrInternalServerError)
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		trMock.EXPECT().SecretGetList(_testToken).Return([]model.SecretInfo{{Type: model.TextSecret, Name: "foo"}}, nil)
+		trMock.EXPECT().SecretGet(_testToken, "foo").Return(nil, transport.ErrInternalServerError)
+		assert.ErrorIs(t, ForEachSecret(trMock, _testToken, nil, fn), transport.ErrInternalServerError)
+	})
+
+	t.Run("fn error", func(t *testing.T) {
+		trMock.EXPECT().SecretGetList(_testToken).Return([]model.SecretInfo{{Type: model.TextSecret, Name: "foo"}}, nil)
+		trMock.EXPECT().
+			SecretGet(_testToken, "foo").
+			Return(newSecret(t, model.TextSecret, "foo", model.NewTextPayload("bar")), nil)
+		assert.ErrorIs(t, ForEachSecret(trMock, _testToken, nil, fn), fnErr)
+	})
+}
+
+func newSecret(t *testing.T, secretType model.SecretType, name string, payload msgp.Encodable) *model.Secret {
+	t.Helper()
+
+	payloadRaw, err := gkMsgp.Serialize(payload)
+	require.NoError(t, err)
+
+	return &model.Secret{Type: secretType, Name: name, Version: 1, PayloadRaw: payloadRaw}
+}
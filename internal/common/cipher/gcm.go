@@ -0,0 +1,95 @@
+package cipher
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+var ErrAuthenticationFailed = errors.New("cipher data authentication failed")
+
+const (
+	// SaltLength is a length of salt for passphrase key derivation.
+	SaltLength = 16
+
+	_scryptN = 1 << 15
+	_scryptR = 8
+	_scryptP = 1
+)
+
+// NewSalt generates random salt for KeyFromPassphrase.
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, SaltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	return salt, nil
+}
+
+// KeyFromPassphrase derives AES key from user passphrase and salt with scrypt.
+//
+// Returns key of AESKeyLength bytes or error.
+func KeyFromPassphrase(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, _scryptN, _scryptR, _scryptP, AESKeyLength)
+}
+
+// AESGCMEncrypt encrypts and authenticates plainData with secretKey.
+//
+// Accepts plain data and key. Returns nonce followed by encrypted data or error.
+func AESGCMEncrypt(plainData []byte, secretKey []byte) ([]byte, error) {
+	aead, err := newGCM(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plainData)+aead.Overhead())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plainData, nil), nil
+}
+
+// AESGCMDecrypt decrypts cipherData with secretKey and verifies its integrity.
+//
+// Accepts encrypted data and key. Returns plain data or error:
+//
+// - ErrCipherDataTooShort - cipher data shorter than nonce.
+//
+// - ErrAuthenticationFailed - wrong key or data was modified.
+func AESGCMDecrypt(cipherData []byte, secretKey []byte) ([]byte, error) {
+	aead, err := newGCM(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cipherData) < aead.NonceSize() {
+		return nil, ErrCipherDataTooShort
+	}
+
+	nonce, cipherData := cipherData[:aead.NonceSize()], cipherData[aead.NonceSize():]
+	plainData, err := aead.Open(nil, nonce, cipherData, nil)
+	if err != nil {
+		return nil, ErrAuthenticationFailed
+	}
+
+	return plainData, nil
+}
+
+func newGCM(secretKey []byte) (cipher.AEAD, error) {
+	if len(secretKey) != AESKeyLength {
+		return nil, ErrWrongKeyLength
+	}
+
+	block, err := aes.NewCipher(secretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,48 @@
+package cipher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAESGCM(t *testing.T) {
+	salt, err := NewSalt()
+	require.NoError(t, err)
+	assert.Len(t, salt, SaltLength)
+
+	key, err := KeyFromPassphrase("correct horse battery staple", salt)
+	require.NoError(t, err)
+	assert.Len(t, key, AESKeyLength)
+
+	plainData := []byte("Hello world!!!")
+
+	cipherData, err := AESGCMEncrypt(plainData, key)
+	assert.NoError(t, err)
+
+	decrData, err := AESGCMDecrypt(cipherData, key)
+	assert.NoError(t, err)
+	assert.Equal(t, plainData, decrData)
+
+	otherKey, err := KeyFromPassphrase("wrong passphrase", salt)
+	require.NoError(t, err)
+
+	_, err = AESGCMDecrypt(cipherData, otherKey)
+	assert.ErrorIs(t, err, ErrAuthenticationFailed)
+
+	cipherData[len(cipherData)-1] ^= 0xff
+	_, err = AESGCMDecrypt(cipherData, key)
+	assert.ErrorIs(t, err, ErrAuthenticationFailed)
+}
+
+func TestAESGCMErrors(t *testing.T) {
+	_, err := AESGCMEncrypt([]byte("test"), []byte("test"))
+	assert.ErrorIs(t, err, ErrWrongKeyLength)
+
+	_, err = AESGCMDecrypt([]byte("test"), []byte("test"))
+	assert.ErrorIs(t, err, ErrWrongKeyLength)
+
+	_, err = AESGCMDecrypt([]byte("test"), []byte("asuperstrong32bitpasswordgohere!"))
+	assert.ErrorIs(t, err, ErrCipherDataTooShort)
+}
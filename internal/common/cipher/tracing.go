@@ -0,0 +1,35 @@
+package cipher
+
+import (
+	"context"
+
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const _tracerName = "github.com/devldavydov/gophkeeper/internal/common/cipher"
+
+// AESEncryptContext is AESEncrpyt, traced as a span of ctx.
+func AESEncryptContext(ctx context.Context, plainData []byte, secretKey []byte) ([]byte, error) {
+	span := startSpan(ctx, "cipher.AESEncrypt", len(plainData))
+	cipherData, err := AESEncrpyt(plainData, secretKey)
+	tracing.EndSpan(span, err)
+
+	return cipherData, err
+}
+
+// AESDecryptContext is AESDecrypt, traced as a span of ctx.
+func AESDecryptContext(ctx context.Context, cipherData []byte, secretKey []byte) ([]byte, error) {
+	span := startSpan(ctx, "cipher.AESDecrypt", len(cipherData))
+	plainData, err := AESDecrypt(cipherData, secretKey)
+	tracing.EndSpan(span, err)
+
+	return plainData, err
+}
+
+func startSpan(ctx context.Context, name string, dataSize int) trace.Span {
+	_, span := otel.Tracer(_tracerName).Start(ctx, name, trace.WithAttributes(attribute.Int("cipher.data_size", dataSize)))
+	return span
+}
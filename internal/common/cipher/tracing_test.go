@@ -0,0 +1,51 @@
+package cipher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestAESContext(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+
+	key := []byte("asuperstrong32bitpasswordgohere!")
+	plainData := []byte("Hello world!!!")
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	cipherData, err := AESEncryptContext(ctx, plainData, key)
+	require.NoError(t, err)
+	decrData, err := AESDecryptContext(ctx, cipherData, key)
+	require.NoError(t, err)
+	_, err = AESDecryptContext(ctx, cipherData, []byte("test"))
+	assert.ErrorIs(t, err, ErrWrongKeyLength)
+	parent.End()
+
+	assert.Equal(t, plainData, decrData)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 4)
+
+	for i, tt := range []struct {
+		name string
+		size int
+		code codes.Code
+	}{
+		{name: "cipher.AESEncrypt", size: len(plainData), code: codes.Unset},
+		{name: "cipher.AESDecrypt", size: len(cipherData), code: codes.Unset},
+		{name: "cipher.AESDecrypt", size: len(cipherData), code: codes.Error},
+	} {
+		assert.Equal(t, tt.name, spans[i].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[i].Parent().SpanID())
+		assert.Contains(t, spans[i].Attributes(), attribute.Int("cipher.data_size", tt.size))
+		assert.Equal(t, tt.code, spans[i].Status().Code)
+	}
+}
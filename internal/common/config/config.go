@@ -0,0 +1,118 @@
+// Package config contains loading of layered configuration with precedence: defaults < file < env < flags.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/caarlos0/env/v7"
+	"gopkg.in/yaml.v3"
+)
+
+// FieldError represents error of configuration field.
+type FieldError struct {
+	// Field - name of configuration field as in config file.
+	Field string
+	// Err - field error.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Load loads configuration to cfg with precedence: defaults < file < env < flags.
+//
+// flagSet should be bound to cfg fields with default values, env variables are defined by `env` tags.
+// Config file path is taken from configPath after flags and env parsing. If loadFile is nil and path
+// is not empty, file is decoded with LoadFile. Custom loadFile is called even with empty path,
+// to load settings of file layer from other sources.
+func Load(
+	flagSet *flag.FlagSet,
+	args []string,
+	cfg interface{},
+	configPath *string,
+	loadFile func(path string) error,
+) error {
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	explicitFlags := make(map[string]string)
+	flagSet.Visit(func(f *flag.Flag) {
+		explicitFlags[f.Name] = f.Value.String()
+	})
+
+	fnEnvAndFlags := func() error {
+		if err := env.Parse(cfg); err != nil {
+			return err
+		}
+
+		// Flags, set in command line, take precedence over env
+		for name, value := range explicitFlags {
+			if err := flagSet.Set(name, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if err := fnEnvAndFlags(); err != nil {
+		return err
+	}
+
+	if loadFile == nil {
+		if *configPath == "" {
+			return nil
+		}
+		loadFile = func(path string) error { return LoadFile(path, cfg) }
+	}
+	if err := loadFile(*configPath); err != nil {
+		return err
+	}
+
+	return fnEnvAndFlags()
+}
+
+// LoadFile decodes YAML config file to cfg, keys are defined by `yaml` tags. Unknown keys are error.
+func LoadFile(path string, cfg interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err = dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// ReadSecretFile sets value to content of secret file, if path is not empty. Trailing newline is trimmed.
+//
+// Returns FieldError with field name in case of read error.
+func ReadSecretFile(value *string, path, field string) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return &FieldError{Field: field, Err: err}
+	}
+
+	*value = strings.TrimRight(string(data), "\r\n")
+	return nil
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	ConfigFile string        `env:"TEST_CONFIG" yaml:"-"`
+	Address    string        `env:"TEST_ADDRESS" yaml:"address"`
+	Level      string        `env:"TEST_LEVEL" yaml:"level"`
+	Timeout    time.Duration `env:"TEST_TIMEOUT" yaml:"timeout"`
+	Debug      bool          `env:"TEST_DEBUG" yaml:"debug"`
+}
+
+func newTestFlagSet(cfg *testConfig) *flag.FlagSet {
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.StringVar(&cfg.ConfigFile, "config", "", "")
+	flagSet.StringVar(&cfg.Address, "a", "default:8080", "")
+	flagSet.StringVar(&cfg.Level, "l", "INFO", "")
+	flagSet.DurationVar(&cfg.Timeout, "t", time.Second, "")
+	flagSet.BoolVar(&cfg.Debug, "debug", false, "")
+	return flagSet
+}
+
+func writeTestFile(t *testing.T, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeTestFile(t, "address: file:8080\nlevel: WARN\ntimeout: 1m\ndebug: true\n")
+	t.Setenv("TEST_CONFIG", path)
+	t.Setenv("TEST_LEVEL", "ERROR")
+	t.Setenv("TEST_TIMEOUT", "1h")
+
+	cfg := &testConfig{}
+	flagSet := newTestFlagSet(cfg)
+	require.NoError(t, Load(flagSet, []string{"-t", "5s", "-debug=false"}, cfg, &cfg.ConfigFile, nil))
+
+	assert.Equal(t, "file:8080", cfg.Address)
+	assert.Equal(t, "ERROR", cfg.Level)
+	assert.Equal(t, 5*time.Second, cfg.Timeout)
+	assert.False(t, cfg.Debug)
+}
+
+func TestLoadWithoutFile(t *testing.T) {
+	cfg := &testConfig{}
+	flagSet := newTestFlagSet(cfg)
+	require.NoError(t, Load(flagSet, []string{"-l", "DEBUG"}, cfg, &cfg.ConfigFile, nil))
+
+	assert.Equal(t, &testConfig{Address: "default:8080", Level: "DEBUG", Timeout: time.Second}, cfg)
+}
+
+func TestLoadCustomFileLoader(t *testing.T) {
+	cfg := &testConfig{}
+	flagSet := newTestFlagSet(cfg)
+
+	var loadedPath string
+	require.NoError(t, Load(flagSet, []string{"-config", "/tmp/foo.yaml"}, cfg, &cfg.ConfigFile, func(path string) error {
+		loadedPath = path
+		cfg.Address = "custom:8080"
+		return nil
+	}))
+	assert.Equal(t, "/tmp/foo.yaml", loadedPath)
+	assert.Equal(t, "custom:8080", cfg.Address)
+
+	cfg = &testConfig{}
+	flagSet = newTestFlagSet(cfg)
+	loadedPath = "not called"
+	require.NoError(t, Load(flagSet, []string{}, cfg, &cfg.ConfigFile, func(path string) error {
+		loadedPath = path
+		return nil
+	}))
+	assert.Equal(t, "", loadedPath)
+
+	errLoad := errors.New("load error")
+	cfg = &testConfig{}
+	flagSet = newTestFlagSet(cfg)
+	err := Load(flagSet, []string{"-config", "/tmp/foo.yaml"}, cfg, &cfg.ConfigFile, func(path string) error {
+		return errLoad
+	})
+	assert.ErrorIs(t, err, errLoad)
+}
+
+func TestLoadError(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		flags []string
+		env   map[string]string
+	}{
+		{name: "unknown flag", flags: []string{"-foo"}},
+		{name: "invalid env", env: map[string]string{"TEST_TIMEOUT": "foobar"}},
+		{name: "file not found", flags: []string{"-config", "/tmp/not/exists.yaml"}},
+		{name: "unknown key", flags: []string{"-config", writeTestFile(t, "foo: bar\n")}},
+		{name: "invalid value", flags: []string{"-config", writeTestFile(t, "timeout: foobar\n")}},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			cfg := &testConfig{}
+			flagSet := newTestFlagSet(cfg)
+			flagSet.SetOutput(io.Discard)
+			assert.Error(t, Load(flagSet, tt.flags, cfg, &cfg.ConfigFile, nil))
+		})
+	}
+}
+
+func TestLoadFileEmpty(t *testing.T) {
+	cfg := &testConfig{Level: "INFO"}
+	require.NoError(t, LoadFile(writeTestFile(t, ""), cfg))
+	assert.Equal(t, &testConfig{Level: "INFO"}, cfg)
+}
+
+func TestReadSecretFile(t *testing.T) {
+	value := "default"
+	require.NoError(t, ReadSecretFile(&value, "", "secret_file"))
+	assert.Equal(t, "default", value)
+
+	require.NoError(t, ReadSecretFile(&value, writeTestFile(t, "supersecret\n"), "secret_file"))
+	assert.Equal(t, "supersecret", value)
+
+	err := ReadSecretFile(&value, "/tmp/not/exists", "secret_file")
+	var fieldErr *FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "secret_file", fieldErr.Field)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.Contains(t, err.Error(), "secret_file")
+}
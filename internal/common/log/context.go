@@ -0,0 +1,42 @@
+package log
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Request logger fields.
+const (
+	FieldRequestID = "request_id"
+	FieldMethod    = "method"
+	FieldUserID    = "user_id"
+	FieldAdmin     = "admin"
+)
+
+type ctxLoggerKey struct{}
+
+// ContextWithLogger returns context with request logger.
+func ContextWithLogger(ctx context.Context, logger *logrus.Entry) context.Context {
+	return context.WithValue(ctx, ctxLoggerKey{}, logger)
+}
+
+// FromContext returns request logger from context or entry of logger, if context has no request logger.
+func FromContext(ctx context.Context, logger *logrus.Logger) *logrus.Entry {
+	if entry, ok := ctx.Value(ctxLoggerKey{}).(*logrus.Entry); ok {
+		return entry
+	}
+
+	return logrus.NewEntry(logger)
+}
+
+// ContextWithFields adds fields to request logger in context.
+// If context has no request logger, it is returned unchanged.
+func ContextWithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	entry, ok := ctx.Value(ctxLoggerKey{}).(*logrus.Entry)
+	if !ok {
+		return ctx
+	}
+
+	return ContextWithLogger(ctx, entry.WithFields(fields))
+}
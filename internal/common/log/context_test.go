@@ -0,0 +1,26 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextLogger(t *testing.T) {
+	logger := logrus.New()
+
+	// No request logger in context
+	ctx := context.Background()
+	assert.Equal(t, logger, FromContext(ctx, logger).Logger)
+	assert.Empty(t, FromContext(ctx, logger).Data)
+	assert.Equal(t, ctx, ContextWithFields(ctx, logrus.Fields{FieldUserID: 1}))
+
+	// Request logger with fields
+	ctx = ContextWithLogger(ctx, logger.WithField(FieldRequestID, "foo"))
+	ctxUser := ContextWithFields(ctx, logrus.Fields{FieldUserID: 1})
+	assert.Equal(t, logrus.Fields{FieldRequestID: "foo"}, FromContext(ctx, logrus.New()).Data)
+	assert.Equal(t, logrus.Fields{FieldRequestID: "foo", FieldUserID: 1}, FromContext(ctxUser, logrus.New()).Data)
+	assert.Equal(t, logger, FromContext(ctxUser, logrus.New()).Logger)
+}
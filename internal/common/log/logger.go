@@ -10,20 +10,31 @@ import (
 )
 
 // NewLogger creates logger with standart output.
+//
+// Logs are JSON formatted, sensitive data is redacted by RedactHook.
 func NewLogger(logLevel string) (*logrus.Logger, error) {
-	logger := logrus.New()
+	logger := newLogger()
+	if err := SetLevel(logger, logLevel); err != nil {
+		return nil, err
+	}
+
+	return logger, nil
+}
+
+// SetLevel sets logging level of logger, level could be changed while logger is used.
+func SetLevel(logger *logrus.Logger, logLevel string) error {
 	logLvl, err := logrus.ParseLevel(logLevel)
 	if err != nil {
-		return nil, fmt.Errorf("wrong LOG_LEVEL: %w", err)
+		return fmt.Errorf("wrong LOG_LEVEL: %w", err)
 	}
 
 	logger.SetLevel(logLvl)
-	return logger, nil
+	return nil
 }
 
-// NewLoggerF creates logger with file output.
+// NewLoggerF creates logger with file output. Format is the same as of NewLogger.
 func NewLoggerF(logLevel string, logFile string) (*logrus.Logger, io.Closer, error) {
-	logger := logrus.New()
+	logger := newLogger()
 	logLvl, err := logrus.ParseLevel(logLevel)
 	if err != nil {
 		return nil, nil, fmt.Errorf("wrong LOG_LEVEL: %w", err)
@@ -38,3 +49,10 @@ func NewLoggerF(logLevel string, logFile string) (*logrus.Logger, io.Closer, err
 	logger.SetOutput(file)
 	return logger, file, nil
 }
+
+func newLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.AddHook(NewRedactHook())
+	return logger
+}
@@ -0,0 +1,85 @@
+package log
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Redacted replaces sensitive data in logs.
+const Redacted = "[REDACTED]"
+
+var (
+	// _sensitiveKeys - parts of field names with sensitive values.
+	_sensitiveKeys = []string{"password", "passwd", "token", "payload", "authorization"} //nolint:gochecknoglobals // OK
+	// _reSensitiveText - sensitive key/value pairs in text like password='foo' or token: foo.
+	_reSensitiveText = regexp.MustCompile( //nolint:gochecknoglobals // OK
+		`(?i)(password|passwd|token)(['"]?\s*[:=]\s*)('[^']*'|"[^"]*"|[^\s,;]+)`)
+	// _reJWT - JSON web token.
+	_reJWT = regexp.MustCompile(`eyJ[\w-]+\.[\w-]+\.[\w-]*`) //nolint:gochecknoglobals // OK
+)
+
+// RedactHook is a logrus hook, which prevents passwords, tokens and payload bytes from reaching the log.
+//
+// Values of fields with sensitive names and byte slices are replaced with Redacted.
+// Sensitive key/value pairs and tokens in message and other text fields are replaced too.
+type RedactHook struct{}
+
+var _ logrus.Hook = (*RedactHook)(nil)
+
+// NewRedactHook creates new RedactHook object.
+func NewRedactHook() *RedactHook {
+	return &RedactHook{}
+}
+
+// Levels returns all levels, hook is fired for every entry.
+func (h *RedactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire redacts entry message and fields. Entry is already a copy, so fields of parent entry are not changed.
+func (h *RedactHook) Fire(entry *logrus.Entry) error {
+	entry.Message = RedactText(entry.Message)
+
+	for key, value := range entry.Data {
+		if sensitiveKey(key) {
+			entry.Data[key] = Redacted
+			continue
+		}
+
+		switch v := value.(type) {
+		case []byte:
+			entry.Data[key] = Redacted
+		case string:
+			entry.Data[key] = RedactText(v)
+		case error:
+			entry.Data[key] = RedactText(v.Error())
+		}
+	}
+
+	return nil
+}
+
+// RedactText replaces sensitive key/value pairs and tokens in text.
+func RedactText(text string) string {
+	text = _reSensitiveText.ReplaceAllString(text, "${1}${2}"+Redacted)
+	return _reJWT.ReplaceAllString(text, Redacted)
+}
+
+// sensitiveKey checks, that field name is sensitive: contains password, token, payload,
+// authorization or ends with secret (server_secret, but not secret_name).
+func sensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if strings.HasSuffix(key, "secret") {
+		return true
+	}
+
+	for _, sk := range _sensitiveKeys {
+		if strings.Contains(key, sk) {
+			return true
+		}
+	}
+
+	return false
+}
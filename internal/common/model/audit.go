@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// AuditEventType is an enum type for audit log events.
+type AuditEventType int32
+
+const (
+	UnknownAuditEvent AuditEventType = iota
+	AuditUserCreate
+	AuditUserLogin
+	AuditSecretRead
+	AuditSecretCreate
+	AuditSecretUpdate
+	AuditSecretDelete
+	AuditShareCreate
+	AuditShareRedeem
+)
+
+func (et AuditEventType) String() string {
+	switch et {
+	case AuditUserCreate:
+		return "user create"
+	case AuditUserLogin:
+		return "user login"
+	case AuditSecretRead:
+		return "secret read"
+	case AuditSecretCreate:
+		return "secret create"
+	case AuditSecretUpdate:
+		return "secret update"
+	case AuditSecretDelete:
+		return "secret delete"
+	case AuditShareCreate:
+		return "share create"
+	case AuditShareRedeem:
+		return "share redeem"
+	case UnknownAuditEvent:
+		return "unknown"
+	default:
+		return "unknown"
+	}
+}
+
+// AuditOutcomeOK - outcome of successful audit event.
+const AuditOutcomeOK = "OK"
+
+// AuditEvent represents record of user's action in audit log.
+type AuditEvent struct {
+	Type AuditEventType
+	// UserID - zero, if user is unknown (e.g. login of not existing user).
+	UserID int64
+	// Login - login given in user create and login events.
+	Login string
+	// SecretName - name of secret in secret events.
+	SecretName  string
+	PeerAddress string
+	UserAgent   string
+	// Outcome - AuditOutcomeOK or name of error.
+	Outcome   string
+	CreatedAt time.Time
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEventTypeString(t *testing.T) {
+	assert.Equal(t, "unknown", AuditEventType(100).String())
+	assert.Equal(t, "unknown", UnknownAuditEvent.String())
+	assert.Equal(t, "user create", AuditUserCreate.String())
+	assert.Equal(t, "user login", AuditUserLogin.String())
+	assert.Equal(t, "secret read", AuditSecretRead.String())
+	assert.Equal(t, "secret create", AuditSecretCreate.String())
+	assert.Equal(t, "secret update", AuditSecretUpdate.String())
+	assert.Equal(t, "secret delete", AuditSecretDelete.String())
+	assert.Equal(t, "share create", AuditShareCreate.String())
+	assert.Equal(t, "share redeem", AuditShareRedeem.String())
+}
@@ -5,14 +5,28 @@ package model
 import (
 	"crypto/sha256"
 	"fmt"
+	"strings"
+
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
 )
 
-const MaxPayloadSizeBytes = 10 * 1024 * 1024
+const (
+	MaxPayloadSizeBytes = 10 * 1024 * 1024
+
+	_cardNumVisibleDigits = 4
+	_cardNumMinMaskLength = 12
+)
 
 // Payload interface represents secret valuable information.
+//
+// String method redacts sensitive data, so payload is safe for logging.
+// UnsafeString returns all data and must not be used in logs.
 type Payload interface {
 	fmt.Stringer
+	fmt.GoStringer
+	UnsafeString() string
 	Valid() bool
+	GetHash() string
 }
 
 // CredsPayload represents user login/password pair.
@@ -32,6 +46,14 @@ func NewCredsPayload(login, password string) *CredsPayload {
 }
 
 func (cp *CredsPayload) String() string {
+	return fmt.Sprintf("Login=%s Password=%s", cp.Login, gkLog.Redacted)
+}
+
+func (cp *CredsPayload) GoString() string {
+	return cp.String()
+}
+
+func (cp *CredsPayload) UnsafeString() string {
 	return fmt.Sprintf("Login=%s Password=%s", cp.Login, cp.Password)
 }
 
@@ -39,6 +61,10 @@ func (cp *CredsPayload) Valid() bool {
 	return cp.Hash == cp.hash()
 }
 
+func (cp *CredsPayload) GetHash() string {
+	return cp.Hash
+}
+
 func (cp *CredsPayload) hash() string {
 	return sha256sum([]byte(cp.Login + cp.Password))
 }
@@ -59,6 +85,14 @@ func NewTextPayload(data string) *TextPayload {
 }
 
 func (tp *TextPayload) String() string {
+	return fmt.Sprintf("Text=%s", gkLog.Redacted)
+}
+
+func (tp *TextPayload) GoString() string {
+	return tp.String()
+}
+
+func (tp *TextPayload) UnsafeString() string {
 	return fmt.Sprintf("Text=%s", tp.Data)
 }
 
@@ -66,6 +100,10 @@ func (tp *TextPayload) Valid() bool {
 	return tp.Hash == tp.hash()
 }
 
+func (tp *TextPayload) GetHash() string {
+	return tp.Hash
+}
+
 func (tp *TextPayload) hash() string {
 	return sha256sum([]byte(tp.Data))
 }
@@ -86,6 +124,14 @@ func NewBinaryPayload(data []byte) *BinaryPayload {
 }
 
 func (bp *BinaryPayload) String() string {
+	return fmt.Sprintf("Data=%s (%d bytes)", gkLog.Redacted, len(bp.Data))
+}
+
+func (bp *BinaryPayload) GoString() string {
+	return bp.String()
+}
+
+func (bp *BinaryPayload) UnsafeString() string {
 	return fmt.Sprintf("Data=%x", bp.Data)
 }
 
@@ -93,6 +139,10 @@ func (bp *BinaryPayload) Valid() bool {
 	return bp.Hash == bp.hash()
 }
 
+func (bp *BinaryPayload) GetHash() string {
+	return bp.Hash
+}
+
 func (bp *BinaryPayload) hash() string {
 	return sha256sum(bp.Data)
 }
@@ -116,6 +166,19 @@ func NewCardPayload(cardNum, cardHolder, validThru, cvv string) *CardPayload {
 }
 
 func (cp *CardPayload) String() string {
+	return fmt.Sprintf(
+		"CardNum=%s CardHolder=%s ValidThru=%s CVV=%s",
+		MaskCardNumber(cp.CardNum),
+		cp.CardHolder,
+		cp.ValidThru,
+		gkLog.Redacted)
+}
+
+func (cp *CardPayload) GoString() string {
+	return cp.String()
+}
+
+func (cp *CardPayload) UnsafeString() string {
 	return fmt.Sprintf(
 		"CardNum=%s CardHolder=%s ValidThru=%s CVV=%s",
 		cp.CardNum,
@@ -128,10 +191,26 @@ func (cp *CardPayload) Valid() bool {
 	return cp.Hash == cp.hash()
 }
 
+func (cp *CardPayload) GetHash() string {
+	return cp.Hash
+}
+
 func (cp *CardPayload) hash() string {
 	return sha256sum([]byte(cp.CardNum + cp.CardHolder + cp.ValidThru + cp.CVV))
 }
 
+// MaskCardNumber hides all card number digits except last four.
+// Short numbers are fully redacted.
+func MaskCardNumber(cardNum string) string {
+	cardNum = strings.ReplaceAll(cardNum, " ", "")
+	if len(cardNum) < _cardNumMinMaskLength {
+		return gkLog.Redacted
+	}
+
+	visible := cardNum[len(cardNum)-_cardNumVisibleDigits:]
+	return strings.Repeat("*", len(cardNum)-_cardNumVisibleDigits) + visible
+}
+
 func sha256sum(data []byte) string {
 	h := sha256.New()
 	h.Write(data)
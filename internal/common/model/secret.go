@@ -3,6 +3,8 @@ package model
 
 import (
 	"errors"
+	"sort"
+	"time"
 
 	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
 	"github.com/tinylib/msgp/msgp"
@@ -57,27 +59,118 @@ func ValidSecretType(st SecretType) error {
 }
 
 // Secret represents data structure for secret.
+//
+// ExpiresAt and RotateEvery are optional, zero value means secret never expires or should not be rotated.
 type Secret struct {
-	Type       SecretType
-	Name       string
-	Meta       string
-	Version    int64
-	PayloadRaw []byte
+	Type        SecretType
+	Name        string
+	Meta        string
+	Version     int64
+	PayloadRaw  []byte
+	ExpiresAt   time.Time
+	RotateEvery time.Duration
 }
 
 // SecretInfo represents short information about Secret. Used in list of secrets.
+//
+// UpdatedAt is a time of any secret update, PayloadUpdatedAt - time of last payload change.
 type SecretInfo struct {
-	Type    SecretType
-	Name    string
-	Version int64
+	Type             SecretType
+	Name             string
+	Version          int64
+	UpdatedAt        time.Time
+	PayloadUpdatedAt time.Time
+	ExpiresAt        time.Time
+	RotateEvery      time.Duration
+}
+
+// RotateAt returns time, when secret should be rotated. Rotation period is counted from last payload change,
+// so editing meta, expiration or rotation period doesn't reset it.
+//
+// Returns zero time, if secret should not be rotated.
+func (si *SecretInfo) RotateAt() time.Time {
+	if si.RotateEvery <= 0 || si.PayloadUpdatedAt.IsZero() {
+		return time.Time{}
+	}
+
+	return si.PayloadUpdatedAt.Add(si.RotateEvery)
+}
+
+// DueAt returns nearest of expiration and rotation times.
+//
+// Returns zero time, if secret never expires and should not be rotated.
+func (si *SecretInfo) DueAt() time.Time {
+	expiresAt, rotateAt := si.ExpiresAt, si.RotateAt()
+
+	switch {
+	case expiresAt.IsZero():
+		return rotateAt
+	case rotateAt.IsZero() || expiresAt.Before(rotateAt):
+		return expiresAt
+	default:
+		return rotateAt
+	}
+}
+
+// SortByUrgency sorts secrets by urgency: secrets with nearest expiration or rotation first,
+// then secrets without them by name.
+func SortByUrgency(items []SecretInfo) {
+	sort.SliceStable(items, func(i, j int) bool {
+		dueI, dueJ := items[i].DueAt(), items[j].DueAt()
+		switch {
+		case dueI.IsZero() && dueJ.IsZero():
+			return items[i].Name < items[j].Name
+		case dueI.IsZero() || dueJ.IsZero():
+			return !dueI.IsZero()
+		default:
+			return dueI.Before(dueJ)
+		}
+	})
 }
 
 // SecretUpdate represents Secret fields to update.
+//
+// ExpiresAt and RotateEvery are always updated with Meta.
 type SecretUpdate struct {
 	Meta          string
 	Version       int64
 	PayloadRaw    []byte
 	UpdatePayload bool
+	ExpiresAt     time.Time
+	RotateEvery   time.Duration
+}
+
+// SecretEventType is an enum type for secret change events.
+type SecretEventType int32
+
+const (
+	UnknownSecretEvent SecretEventType = iota
+	SecretCreated
+	SecretUpdated
+	SecretDeleted
+)
+
+func (et SecretEventType) String() string {
+	switch et {
+	case SecretCreated:
+		return "created"
+	case SecretUpdated:
+		return "updated"
+	case SecretDeleted:
+		return "deleted"
+	case UnknownSecretEvent:
+		return "unknown"
+	default:
+		return "unknown"
+	}
+}
+
+// SecretEvent represents change of user's secret.
+type SecretEvent struct {
+	EventType  SecretEventType
+	Name       string
+	SecretType SecretType
+	Version    int64
 }
 
 // GetPayload returns Payload from binary raw.
@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	gkMsgp "github.com/devldavydov/gophkeeper/internal/common/msgp"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +19,14 @@ func TestSecretTypeString(t *testing.T) {
 	assert.Equal(t, "Card", CardSecret.String())
 }
 
+func TestSecretEventTypeString(t *testing.T) {
+	assert.Equal(t, "unknown", SecretEventType(100).String())
+	assert.Equal(t, "unknown", UnknownSecretEvent.String())
+	assert.Equal(t, "created", SecretCreated.String())
+	assert.Equal(t, "updated", SecretUpdated.String())
+	assert.Equal(t, "deleted", SecretDeleted.String())
+}
+
 func TestPayloadValid(t *testing.T) {
 	for i, tt := range []struct {
 		payload Payload
@@ -46,29 +55,42 @@ func TestPayloadValid(t *testing.T) {
 
 func TestPayloadString(t *testing.T) {
 	for i, tt := range []struct {
-		payload Payload
-		str     string
+		payload   Payload
+		str       string
+		unsafeStr string
 	}{
 		{
-			payload: NewCredsPayload("foo", "bar"),
-			str:     "Login=foo Password=bar",
+			payload:   NewCredsPayload("foo", "bar"),
+			str:       "Login=foo Password=[REDACTED]",
+			unsafeStr: "Login=foo Password=bar",
 		},
 		{
-			payload: NewTextPayload("foo"),
-			str:     "Text=foo",
+			payload:   NewTextPayload("foo"),
+			str:       "Text=[REDACTED]",
+			unsafeStr: "Text=foo",
 		},
 		{
-			payload: NewBinaryPayload([]byte("foobar")),
-			str:     "Data=666f6f626172",
+			payload:   NewBinaryPayload([]byte("foobar")),
+			str:       "Data=[REDACTED] (6 bytes)",
+			unsafeStr: "Data=666f6f626172",
 		},
 		{
-			payload: NewCardPayload("2202", "foo", "11/26", "777"),
-			str:     "CardNum=2202 CardHolder=foo ValidThru=11/26 CVV=777",
+			payload:   NewCardPayload("2202", "foo", "11/26", "777"),
+			str:       "CardNum=[REDACTED] CardHolder=foo ValidThru=11/26 CVV=[REDACTED]",
+			unsafeStr: "CardNum=2202 CardHolder=foo ValidThru=11/26 CVV=777",
+		},
+		{
+			payload:   NewCardPayload("2202 2000 1234 5678", "foo", "11/26", "777"),
+			str:       "CardNum=************5678 CardHolder=foo ValidThru=11/26 CVV=[REDACTED]",
+			unsafeStr: "CardNum=2202 2000 1234 5678 CardHolder=foo ValidThru=11/26 CVV=777",
 		},
 	} {
 		tt := tt
 		t.Run(fmt.Sprintf("Run %d", i), func(t *testing.T) {
 			assert.Equal(t, tt.str, tt.payload.String())
+			assert.Equal(t, tt.str, fmt.Sprintf("%v", tt.payload))
+			assert.Equal(t, tt.str, fmt.Sprintf("%#v", tt.payload))
+			assert.Equal(t, tt.unsafeStr, tt.payload.UnsafeString())
 		})
 	}
 }
@@ -125,7 +147,7 @@ func TestSecretGetPayload(t *testing.T) {
 			} else {
 				expPayload, ok := tt.input.(Payload)
 				assert.True(t, ok)
-				assert.Equal(t, expPayload.String(), payload.String())
+				assert.Equal(t, expPayload.UnsafeString(), payload.UnsafeString())
 			}
 		})
 	}
@@ -140,3 +162,67 @@ func TestValidSecretType(t *testing.T) {
 		assert.ErrorIs(t, ValidSecretType(sType), ErrInvalidSecretType)
 	}
 }
+
+func TestSecretInfoDueAt(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name        string
+		info        SecretInfo
+		expRotateAt time.Time
+		expDueAt    time.Time
+	}{
+		{name: "no expiration and rotation", info: SecretInfo{PayloadUpdatedAt: updatedAt}},
+		{
+			name:     "expiration only",
+			info:     SecretInfo{PayloadUpdatedAt: updatedAt, ExpiresAt: updatedAt.AddDate(0, 6, 0)},
+			expDueAt: updatedAt.AddDate(0, 6, 0),
+		},
+		{
+			name:        "rotation only",
+			info:        SecretInfo{PayloadUpdatedAt: updatedAt, RotateEvery: 90 * 24 * time.Hour},
+			expRotateAt: updatedAt.AddDate(0, 0, 90),
+			expDueAt:    updatedAt.AddDate(0, 0, 90),
+		},
+		{
+			name: "expiration before rotation",
+			info: SecretInfo{
+				PayloadUpdatedAt: updatedAt, ExpiresAt: updatedAt.AddDate(0, 0, 30), RotateEvery: 90 * 24 * time.Hour,
+			},
+			expRotateAt: updatedAt.AddDate(0, 0, 90),
+			expDueAt:    updatedAt.AddDate(0, 0, 30),
+		},
+		{
+			name: "rotation before expiration",
+			info: SecretInfo{
+				PayloadUpdatedAt: updatedAt, ExpiresAt: updatedAt.AddDate(1, 0, 0), RotateEvery: 90 * 24 * time.Hour,
+			},
+			expRotateAt: updatedAt.AddDate(0, 0, 90),
+			expDueAt:    updatedAt.AddDate(0, 0, 90),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expRotateAt, tt.info.RotateAt())
+			assert.Equal(t, tt.expDueAt, tt.info.DueAt())
+		})
+	}
+}
+
+func TestSortByUrgency(t *testing.T) {
+	updatedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []SecretInfo{
+		{Name: "c", PayloadUpdatedAt: updatedAt},
+		{Name: "rotate", PayloadUpdatedAt: updatedAt, RotateEvery: 30 * 24 * time.Hour},
+		{Name: "a", PayloadUpdatedAt: updatedAt},
+		{Name: "expire", PayloadUpdatedAt: updatedAt, ExpiresAt: updatedAt.AddDate(0, 0, 10)},
+	}
+
+	SortByUrgency(items)
+
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	assert.Equal(t, []string{"expire", "rotate", "a", "c"}, names)
+}
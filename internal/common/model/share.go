@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Share represents one-time share of secret payload.
+//
+// Payload is encrypted on client with random key, which is never sent to server.
+type Share struct {
+	ID string
+	// UserID - owner of share.
+	UserID int64
+	// SecretName - name of shared secret, used only in owner's audit log.
+	SecretName       string
+	Type             SecretType
+	PayloadEncrypted []byte
+	// ViewsLeft - number of remaining retrievals, share is deleted when none left.
+	ViewsLeft int32
+	ExpiresAt time.Time
+}
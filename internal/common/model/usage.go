@@ -0,0 +1,33 @@
+package model
+
+// Usage represents user's storage usage and quota.
+//
+// Bytes is a total size of stored secrets payload. Zero limit means unlimited.
+type Usage struct {
+	Bytes      int64
+	Secrets    int64
+	MaxBytes   int64
+	MaxSecrets int64
+}
+
+// BytesRatio returns part of bytes quota in use, from 0 to 1. Returns 0 if quota is unlimited.
+func (u *Usage) BytesRatio() float64 {
+	return usageRatio(u.Bytes, u.MaxBytes)
+}
+
+// SecretsRatio returns part of secrets quota in use, from 0 to 1. Returns 0 if quota is unlimited.
+func (u *Usage) SecretsRatio() float64 {
+	return usageRatio(u.Secrets, u.MaxSecrets)
+}
+
+func usageRatio(used, limit int64) float64 {
+	if limit <= 0 || used <= 0 {
+		return 0
+	}
+
+	if used >= limit {
+		return 1
+	}
+
+	return float64(used) / float64(limit)
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageRatio(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		usage      Usage
+		expBytes   float64
+		expSecrets float64
+	}{
+		{name: "unlimited", usage: Usage{Bytes: 100, Secrets: 10}},
+		{name: "empty", usage: Usage{MaxBytes: 100, MaxSecrets: 10}},
+		{name: "partial", usage: Usage{Bytes: 25, Secrets: 5, MaxBytes: 100, MaxSecrets: 10}, expBytes: 0.25, expSecrets: 0.5},
+		{name: "over quota", usage: Usage{Bytes: 200, Secrets: 20, MaxBytes: 100, MaxSecrets: 10}, expBytes: 1, expSecrets: 1},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expBytes, tt.usage.BytesRatio())
+			assert.Equal(t, tt.expSecrets, tt.usage.SecretsRatio())
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"time"
+)
+
+const _serialNumberBits = 128
+
+var (
+	ErrInvalidCACert     = errors.New("invalid CA certificate")
+	ErrInvalidCAKey      = errors.New("invalid CA private key")
+	ErrInvalidCommonName = errors.New("invalid certificate common name")
+)
+
+// GenerateClientCert issues client certificate with given subject common name, signed by CA.
+//
+// Accepts PEM encoded CA certificate and private key, common name and validity period.
+// Returns PEM encoded client certificate and private key or error:
+//
+// - ErrInvalidCACert - CA certificate can't be parsed.
+//
+// - ErrInvalidCAKey - CA private key can't be parsed.
+//
+// - ErrInvalidCommonName - common name is empty.
+func GenerateClientCert(
+	caCertPEM, caKeyPEM []byte,
+	commonName string,
+	validity time.Duration,
+) ([]byte, []byte, error) {
+	if commonName == "" {
+		return nil, nil, ErrInvalidCommonName
+	}
+
+	caBlock, _ := pem.Decode(caCertPEM)
+	if caBlock == nil {
+		return nil, nil, ErrInvalidCACert
+	}
+	caCert, err := x509.ParseCertificate(caBlock.Bytes)
+	if err != nil {
+		return nil, nil, ErrInvalidCACert
+	}
+
+	caKey, err := parsePrivateKey(caKeyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), _serialNumberBits))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		nil
+}
+
+// parsePrivateKey parses PKCS8, PKCS1 or EC private key, as produced by openssl.
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, ErrInvalidCAKey
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, ErrInvalidCAKey
+		}
+		return signer, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, ErrInvalidCAKey
+}
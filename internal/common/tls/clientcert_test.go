@@ -0,0 +1,231 @@
+package tls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+)
+
+func TestGenerateClientCert(t *testing.T) {
+	caCertPEM, caKeyPEM := newTestCA(t)
+
+	certPEM, keyPEM, err := GenerateClientCert(caCertPEM, caKeyPEM, "user", time.Hour)
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(certPEM)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	caBlock, _ := pem.Decode(caCertPEM)
+	caCert, err := x509.ParseCertificate(caBlock.Bytes)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	assert.Equal(t, "user", cert.Subject.CommonName)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	assert.NoError(t, err)
+
+	_, err = parsePrivateKey(keyPEM)
+	assert.NoError(t, err)
+}
+
+func TestGenerateClientCertOpensslCA(t *testing.T) {
+	caCertPEM, err := os.ReadFile(getTLSFile("ca-cert.pem"))
+	require.NoError(t, err)
+	caKeyPEM, err := os.ReadFile(getTLSFile("ca-key.pem"))
+	require.NoError(t, err)
+
+	_, _, err = GenerateClientCert(caCertPEM, caKeyPEM, "user", time.Hour)
+	assert.NoError(t, err)
+}
+
+func TestGenerateClientCertErrors(t *testing.T) {
+	caCertPEM, caKeyPEM := newTestCA(t)
+
+	_, _, err := GenerateClientCert(caCertPEM, caKeyPEM, "", time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidCommonName)
+
+	_, _, err = GenerateClientCert([]byte("foobar"), caKeyPEM, "user", time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidCACert)
+
+	_, _, err = GenerateClientCert(caCertPEM, caCertPEM, "user", time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidCAKey)
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	caCertPEM, caKeyPEM := newTestCA(t)
+	serverCertPEM, serverKeyPEM := newTestServerCert(t, caCertPEM, caKeyPEM)
+	clientCertPEM, clientKeyPEM, err := GenerateClientCert(caCertPEM, caKeyPEM, "user", time.Hour)
+	require.NoError(t, err)
+
+	for name, data := range map[string][]byte{
+		"ca-cert.pem":     caCertPEM,
+		"server-cert.pem": serverCertPEM,
+		"server-key.pem":  serverKeyPEM,
+		"client-cert.pem": clientCertPEM,
+		"client-key.pem":  clientKeyPEM,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0600))
+	}
+	fnPath := func(name string) string { return filepath.Join(dir, name) }
+
+	for _, tt := range []struct {
+		name       string
+		require    bool
+		clientCert bool
+		expErr     bool
+		expCN      string
+	}{
+		{name: "optional without cert", require: false, clientCert: false},
+		{name: "optional with cert", require: false, clientCert: true, expCN: "user"},
+		{name: "required without cert", require: true, clientCert: false, expErr: true},
+		{name: "required with cert", require: true, clientCert: true, expCN: "user"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := NewServerSettings(fnPath("server-cert.pem"), fnPath("server-key.pem"), fnPath("ca-cert.pem"), tt.require)
+			require.NoError(t, err)
+			srvCreds, err := settings.Load()
+			require.NoError(t, err)
+
+			var peerCN string
+			addr := startTestServer(t, srvCreds, func(ctx context.Context) {
+				peerCN, _ = PeerCommonName(ctx)
+			})
+
+			var cltCreds credentials.TransportCredentials
+			if tt.clientCert {
+				cltCreds, err = LoadClientCert(fnPath("ca-cert.pem"), "", fnPath("client-cert.pem"), fnPath("client-key.pem"))
+			} else {
+				cltCreds, err = LoadCACert(fnPath("ca-cert.pem"), "")
+			}
+			require.NoError(t, err)
+
+			conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(cltCreds))
+			require.NoError(t, err)
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+			if tt.expErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expCN, peerCN)
+		})
+	}
+}
+
+func TestPeerCommonName(t *testing.T) {
+	_, ok := PeerCommonName(context.Background())
+	assert.False(t, ok)
+
+	_, ok = PeerCommonName(peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}}))
+	assert.False(t, ok)
+}
+
+func startTestServer(t *testing.T, creds credentials.TransportCredentials, fnPeer func(ctx context.Context)) string {
+	t.Helper()
+
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.UnaryInterceptor(func(
+			ctx context.Context,
+			req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			fnPeer(ctx)
+			return handler(ctx, req)
+		}),
+	)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go func() { _ = srv.Serve(listen) }()
+	t.Cleanup(srv.Stop)
+
+	return listen.Addr().String()
+}
+
+func newTestCA(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func newTestServerCert(t *testing.T, caCertPEM, caKeyPEM []byte) ([]byte, []byte) {
+	t.Helper()
+
+	caBlock, _ := pem.Decode(caCertPEM)
+	caCert, err := x509.ParseCertificate(caBlock.Bytes)
+	require.NoError(t, err)
+	caKey, err := parsePrivateKey(caKeyPEM)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "server"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+}
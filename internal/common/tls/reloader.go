@@ -0,0 +1,141 @@
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultCertCheckInterval - default interval of certificate files change checks.
+const DefaultCertCheckInterval = 10 * time.Second
+
+// CertReloader serves server certificate, which is reloaded from files on change,
+// without restart and drop of established connections.
+type CertReloader struct {
+	mu       sync.RWMutex
+	certPath string
+	keyPath  string
+	cert     *tls.Certificate
+	state    certFilesState
+	logger   *logrus.Logger
+}
+
+// certFilesState is a state of certificate files to detect change.
+type certFilesState struct {
+	certModTime, keyModTime time.Time
+	certSize, keySize       int64
+}
+
+// NewCertReloader creates new CertReloader object and loads certificate from files.
+func NewCertReloader(certPath, keyPath string, logger *logrus.Logger) (*CertReloader, error) {
+	r := &CertReloader{logger: logger}
+	if err := r.SetFiles(certPath, keyPath); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetCertificate returns current certificate, used as tls.Config.GetCertificate.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, nil
+}
+
+// Reload re-reads certificate from files. In case of error current certificate is kept.
+func (r *CertReloader) Reload() error {
+	r.mu.RLock()
+	certPath, keyPath := r.certPath, r.keyPath
+	r.mu.RUnlock()
+
+	return r.SetFiles(certPath, keyPath)
+}
+
+// SetFiles loads certificate from new files, further reloads use them.
+// In case of error current certificate and files are kept.
+func (r *CertReloader) SetFiles(certPath, keyPath string) error {
+	// State is taken before load, so change during load is detected by next check
+	state, err := statCertFiles(certPath, keyPath)
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.certPath, r.keyPath = certPath, keyPath
+	r.cert = &cert
+	r.state = state
+	return nil
+}
+
+// Watch checks certificate files with interval and reloads certificate on change till context canceled.
+func (r *CertReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.check()
+		}
+	}
+}
+
+func (r *CertReloader) check() {
+	r.mu.RLock()
+	certPath, keyPath, prevState := r.certPath, r.keyPath, r.state
+	r.mu.RUnlock()
+
+	state, err := statCertFiles(certPath, keyPath)
+	if err != nil {
+		r.logger.Errorf("TLS certificate files check error: %v", err)
+		return
+	}
+	if state == prevState {
+		return
+	}
+
+	if err = r.SetFiles(certPath, keyPath); err != nil {
+		// Files could be written partially, retry on next change
+		r.logger.Errorf("TLS certificate reload error: %v", err)
+		r.mu.Lock()
+		r.state = state
+		r.mu.Unlock()
+		return
+	}
+
+	r.logger.Infof("TLS certificate reloaded from [%s]", certPath)
+}
+
+func statCertFiles(certPath, keyPath string) (certFilesState, error) {
+	certInfo, err := os.Stat(certPath)
+	if err != nil {
+		return certFilesState{}, err
+	}
+
+	keyInfo, err := os.Stat(keyPath)
+	if err != nil {
+		return certFilesState{}, err
+	}
+
+	return certFilesState{
+		certModTime: certInfo.ModTime(),
+		keyModTime:  keyInfo.ModTime(),
+		certSize:    certInfo.Size(),
+		keySize:     keyInfo.Size(),
+	}, nil
+}
@@ -0,0 +1,136 @@
+package tls
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "server-cert.pem"), filepath.Join(dir, "server-key.pem")
+	caCertPEM, caKeyPEM := newTestCA(t)
+
+	certPEM1, keyPEM1 := newTestServerCert(t, caCertPEM, caKeyPEM)
+	writeTestCert(t, certPath, keyPath, certPEM1, keyPEM1)
+
+	reloader, err := NewCertReloader(certPath, keyPath, logrus.New())
+	require.NoError(t, err)
+	assertServedCert(t, reloader, certPEM1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go reloader.Watch(ctx, 10*time.Millisecond)
+
+	// Changed files are reloaded
+	certPEM2, keyPEM2 := newTestServerCert(t, caCertPEM, caKeyPEM)
+	writeTestCert(t, certPath, keyPath, certPEM2, keyPEM2)
+	assert.Eventually(t, func() bool { return servedCertEqual(t, reloader, certPEM2) }, time.Second, 10*time.Millisecond)
+
+	// Invalid files are not loaded, current certificate is kept
+	writeTestCert(t, certPath, keyPath, []byte("foobar"), keyPEM2)
+	time.Sleep(50 * time.Millisecond)
+	assertServedCert(t, reloader, certPEM2)
+	assert.Error(t, reloader.Reload())
+	assertServedCert(t, reloader, certPEM2)
+
+	assert.Error(t, reloader.SetFiles(filepath.Join(dir, "not-exists.pem"), keyPath))
+	assertServedCert(t, reloader, certPEM2)
+
+	// Fixed files are reloaded by explicit reload
+	writeTestCert(t, certPath, keyPath, certPEM1, keyPEM1)
+	assert.NoError(t, reloader.Reload())
+	assertServedCert(t, reloader, certPEM1)
+}
+
+func TestNewCertReloaderError(t *testing.T) {
+	_, err := NewCertReloader("/tmp/not/exists.pem", "/tmp/not/exists.pem", logrus.New())
+	assert.Error(t, err)
+}
+
+func TestServerSettingsLoadReloadable(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "server-cert.pem"), filepath.Join(dir, "server-key.pem")
+	caCertPEM, caKeyPEM := newTestCA(t)
+	certPEM1, keyPEM1 := newTestServerCert(t, caCertPEM, caKeyPEM)
+	writeTestCert(t, certPath, keyPath, certPEM1, keyPEM1)
+
+	settings, err := NewServerSettings(certPath, keyPath, "", false)
+	require.NoError(t, err)
+	creds, reloader, err := settings.LoadReloadable(logrus.New())
+	require.NoError(t, err)
+
+	addr := startTestServer(t, creds, func(ctx context.Context) {})
+	assert.Equal(t, certPEM1, dialServerCert(t, addr, caCertPEM))
+
+	// Another server shares reloader certificate
+	sharedCreds, err := (&ServerSettings{}).LoadWithReloader(reloader)
+	require.NoError(t, err)
+	sharedAddr := startTestServer(t, sharedCreds, func(ctx context.Context) {})
+	assert.Equal(t, certPEM1, dialServerCert(t, sharedAddr, caCertPEM))
+
+	// New connections get new certificate after reload
+	certPEM2, keyPEM2 := newTestServerCert(t, caCertPEM, caKeyPEM)
+	writeTestCert(t, certPath, keyPath, certPEM2, keyPEM2)
+	require.NoError(t, reloader.Reload())
+	assert.Equal(t, certPEM2, dialServerCert(t, addr, caCertPEM))
+	assert.Equal(t, certPEM2, dialServerCert(t, sharedAddr, caCertPEM))
+}
+
+func writeTestCert(t *testing.T, certPath, keyPath string, certPEM, keyPEM []byte) {
+	t.Helper()
+
+	// Modification time resolution could be coarse, so it is moved forward explicitly
+	modTime := time.Now()
+	if info, err := os.Stat(certPath); err == nil && !modTime.After(info.ModTime()) {
+		modTime = info.ModTime().Add(time.Second)
+	}
+
+	require.NoError(t, os.WriteFile(certPath, certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyPath, keyPEM, 0600))
+	require.NoError(t, os.Chtimes(certPath, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyPath, modTime, modTime))
+}
+
+func servedCertEqual(t *testing.T, reloader *CertReloader, certPEM []byte) bool {
+	t.Helper()
+
+	cert, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+
+	block, _ := pem.Decode(certPEM)
+	require.NotNil(t, block)
+	return bytes.Equal(block.Bytes, cert.Certificate[0])
+}
+
+func assertServedCert(t *testing.T, reloader *CertReloader, certPEM []byte) {
+	t.Helper()
+	assert.True(t, servedCertEqual(t, reloader, certPEM))
+}
+
+func dialServerCert(t *testing.T, addr string, caCertPEM []byte) []byte {
+	t.Helper()
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(caCertPEM))
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    roots,
+		NextProtos: []string{"h2"},
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: conn.ConnectionState().PeerCertificates[0].Raw})
+}
@@ -2,13 +2,16 @@
 package tls
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"fmt"
 	"os"
 
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 // ServerSettings represents TLS server settings.
@@ -18,17 +21,33 @@ type ServerSettings struct {
 
 	// Server certificate key file path
 	ServerKeyPath string
+
+	// Client certificates CA bundle file path. If empty, client certificates are not requested.
+	ClientCACertPath string
+
+	// Client certificate is required, otherwise verified only if given
+	RequireClientCert bool
 }
 
 // NewServerSettings creates new ServerSettings object.
 //
-// Input arguments - certificate and key file paths.
-func NewServerSettings(certPath, keyPath string) (*ServerSettings, error) {
+// Input arguments - certificate and key file paths, optional CA bundle to verify client certificates
+// and flag to require client certificate.
+func NewServerSettings(certPath, keyPath, clientCACertPath string, requireClientCert bool) (*ServerSettings, error) {
 	if certPath == "" || keyPath == "" {
 		return nil, errors.New("invalid TLS server settings")
 	}
 
-	return &ServerSettings{ServerCertPath: certPath, ServerKeyPath: keyPath}, nil
+	if requireClientCert && clientCACertPath == "" {
+		return nil, errors.New("invalid TLS server settings: client CA required")
+	}
+
+	return &ServerSettings{
+		ServerCertPath:    certPath,
+		ServerKeyPath:     keyPath,
+		ClientCACertPath:  clientCACertPath,
+		RequireClientCert: requireClientCert,
+	}, nil
 }
 
 // Load creates new TransportCredentials from certificate and key file paths.
@@ -40,25 +59,83 @@ func (t *ServerSettings) Load() (credentials.TransportCredentials, error) {
 		return nil, err
 	}
 
-	config := &tls.Config{
-		MinVersion:   tls.VersionTLS12,
-		Certificates: []tls.Certificate{serverCert},
-		ClientAuth:   tls.NoClientCert,
+	config, err := t.config()
+	if err != nil {
+		return nil, err
 	}
+	config.Certificates = []tls.Certificate{serverCert}
 
 	return credentials.NewTLS(config), nil
 }
 
-// LoadCACert loads Certificate Authority certificate from file.
-func LoadCACert(caCertPath string, customServerName string) (credentials.TransportCredentials, error) {
-	pemServerCA, err := os.ReadFile(caCertPath)
+// LoadReloadable creates new TransportCredentials, which serve certificate of returned CertReloader.
+//
+// In case of invalid format returns specific TLS error.
+func (t *ServerSettings) LoadReloadable(logger *logrus.Logger) (credentials.TransportCredentials, *CertReloader, error) {
+	reloader, err := NewCertReloader(t.ServerCertPath, t.ServerKeyPath, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsCredentials, err := t.LoadWithReloader(reloader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tlsCredentials, reloader, nil
+}
+
+// LoadWithReloader creates new TransportCredentials, which serve certificate of existing CertReloader.
+// Certificate and key file paths of settings are not used.
+//
+// In case of invalid client CA returns specific TLS error.
+func (t *ServerSettings) LoadWithReloader(reloader *CertReloader) (credentials.TransportCredentials, error) {
+	config, err := t.config()
 	if err != nil {
 		return nil, err
 	}
+	config.GetCertificate = reloader.GetCertificate
 
-	certPool := x509.NewCertPool()
-	if !certPool.AppendCertsFromPEM(pemServerCA) {
-		return nil, fmt.Errorf("failed to add server CA's certificate")
+	return credentials.NewTLS(config), nil
+}
+
+// config creates server TLS config without certificate.
+func (t *ServerSettings) config() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.NoClientCert,
+	}
+
+	if t.ClientCACertPath != "" {
+		clientCAs, err := loadCertPool(t.ClientCACertPath)
+		if err != nil {
+			return nil, err
+		}
+
+		config.ClientCAs = clientCAs
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+		if t.RequireClientCert {
+			config.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	return config, nil
+}
+
+// LoadCACert loads Certificate Authority certificate from file.
+func LoadCACert(caCertPath string, customServerName string) (credentials.TransportCredentials, error) {
+	return LoadClientCert(caCertPath, customServerName, "", "")
+}
+
+// LoadClientCert loads Certificate Authority certificate and client certificate with key from files.
+//
+// If certPath is empty, client certificate is not used.
+func LoadClientCert(
+	caCertPath, customServerName, certPath, keyPath string,
+) (credentials.TransportCredentials, error) {
+	certPool, err := loadCertPool(caCertPath)
+	if err != nil {
+		return nil, err
 	}
 
 	config := &tls.Config{
@@ -69,5 +146,56 @@ func LoadCACert(caCertPath string, customServerName string) (credentials.Transpo
 		config.ServerName = customServerName
 	}
 
+	if certPath != "" {
+		clientCert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{clientCert}
+	}
+
 	return credentials.NewTLS(config), nil
 }
+
+// PeerCommonName returns subject common name of verified client certificate of gRPC request.
+//
+// Returns false, if client certificate was not given.
+func PeerCommonName(ctx context.Context) (string, bool) {
+	cert, ok := PeerCertificate(ctx)
+	if !ok {
+		return "", false
+	}
+
+	return cert.Subject.CommonName, true
+}
+
+// PeerCertificate returns verified client (leaf) certificate of gRPC request.
+//
+// Returns false, if client certificate was not given.
+func PeerCertificate(ctx context.Context) (*x509.Certificate, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, false
+	}
+
+	return tlsInfo.State.VerifiedChains[0][0], true
+}
+
+func loadCertPool(caCertPath string) (*x509.CertPool, error) {
+	pemCA, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(pemCA) {
+		return nil, fmt.Errorf("failed to add CA's certificate")
+	}
+
+	return certPool, nil
+}
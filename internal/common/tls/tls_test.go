@@ -9,21 +9,41 @@ import (
 )
 
 func TestNewServerSettings(t *testing.T) {
-	_, err := NewServerSettings("", "")
+	_, err := NewServerSettings("", "", "", false)
 	assert.Error(t, err)
 
-	_, err = NewServerSettings(getTLSFile("server-cert.pem"), getTLSFile("server-key.pem"))
+	_, err = NewServerSettings(getTLSFile("server-cert.pem"), getTLSFile("server-key.pem"), "", true)
+	assert.Error(t, err)
+
+	_, err = NewServerSettings(getTLSFile("server-cert.pem"), getTLSFile("server-key.pem"), "", false)
 	assert.NoError(t, err)
+
+	s, err := NewServerSettings(getTLSFile("server-cert.pem"), getTLSFile("server-key.pem"), getTLSFile("ca-cert.pem"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, getTLSFile("ca-cert.pem"), s.ClientCACertPath)
+	assert.True(t, s.RequireClientCert)
 }
 
 func TestServerSettingsLoad(t *testing.T) {
-	s, err := NewServerSettings("/tmp/foo", "/tmp/bar")
+	s, err := NewServerSettings("/tmp/foo", "/tmp/bar", "", false)
 	assert.NoError(t, err)
 
 	_, err = s.Load()
 	assert.Error(t, err)
 
-	s, err = NewServerSettings(getTLSFile("server-cert.pem"), getTLSFile("server-key.pem"))
+	s, err = NewServerSettings(getTLSFile("server-cert.pem"), getTLSFile("server-key.pem"), "", false)
+	assert.NoError(t, err)
+
+	_, err = s.Load()
+	assert.NoError(t, err)
+
+	s, err = NewServerSettings(getTLSFile("server-cert.pem"), getTLSFile("server-key.pem"), "/tmp/foo", false)
+	assert.NoError(t, err)
+
+	_, err = s.Load()
+	assert.Error(t, err)
+
+	s, err = NewServerSettings(getTLSFile("server-cert.pem"), getTLSFile("server-key.pem"), getTLSFile("ca-cert.pem"), false)
 	assert.NoError(t, err)
 
 	_, err = s.Load()
@@ -41,6 +61,14 @@ func TestLoadCACert(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestLoadClientCert(t *testing.T) {
+	_, err := LoadClientCert(getTLSFile("ca-cert.pem"), "", "/tmp/foo", "/tmp/bar")
+	assert.Error(t, err)
+
+	_, err = LoadClientCert(getTLSFile("ca-cert.pem"), "", getTLSFile("server-cert.pem"), getTLSFile("server-key.pem"))
+	assert.NoError(t, err)
+}
+
 func getTLSFile(fileName string) string {
 	_, this, _, _ := runtime.Caller(0)
 	tlsRoot := filepath.Join(this, "../../../../tls")
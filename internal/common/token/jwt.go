@@ -15,21 +15,45 @@ var ErrInvalidToken = errors.New("invalid token")
 
 // NewJWTForUser creates new JWT token for user.
 //
+// If certSubject is not empty, token is bound to client certificate with this subject common name.
+//
 // In case of error returns specific JWT error.
-func NewJWTForUser(userID int64, expirationInterval time.Duration, secret []byte) (string, error) {
+func NewJWTForUser(userID int64, certSubject string, expirationInterval time.Duration, secret []byte) (string, error) {
+	now := time.Now().UTC()
 	claims := &jwt.RegisteredClaims{
 		ID:        strconv.FormatInt(userID, 10),
-		ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(expirationInterval)),
+		Subject:   certSubject,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(expirationInterval)),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(secret)
 }
 
-// GetUserFromJWT get user id as string from token.
+// Claims represents user data from token.
+type Claims struct {
+	UserID      string
+	CertSubject string
+	IssuedAt    time.Time
+}
+
+// GetUserFromJWT get user id as string and bound client certificate subject from token.
+//
+// If token invalid or expired, returns ErrInvalidToken error.
+func GetUserFromJWT(tokenString string, secret []byte) (string, string, error) {
+	claims, err := ParseJWT(tokenString, secret)
+	if err != nil {
+		return "", "", err
+	}
+
+	return claims.UserID, claims.CertSubject, nil
+}
+
+// ParseJWT get user claims from token. Token without issue time has zero IssuedAt.
 //
 // If token invalid or expired, returns ErrInvalidToken error.
-func GetUserFromJWT(tokenString string, secret []byte) (string, error) {
+func ParseJWT(tokenString string, secret []byte) (*Claims, error) {
 	var token *jwt.Token
 	var err error
 
@@ -47,12 +71,18 @@ func GetUserFromJWT(tokenString string, secret []byte) (string, error) {
 	)
 
 	if err != nil {
-		return "", ErrInvalidToken
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*jwt.RegisteredClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidToken
 	}
 
-	if claims, ok := token.Claims.(*jwt.RegisteredClaims); ok && token.Valid {
-		return claims.ID, nil
+	result := &Claims{UserID: claims.ID, CertSubject: claims.Subject}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Time
 	}
 
-	return "", ErrInvalidToken
+	return result, nil
 }
@@ -13,27 +13,36 @@ func TestJWTToken(t *testing.T) {
 	userID := int64(1)
 
 	for _, tt := range []struct {
-		name  string
-		exp   time.Duration
-		isErr bool
+		name        string
+		certSubject string
+		exp         time.Duration
+		isErr       bool
 	}{
 		{name: "valid token", exp: 100 * time.Second},
+		{name: "valid token bound to certificate", certSubject: "user", exp: 100 * time.Second},
 		{name: "expired token", exp: 1 * time.Second, isErr: true},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			token, err := NewJWTForUser(userID, tt.exp, secret)
+			issued := time.Now().Truncate(time.Second)
+			token, err := NewJWTForUser(userID, tt.certSubject, tt.exp, secret)
 			assert.NoError(t, err)
 			assert.NotEqual(t, "", token)
 
 			time.Sleep(2 * time.Second)
 
-			tokenUserID, err := GetUserFromJWT(token, secret)
+			tokenUserID, tokenCertSubject, err := GetUserFromJWT(token, secret)
 			if tt.isErr {
 				assert.ErrorIs(t, err, ErrInvalidToken)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, strconv.FormatInt(userID, 10), tokenUserID)
+				assert.Equal(t, tt.certSubject, tokenCertSubject)
+
+				claims, err := ParseJWT(token, secret)
+				assert.NoError(t, err)
+				assert.Equal(t, tokenUserID, claims.UserID)
+				assert.False(t, claims.IssuedAt.Before(issued))
 			}
 		})
 	}
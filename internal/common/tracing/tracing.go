@@ -0,0 +1,162 @@
+// Package tracing contains OpenTelemetry tracing setup and W3C trace context propagation over gRPC metadata.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// ExporterStdout - spans are written as JSON to writer, given to Init.
+	ExporterStdout = "stdout"
+	// ExporterOTLP - spans are sent to OTLP gRPC collector.
+	ExporterOTLP = "otlp"
+)
+
+var ErrUnknownExporter = errors.New("unknown tracing exporter")
+
+// Settings represents tracing settings.
+type Settings struct {
+	// Exporter - spans exporter, ExporterStdout or ExporterOTLP.
+	Exporter string
+
+	// OTLPEndpoint - address of OTLP gRPC collector.
+	// If empty, OTEL_EXPORTER_OTLP_ENDPOINT env or "localhost:4317" is used.
+	OTLPEndpoint string
+}
+
+// NewSettings creates new Settings object.
+//
+// Returns settings or error:
+//
+// - ErrUnknownExporter - exporter is not ExporterStdout or ExporterOTLP.
+func NewSettings(exporter, otlpEndpoint string) (*Settings, error) {
+	if exporter != ExporterStdout && exporter != ExporterOTLP {
+		return nil, ErrUnknownExporter
+	}
+
+	return &Settings{Exporter: exporter, OTLPEndpoint: otlpEndpoint}, nil
+}
+
+// Init creates tracer provider with exporter from settings and sets it as global one.
+// Export errors are written to logger.
+//
+// Accepts settings, service name and writer for ExporterStdout.
+// Returns tracer provider, which should be shut down to flush spans, or exporter error.
+func Init(
+	ctx context.Context,
+	settings *Settings,
+	serviceName string,
+	w io.Writer,
+	logger *logrus.Logger,
+) (*sdktrace.TracerProvider, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	switch settings.Exporter {
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	case ExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if settings.OTLPEndpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(settings.OTLPEndpoint))
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, ErrUnknownExporter
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+
+	otel.SetTracerProvider(tp)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Errorf("tracing error: %v", err)
+	}))
+
+	return tp, nil
+}
+
+// InjectMetadata adds trace context of ctx to outgoing gRPC metadata.
+func InjectMetadata(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+
+	propagation.TraceContext{}.Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// ExtractMetadata returns context with remote trace context from incoming gRPC metadata.
+func ExtractMetadata(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	return propagation.TraceContext{}.Extract(ctx, metadataCarrier(md))
+}
+
+// EndSpan sets span error status, if err not nil, and ends span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// metadataCarrier adapts gRPC metadata to propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+var _ propagation.TextMapCarrier = metadataCarrier{}
+
+func (c metadataCarrier) Get(key string) string {
+	vals := metadata.MD(c).Get(key)
+	if len(vals) == 0 {
+		return ""
+	}
+
+	return vals[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNewSettings(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		exporter string
+		err      error
+	}{
+		{name: "stdout", exporter: ExporterStdout},
+		{name: "otlp", exporter: ExporterOTLP},
+		{name: "empty", exporter: "", err: ErrUnknownExporter},
+		{name: "unknown", exporter: "jaeger", err: ErrUnknownExporter},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := NewSettings(tt.exporter, "localhost:4317")
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, &Settings{Exporter: tt.exporter, OTLPEndpoint: "localhost:4317"}, settings)
+		})
+	}
+}
+
+func TestInitStdout(t *testing.T) {
+	buf := &bytes.Buffer{}
+	settings, err := NewSettings(ExporterStdout, "")
+	require.NoError(t, err)
+
+	tp, err := Init(context.Background(), settings, "test-service", buf, logrus.New())
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "test-span")
+	span.End()
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	assert.Contains(t, buf.String(), `"Name":"test-span"`)
+	assert.Contains(t, buf.String(), `"Value":"test-service"`)
+}
+
+func TestPropagation(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	ctx, span := tp.Tracer("test").Start(context.Background(), "client")
+	outCtx := InjectMetadata(metadata.AppendToOutgoingContext(ctx, "token", "123"))
+	span.End()
+
+	md, ok := metadata.FromOutgoingContext(outCtx)
+	require.True(t, ok)
+	assert.Equal(t, []string{"123"}, md.Get("token"))
+	assert.Len(t, md.Get("traceparent"), 1)
+
+	inCtx := ExtractMetadata(metadata.NewIncomingContext(context.Background(), md))
+	remote := trace.SpanContextFromContext(inCtx)
+	assert.True(t, remote.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+
+	// Without metadata context is not changed
+	assert.False(t, trace.SpanContextFromContext(ExtractMetadata(context.Background())).IsValid())
+}
+
+func TestEndSpan(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	_, span := tp.Tracer("test").Start(context.Background(), "ok")
+	EndSpan(span, nil)
+	_, span = tp.Tracer("test").Start(context.Background(), "failed")
+	EndSpan(span, errors.New("test error"))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "test error", spans[1].Status().Description)
+	assert.Len(t, spans[1].Events(), 1)
+}
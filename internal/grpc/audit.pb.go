@@ -0,0 +1,417 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.12.4
+// source: internal/grpc/proto/audit.proto
+
+package grpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AuditEventType int32
+
+const (
+	AuditEventType_AUDIT_EVENT_UNKNOWN AuditEventType = 0
+	AuditEventType_AUDIT_USER_CREATE   AuditEventType = 1
+	AuditEventType_AUDIT_USER_LOGIN    AuditEventType = 2
+	AuditEventType_AUDIT_SECRET_READ   AuditEventType = 3
+	AuditEventType_AUDIT_SECRET_CREATE AuditEventType = 4
+	AuditEventType_AUDIT_SECRET_UPDATE AuditEventType = 5
+	AuditEventType_AUDIT_SECRET_DELETE AuditEventType = 6
+	AuditEventType_AUDIT_SHARE_CREATE  AuditEventType = 7
+	AuditEventType_AUDIT_SHARE_REDEEM  AuditEventType = 8
+)
+
+// Enum value maps for AuditEventType.
+var (
+	AuditEventType_name = map[int32]string{
+		0: "AUDIT_EVENT_UNKNOWN",
+		1: "AUDIT_USER_CREATE",
+		2: "AUDIT_USER_LOGIN",
+		3: "AUDIT_SECRET_READ",
+		4: "AUDIT_SECRET_CREATE",
+		5: "AUDIT_SECRET_UPDATE",
+		6: "AUDIT_SECRET_DELETE",
+		7: "AUDIT_SHARE_CREATE",
+		8: "AUDIT_SHARE_REDEEM",
+	}
+	AuditEventType_value = map[string]int32{
+		"AUDIT_EVENT_UNKNOWN": 0,
+		"AUDIT_USER_CREATE":   1,
+		"AUDIT_USER_LOGIN":    2,
+		"AUDIT_SECRET_READ":   3,
+		"AUDIT_SECRET_CREATE": 4,
+		"AUDIT_SECRET_UPDATE": 5,
+		"AUDIT_SECRET_DELETE": 6,
+		"AUDIT_SHARE_CREATE":  7,
+		"AUDIT_SHARE_REDEEM":  8,
+	}
+)
+
+func (x AuditEventType) Enum() *AuditEventType {
+	p := new(AuditEventType)
+	*p = x
+	return p
+}
+
+func (x AuditEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuditEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_grpc_proto_audit_proto_enumTypes[0].Descriptor()
+}
+
+func (AuditEventType) Type() protoreflect.EnumType {
+	return &file_internal_grpc_proto_audit_proto_enumTypes[0]
+}
+
+func (x AuditEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuditEventType.Descriptor instead.
+func (AuditEventType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_audit_proto_rawDescGZIP(), []int{0}
+}
+
+type AuditEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventType   AuditEventType `protobuf:"varint,1,opt,name=event_type,json=eventType,proto3,enum=proto.AuditEventType" json:"event_type,omitempty"`
+	SecretName  string         `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"` // set for secret events only
+	PeerAddress string         `protobuf:"bytes,3,opt,name=peer_address,json=peerAddress,proto3" json:"peer_address,omitempty"`
+	UserAgent   string         `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Outcome     string         `protobuf:"bytes,5,opt,name=outcome,proto3" json:"outcome,omitempty"`                       // gRPC status code name, "OK" on success
+	CreatedAt   int64          `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // unix time of event
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_grpc_proto_audit_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_grpc_proto_audit_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditEvent) GetEventType() AuditEventType {
+	if x != nil {
+		return x.EventType
+	}
+	return AuditEventType_AUDIT_EVENT_UNKNOWN
+}
+
+func (x *AuditEvent) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetPeerAddress() string {
+	if x != nil {
+		return x.PeerAddress
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type UserGetAuditLogRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From int64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"` // unix time, events from, not limited if 0
+	To   int64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`     // unix time, events to, not limited if 0
+}
+
+func (x *UserGetAuditLogRequest) Reset() {
+	*x = UserGetAuditLogRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_grpc_proto_audit_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserGetAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserGetAuditLogRequest) ProtoMessage() {}
+
+func (x *UserGetAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_grpc_proto_audit_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserGetAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*UserGetAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserGetAuditLogRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *UserGetAuditLogRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type UserGetAuditLogResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*AuditEvent `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *UserGetAuditLogResponse) Reset() {
+	*x = UserGetAuditLogResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_grpc_proto_audit_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserGetAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserGetAuditLogResponse) ProtoMessage() {}
+
+func (x *UserGetAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_grpc_proto_audit_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserGetAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*UserGetAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_audit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserGetAuditLogResponse) GetItems() []*AuditEvent {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_internal_grpc_proto_audit_proto protoreflect.FileDescriptor
+
+var file_internal_grpc_proto_audit_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xde, 0x01, 0x0a, 0x0a, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x3c, 0x0a, 0x16, 0x55, 0x73, 0x65,
+	0x72, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x42, 0x0a, 0x17, 0x55, 0x73, 0x65, 0x72, 0x47,
+	0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x2a, 0xe8, 0x01, 0x0a, 0x0e,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17,
+	0x0a, 0x13, 0x41, 0x55, 0x44, 0x49, 0x54, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x55, 0x44, 0x49, 0x54,
+	0x5f, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x14,
+	0x0a, 0x10, 0x41, 0x55, 0x44, 0x49, 0x54, 0x5f, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x4c, 0x4f, 0x47,
+	0x49, 0x4e, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x41, 0x55, 0x44, 0x49, 0x54, 0x5f, 0x53, 0x45,
+	0x43, 0x52, 0x45, 0x54, 0x5f, 0x52, 0x45, 0x41, 0x44, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x41,
+	0x55, 0x44, 0x49, 0x54, 0x5f, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x43, 0x52, 0x45, 0x41,
+	0x54, 0x45, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x55, 0x44, 0x49, 0x54, 0x5f, 0x53, 0x45,
+	0x43, 0x52, 0x45, 0x54, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x05, 0x12, 0x17, 0x0a,
+	0x13, 0x41, 0x55, 0x44, 0x49, 0x54, 0x5f, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x44, 0x45,
+	0x4c, 0x45, 0x54, 0x45, 0x10, 0x06, 0x12, 0x16, 0x0a, 0x12, 0x41, 0x55, 0x44, 0x49, 0x54, 0x5f,
+	0x53, 0x48, 0x41, 0x52, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x07, 0x12, 0x16,
+	0x0a, 0x12, 0x41, 0x55, 0x44, 0x49, 0x54, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x45, 0x5f, 0x52, 0x45,
+	0x44, 0x45, 0x45, 0x4d, 0x10, 0x08, 0x42, 0x0f, 0x5a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_grpc_proto_audit_proto_rawDescOnce sync.Once
+	file_internal_grpc_proto_audit_proto_rawDescData = file_internal_grpc_proto_audit_proto_rawDesc
+)
+
+func file_internal_grpc_proto_audit_proto_rawDescGZIP() []byte {
+	file_internal_grpc_proto_audit_proto_rawDescOnce.Do(func() {
+		file_internal_grpc_proto_audit_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_grpc_proto_audit_proto_rawDescData)
+	})
+	return file_internal_grpc_proto_audit_proto_rawDescData
+}
+
+var file_internal_grpc_proto_audit_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_internal_grpc_proto_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_internal_grpc_proto_audit_proto_goTypes = []interface{}{
+	(AuditEventType)(0),             // 0: proto.AuditEventType
+	(*AuditEvent)(nil),              // 1: proto.AuditEvent
+	(*UserGetAuditLogRequest)(nil),  // 2: proto.UserGetAuditLogRequest
+	(*UserGetAuditLogResponse)(nil), // 3: proto.UserGetAuditLogResponse
+}
+var file_internal_grpc_proto_audit_proto_depIdxs = []int32{
+	0, // 0: proto.AuditEvent.event_type:type_name -> proto.AuditEventType
+	1, // 1: proto.UserGetAuditLogResponse.items:type_name -> proto.AuditEvent
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_internal_grpc_proto_audit_proto_init() }
+func file_internal_grpc_proto_audit_proto_init() {
+	if File_internal_grpc_proto_audit_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_grpc_proto_audit_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_grpc_proto_audit_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserGetAuditLogRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_grpc_proto_audit_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserGetAuditLogResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_grpc_proto_audit_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_internal_grpc_proto_audit_proto_goTypes,
+		DependencyIndexes: file_internal_grpc_proto_audit_proto_depIdxs,
+		EnumInfos:         file_internal_grpc_proto_audit_proto_enumTypes,
+		MessageInfos:      file_internal_grpc_proto_audit_proto_msgTypes,
+	}.Build()
+	File_internal_grpc_proto_audit_proto = out.File
+	file_internal_grpc_proto_audit_proto_rawDesc = nil
+	file_internal_grpc_proto_audit_proto_goTypes = nil
+	file_internal_grpc_proto_audit_proto_depIdxs = nil
+}
@@ -0,0 +1,169 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
+	gkTLS "github.com/devldavydov/gophkeeper/internal/common/tls"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	MetaAuthorization = "authorization"
+
+	_basicAuthPrefix = "Basic "
+)
+
+// AdminAuthInterceptor represents interceptor to authenticate administrator requests.
+//
+// Administrator is authenticated with verified client certificate, which subject common name or
+// DNS/email SAN is in allowed names, or with login and password in request basic authorization metadata,
+// if login is set.
+type AdminAuthInterceptor struct {
+	login     string
+	password  string
+	certNames map[string]struct{}
+}
+
+var _ Interceptor = (*AdminAuthInterceptor)(nil)
+
+// NewAdminAuthInterceptor creates new AdminAuthInterceptor object.
+//
+// If login is empty, password authentication is disabled.
+// If certNames is empty, client certificate authentication is disabled. Else client certificate,
+// verified by server, authenticates administrator only if it is issued to one of certNames.
+func NewAdminAuthInterceptor(login, password string, certNames []string) *AdminAuthInterceptor {
+	names := make(map[string]struct{}, len(certNames))
+	for _, name := range certNames {
+		names[name] = struct{}{}
+	}
+
+	return &AdminAuthInterceptor{login: login, password: password, certNames: names}
+}
+
+// Handle implements administrator authentication for request.
+//
+// If administrator is not authenticated, returns PermissionDenied gRPC code.
+// Else adds administrator name to request logger and calls request handler.
+func (a *AdminAuthInterceptor) Handle(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	authCtx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(authCtx, req)
+}
+
+// HandleStream implements administrator authentication for stream in the same way as Handle.
+func (a *AdminAuthInterceptor) HandleStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	authCtx, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: authCtx})
+}
+
+// authenticate validates client certificate or basic credentials from context.
+//
+// Returns context with administrator name in request logger or PermissionDenied gRPC code.
+func (a *AdminAuthInterceptor) authenticate(ctx context.Context) (context.Context, error) {
+	if len(a.certNames) > 0 {
+		if cert, ok := gkTLS.PeerCertificate(ctx); ok {
+			if name, ok := a.checkCertificate(cert); ok {
+				return gkLog.ContextWithFields(ctx, logrus.Fields{gkLog.FieldAdmin: name}), nil
+			}
+		}
+	}
+
+	if a.login != "" {
+		if login, password, ok := basicCredentialsFromContext(ctx); ok && a.checkCredentials(login, password) {
+			return gkLog.ContextWithFields(ctx, logrus.Fields{gkLog.FieldAdmin: login}), nil
+		}
+	}
+
+	return nil, status.Error(codes.PermissionDenied, "forbidden")
+}
+
+// checkCertificate finds allowed name in certificate subject common name, DNS and email SANs.
+func (a *AdminAuthInterceptor) checkCertificate(cert *x509.Certificate) (string, bool) {
+	names := append([]string{cert.Subject.CommonName}, cert.DNSNames...)
+	names = append(names, cert.EmailAddresses...)
+	for _, name := range names {
+		if _, ok := a.certNames[name]; ok && name != "" {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// checkCredentials compares credentials in constant time.
+func (a *AdminAuthInterceptor) checkCredentials(login, password string) bool {
+	loginOK := subtle.ConstantTimeCompare([]byte(login), []byte(a.login))
+	passwordOK := subtle.ConstantTimeCompare([]byte(password), []byte(a.password))
+	return loginOK&passwordOK == 1
+}
+
+// basicCredentialsFromContext gets login and password from basic authorization metadata.
+func basicCredentialsFromContext(ctx context.Context) (string, string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", "", false
+	}
+
+	values := md.Get(MetaAuthorization)
+	if len(values) != 1 || !strings.HasPrefix(values[0], _basicAuthPrefix) {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(values[0], _basicAuthPrefix))
+	if err != nil {
+		return "", "", false
+	}
+
+	return strings.Cut(string(decoded), ":")
+}
+
+// BasicCredentials represents per-request basic authorization credentials of gRPC client.
+type BasicCredentials struct {
+	login    string
+	password string
+}
+
+var _ credentials.PerRPCCredentials = (*BasicCredentials)(nil)
+
+// NewBasicCredentials creates new BasicCredentials object.
+func NewBasicCredentials(login, password string) *BasicCredentials {
+	return &BasicCredentials{login: login, password: password}
+}
+
+// GetRequestMetadata returns basic authorization metadata.
+func (b *BasicCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	encoded := base64.StdEncoding.EncodeToString([]byte(b.login + ":" + b.password))
+	return map[string]string{MetaAuthorization: _basicAuthPrefix + encoded}, nil
+}
+
+// RequireTransportSecurity requires TLS connection, credentials are not sent in plain text.
+func (b *BasicCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,147 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminAuthInterceptor(t *testing.T) {
+	fnCredsContext := func(login, password string) context.Context {
+		md, err := NewBasicCredentials(login, password).GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		return metadata.NewIncomingContext(context.Background(), metadata.New(md))
+	}
+
+	fnCertContext := func(cert *x509.Certificate) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+		}})
+	}
+	certContext := fnCertContext(&x509.Certificate{Subject: pkix.Name{CommonName: "operator"}})
+	certNames := []string{"operator", "admin.example.com"}
+
+	for _, tt := range []struct {
+		name     string
+		auth     *AdminAuthInterceptor
+		ctx      context.Context
+		expAdmin string
+		expErr   bool
+	}{
+		{
+			name:     "valid credentials",
+			auth:     NewAdminAuthInterceptor("admin", "secret", nil),
+			ctx:      fnCredsContext("admin", "secret"),
+			expAdmin: "admin",
+		},
+		{
+			name:   "wrong password",
+			auth:   NewAdminAuthInterceptor("admin", "secret", nil),
+			ctx:    fnCredsContext("admin", "foobar"),
+			expErr: true,
+		},
+		{
+			name:   "wrong login",
+			auth:   NewAdminAuthInterceptor("admin", "secret", nil),
+			ctx:    fnCredsContext("root", "secret"),
+			expErr: true,
+		},
+		{
+			name: "invalid authorization",
+			auth: NewAdminAuthInterceptor("admin", "secret", nil),
+			ctx: metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs(MetaAuthorization, "Basic foobar")),
+			expErr: true,
+		},
+		{
+			name:   "no credentials",
+			auth:   NewAdminAuthInterceptor("admin", "secret", certNames),
+			ctx:    context.Background(),
+			expErr: true,
+		},
+		{
+			name:   "password authentication disabled",
+			auth:   NewAdminAuthInterceptor("", "", certNames),
+			ctx:    fnCredsContext("", ""),
+			expErr: true,
+		},
+		{
+			name:     "client certificate",
+			auth:     NewAdminAuthInterceptor("", "", certNames),
+			ctx:      certContext,
+			expAdmin: "operator",
+		},
+		{
+			name: "client certificate with allowed SAN",
+			auth: NewAdminAuthInterceptor("", "", certNames),
+			ctx: fnCertContext(&x509.Certificate{
+				Subject:  pkix.Name{CommonName: "node-1"},
+				DNSNames: []string{"node-1.example.com", "admin.example.com"},
+			}),
+			expAdmin: "admin.example.com",
+		},
+		{
+			name: "client certificate not in allowed names",
+			auth: NewAdminAuthInterceptor("", "", certNames),
+			ctx: fnCertContext(&x509.Certificate{
+				Subject:        pkix.Name{CommonName: "john"},
+				DNSNames:       []string{"john.example.com"},
+				EmailAddresses: []string{"john@example.com"},
+			}),
+			expErr: true,
+		},
+		{
+			name:   "client certificate without subject",
+			auth:   NewAdminAuthInterceptor("", "", []string{""}),
+			ctx:    fnCertContext(&x509.Certificate{}),
+			expErr: true,
+		},
+		{
+			name:   "client certificate authentication disabled",
+			auth:   NewAdminAuthInterceptor("admin", "secret", nil),
+			ctx:    certContext,
+			expErr: true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := gkLog.ContextWithLogger(tt.ctx, logrus.NewEntry(logrus.New()))
+			_, err := tt.auth.Handle(
+				ctx,
+				nil,
+				&grpc.UnaryServerInfo{FullMethod: _testMethod},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					assert.Equal(t, tt.expAdmin, gkLog.FromContext(ctx, logrus.New()).Data[gkLog.FieldAdmin])
+					return nil, nil
+				})
+			if tt.expErr {
+				assert.Equal(t, codes.PermissionDenied, status.Code(err))
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestBasicCredentials(t *testing.T) {
+	creds := NewBasicCredentials("admin", "secret")
+	assert.True(t, creds.RequireTransportSecurity())
+
+	md, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{MetaAuthorization: "Basic YWRtaW46c2VjcmV0"}, md)
+}
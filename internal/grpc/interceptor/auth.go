@@ -3,8 +3,12 @@ package interceptor
 
 import (
 	"context"
+	"time"
 
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
+	gkTLS "github.com/devldavydov/gophkeeper/internal/common/tls"
 	"github.com/devldavydov/gophkeeper/internal/common/token"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -13,19 +17,32 @@ import (
 
 const MetaUserID = "USER_ID"
 
+// UserChecker checks that user from valid token is still allowed to make requests,
+// e.g. user is not disabled and token is not revoked.
+//
+// To reject request, returns gRPC status error.
+type UserChecker func(ctx context.Context, userID string, issuedAt time.Time) error
+
 // AuthTokenInterceptor represents interceptor to validate requests against token.
 type AuthTokenInterceptor struct {
 	protectedMethods map[string]bool
 	serverSecret     []byte
+	userChecker      UserChecker
 }
 
 // NewAuthTokenInterceptor creates new AuthTokenInterceptor object.
-func NewAuthTokenInterceptor(protectedMethods []string, serverSecret []byte) *AuthTokenInterceptor {
+//
+// If userChecker is nil, token validation is enough to authenticate request.
+func NewAuthTokenInterceptor(
+	protectedMethods []string,
+	serverSecret []byte,
+	userChecker UserChecker,
+) *AuthTokenInterceptor {
 	pm := make(map[string]bool, len(protectedMethods))
 	for _, p := range protectedMethods {
 		pm[p] = true
 	}
-	return &AuthTokenInterceptor{protectedMethods: pm, serverSecret: serverSecret}
+	return &AuthTokenInterceptor{protectedMethods: pm, serverSecret: serverSecret, userChecker: userChecker}
 }
 
 // Handle implements authentication for request.
@@ -33,7 +50,12 @@ func NewAuthTokenInterceptor(protectedMethods []string, serverSecret []byte) *Au
 // If incoming request does not containt metadata with token
 // or token invalid, returns PersmissionDenied gRPC code.
 //
-// Else retrieves user id from token, adds token to request context and calls request handler.
+// If token is bound to client certificate, request should be made with the same certificate.
+//
+// If user checker rejects user, returns its error.
+//
+// Else retrieves user id from token, adds it to request context metadata and request logger
+// and calls request handler.
 func (a *AuthTokenInterceptor) Handle(
 	ctx context.Context,
 	req interface{},
@@ -44,6 +66,37 @@ func (a *AuthTokenInterceptor) Handle(
 		return handler(ctx, req)
 	}
 
+	authCtx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(authCtx, req)
+}
+
+// HandleStream implements authentication for stream in the same way as Handle.
+func (a *AuthTokenInterceptor) HandleStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if !a.protectedMethods[info.FullMethod] {
+		return handler(srv, ss)
+	}
+
+	authCtx, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: authCtx})
+}
+
+// authenticate validates token from context metadata.
+//
+// Returns context with user id in metadata or PermissionDenied gRPC code.
+func (a *AuthTokenInterceptor) authenticate(ctx context.Context) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.PermissionDenied, "forbidden")
@@ -54,13 +107,28 @@ func (a *AuthTokenInterceptor) Handle(
 		return nil, status.Error(codes.PermissionDenied, "forbidden")
 	}
 
-	userID, err := token.GetUserFromJWT(tokens[0], a.serverSecret)
+	claims, err := token.ParseJWT(tokens[0], a.serverSecret)
 	if err != nil {
 		return nil, status.Error(codes.PermissionDenied, "forbidden")
 	}
+	userID := claims.UserID
+
+	// Token, issued with client certificate, is valid only with the same certificate
+	if claims.CertSubject != "" {
+		if peerSubject, ok := gkTLS.PeerCommonName(ctx); !ok || peerSubject != claims.CertSubject {
+			return nil, status.Error(codes.PermissionDenied, "forbidden")
+		}
+	}
+
+	if a.userChecker != nil {
+		if err = a.userChecker(ctx, userID, claims.IssuedAt); err != nil {
+			return nil, err
+		}
+	}
 
 	newMD := md.Copy()
 	newMD.Append(MetaUserID, userID)
 
-	return handler(metadata.NewIncomingContext(ctx, newMD), req)
+	ctx = gkLog.ContextWithFields(ctx, logrus.Fields{gkLog.FieldUserID: userID})
+	return metadata.NewIncomingContext(ctx, newMD), nil
 }
@@ -0,0 +1,144 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
+	"github.com/devldavydov/gophkeeper/internal/common/token"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthTokenInterceptor(t *testing.T) {
+	secret := []byte("secret")
+	auth := NewAuthTokenInterceptor([]string{_testMethod}, secret, nil)
+
+	tokenUnbound, err := token.NewJWTForUser(1, "", time.Minute, secret)
+	require.NoError(t, err)
+	tokenBound, err := token.NewJWTForUser(1, "alice", time.Minute, secret)
+	require.NoError(t, err)
+
+	fnContext := func(tokenString, certSubject string) context.Context {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(token.HeaderName, tokenString))
+		if certSubject == "" {
+			return ctx
+		}
+
+		return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: certSubject}}}},
+			},
+		}})
+	}
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		ctx    context.Context
+		expErr bool
+	}{
+		{name: "not protected", method: "/test/NotProtected", ctx: context.Background()},
+		{name: "no token", method: _testMethod, ctx: context.Background(), expErr: true},
+		{name: "invalid token", method: _testMethod, ctx: fnContext("foobar", ""), expErr: true},
+		{name: "token", method: _testMethod, ctx: fnContext(tokenUnbound, "")},
+		{name: "token with cert", method: _testMethod, ctx: fnContext(tokenUnbound, "bob")},
+		{name: "bound token without cert", method: _testMethod, ctx: fnContext(tokenBound, ""), expErr: true},
+		{name: "bound token with other cert", method: _testMethod, ctx: fnContext(tokenBound, "bob"), expErr: true},
+		{name: "bound token with cert", method: _testMethod, ctx: fnContext(tokenBound, "alice")},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.Handle(
+				tt.ctx,
+				nil,
+				&grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+			if tt.expErr {
+				assert.Equal(t, codes.PermissionDenied, status.Code(err))
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthTokenInterceptorLogger(t *testing.T) {
+	secret := []byte("secret")
+	tokenString, err := token.NewJWTForUser(1, "", time.Minute, secret)
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(token.HeaderName, tokenString))
+	ctx = gkLog.ContextWithLogger(ctx, logrus.New().WithField(gkLog.FieldRequestID, "req1"))
+
+	_, err = NewAuthTokenInterceptor([]string{_testMethod}, secret, nil).Handle(
+		ctx,
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: _testMethod},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			assert.Equal(t,
+				logrus.Fields{gkLog.FieldRequestID: "req1", gkLog.FieldUserID: "1"},
+				gkLog.FromContext(ctx, logrus.New()).Data)
+			return nil, nil
+		})
+	assert.NoError(t, err)
+}
+
+func TestAuthTokenInterceptorUserChecker(t *testing.T) {
+	secret := []byte("secret")
+	tokenString, err := token.NewJWTForUser(1, "", time.Minute, secret)
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(token.HeaderName, tokenString))
+
+	for _, tt := range []struct {
+		name    string
+		checker UserChecker
+		expCode codes.Code
+	}{
+		{
+			name: "allowed",
+			checker: func(ctx context.Context, userID string, issuedAt time.Time) error {
+				assert.Equal(t, "1", userID)
+				assert.False(t, issuedAt.IsZero())
+				return nil
+			},
+			expCode: codes.OK,
+		},
+		{
+			name: "rejected",
+			checker: func(ctx context.Context, userID string, issuedAt time.Time) error {
+				return status.Error(codes.PermissionDenied, "disabled")
+			},
+			expCode: codes.PermissionDenied,
+		},
+		{
+			name: "checker error",
+			checker: func(ctx context.Context, userID string, issuedAt time.Time) error {
+				return status.Error(codes.Internal, "internal")
+			},
+			expCode: codes.Internal,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAuthTokenInterceptor([]string{_testMethod}, secret, tt.checker).Handle(
+				ctx,
+				nil,
+				&grpc.UnaryServerInfo{FullMethod: _testMethod},
+				func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+			assert.Equal(t, tt.expCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package interceptor
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// InFlightInterceptor represents server interceptor, tracking requests in progress,
+// to wait for their handlers on server stop.
+type InFlightInterceptor struct {
+	mu    sync.Mutex
+	count int
+	idle  chan struct{}
+}
+
+var _ Interceptor = (*InFlightInterceptor)(nil)
+
+// NewInFlightInterceptor creates new InFlightInterceptor object.
+func NewInFlightInterceptor() *InFlightInterceptor {
+	return &InFlightInterceptor{}
+}
+
+// Handle tracks unary request handler.
+func (i *InFlightInterceptor) Handle(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	i.begin()
+	defer i.end()
+
+	return handler(ctx, req)
+}
+
+// HandleStream tracks stream handler.
+func (i *InFlightInterceptor) HandleStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	i.begin()
+	defer i.end()
+
+	return handler(srv, ss)
+}
+
+// Count returns number of handlers in progress.
+func (i *InFlightInterceptor) Count() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.count
+}
+
+// Wait waits for handlers in progress to finish. Returns context error, if context is done before.
+func (i *InFlightInterceptor) Wait(ctx context.Context) error {
+	i.mu.Lock()
+	if i.count == 0 {
+		i.mu.Unlock()
+		return nil
+	}
+	idle := i.idle
+	i.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (i *InFlightInterceptor) begin() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.count == 0 {
+		i.idle = make(chan struct{})
+	}
+	i.count++
+}
+
+func (i *InFlightInterceptor) end() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.count--
+	if i.count == 0 {
+		close(i.idle)
+	}
+}
@@ -0,0 +1,57 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestInFlightInterceptor(t *testing.T) {
+	inFlight := NewInFlightInterceptor()
+	assert.NoError(t, inFlight.Wait(context.Background()))
+
+	releaseUnary, releaseStream := make(chan struct{}), make(chan struct{})
+	started := make(chan struct{}, 2)
+
+	go func() {
+		_, _ = inFlight.Handle(
+			context.Background(),
+			nil,
+			&grpc.UnaryServerInfo{FullMethod: _testMethod},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				started <- struct{}{}
+				<-releaseUnary
+				return nil, nil
+			})
+	}()
+	go func() {
+		_ = inFlight.HandleStream(
+			nil,
+			&testServerStream{ctx: context.Background()},
+			&grpc.StreamServerInfo{FullMethod: _testMethod},
+			func(srv interface{}, stream grpc.ServerStream) error {
+				started <- struct{}{}
+				<-releaseStream
+				return nil
+			})
+	}()
+	<-started
+	<-started
+	assert.Equal(t, 2, inFlight.Count())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, inFlight.Wait(ctx), context.DeadlineExceeded)
+
+	close(releaseUnary)
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, inFlight.Wait(ctx), context.DeadlineExceeded)
+
+	close(releaseStream)
+	assert.NoError(t, inFlight.Wait(context.Background()))
+	assert.Equal(t, 0, inFlight.Count())
+}
@@ -0,0 +1,27 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// Interceptor is an interface of server interceptor for unary and stream requests.
+type Interceptor interface {
+	// Handle intercepts unary request.
+	Handle(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error)
+	// HandleStream intercepts stream.
+	HandleStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error
+}
+
+var _ Interceptor = (*AuthTokenInterceptor)(nil)
+
+// contextServerStream overrides stream context with the one, created by interceptor.
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,100 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimitInterceptor represents interceptor, limiting number of requests to given methods
+// from one peer host in fixed time window.
+type RateLimitInterceptor struct {
+	methods     map[string]bool
+	limit       int
+	window      time.Duration
+	mu          sync.Mutex
+	windowStart time.Time
+	counts      map[string]int
+}
+
+var _ Interceptor = (*RateLimitInterceptor)(nil)
+
+// NewRateLimitInterceptor creates new RateLimitInterceptor object.
+//
+// Every peer host can make limit requests to methods within window, requests to other methods are not limited.
+func NewRateLimitInterceptor(methods []string, limit int, window time.Duration) *RateLimitInterceptor {
+	m := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		m[method] = true
+	}
+	return &RateLimitInterceptor{methods: m, limit: limit, window: window, counts: make(map[string]int)}
+}
+
+// Handle limits unary requests.
+//
+// If peer host exceeded limit, returns ResourceExhausted gRPC code.
+func (r *RateLimitInterceptor) Handle(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if r.methods[info.FullMethod] && !r.allow(peerHost(ctx)) {
+		return nil, status.Error(codes.ResourceExhausted, "too many requests")
+	}
+
+	return handler(ctx, req)
+}
+
+// HandleStream limits streams in the same way as Handle.
+func (r *RateLimitInterceptor) HandleStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if r.methods[info.FullMethod] && !r.allow(peerHost(ss.Context())) {
+		return status.Error(codes.ResourceExhausted, "too many requests")
+	}
+
+	return handler(srv, ss)
+}
+
+// allow counts request of host in current window. Counters of all hosts are reset, when window is over.
+func (r *RateLimitInterceptor) allow(host string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if now := time.Now(); now.Sub(r.windowStart) >= r.window {
+		r.windowStart = now
+		r.counts = make(map[string]int)
+	}
+
+	if r.counts[host] >= r.limit {
+		return false
+	}
+	r.counts[host]++
+
+	return true
+}
+
+// peerHost returns host of peer address without port, or empty string, if peer is unknown.
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
@@ -0,0 +1,56 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimitInterceptor(t *testing.T) {
+	window := 100 * time.Millisecond
+	rateLimit := NewRateLimitInterceptor([]string{_testMethod}, 2, window)
+
+	fnContext := func(addr string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(addr), Port: 1234}})
+	}
+	fnCall := func(ctx context.Context, method string) error {
+		_, err := rateLimit.Handle(
+			ctx,
+			nil,
+			&grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, nil
+			})
+		return err
+	}
+	fnCallStream := func(ctx context.Context) error {
+		return rateLimit.HandleStream(
+			nil,
+			&testServerStream{ctx: ctx},
+			&grpc.StreamServerInfo{FullMethod: _testMethod},
+			func(srv interface{}, stream grpc.ServerStream) error {
+				return nil
+			})
+	}
+
+	alice, bob := fnContext("10.0.0.1"), fnContext("10.0.0.2")
+
+	assert.NoError(t, fnCall(alice, _testMethod))
+	assert.NoError(t, fnCallStream(alice))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(fnCall(alice, _testMethod)))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(fnCallStream(alice)))
+
+	// Other hosts and methods are not limited
+	assert.NoError(t, fnCall(bob, _testMethod))
+	assert.NoError(t, fnCall(alice, "/test/NotLimited"))
+
+	time.Sleep(window)
+	assert.NoError(t, fnCall(alice, _testMethod))
+}
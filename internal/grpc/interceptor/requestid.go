@@ -0,0 +1,198 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MetaRequestID - metadata key of request ID.
+	MetaRequestID = "x-request-id"
+
+	_requestIDMaxLen = 64
+)
+
+// RequestIDInterceptor represents server interceptor, which adds request logger to request context.
+//
+// Request logger has request ID, received from client in metadata or generated, and method fields.
+// Request ID is returned to client in header.
+type RequestIDInterceptor struct {
+	logger *logrus.Logger
+}
+
+var _ Interceptor = (*RequestIDInterceptor)(nil)
+
+// NewRequestIDInterceptor creates new RequestIDInterceptor object.
+func NewRequestIDInterceptor(logger *logrus.Logger) *RequestIDInterceptor {
+	return &RequestIDInterceptor{logger: logger}
+}
+
+// Handle adds request logger to unary request context.
+func (r *RequestIDInterceptor) Handle(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	requestID := incomingRequestID(ctx)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(MetaRequestID, requestID)); err != nil {
+		r.logger.Warnf("request ID header set error: %v", err)
+	}
+
+	return handler(r.contextWithLogger(ctx, requestID, info.FullMethod), req)
+}
+
+// HandleStream adds request logger to stream context.
+func (r *RequestIDInterceptor) HandleStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	requestID := incomingRequestID(ss.Context())
+	if err := ss.SetHeader(metadata.Pairs(MetaRequestID, requestID)); err != nil {
+		r.logger.Warnf("request ID header set error: %v", err)
+	}
+
+	ctx := r.contextWithLogger(ss.Context(), requestID, info.FullMethod)
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+}
+
+func (r *RequestIDInterceptor) contextWithLogger(ctx context.Context, requestID, method string) context.Context {
+	return gkLog.ContextWithLogger(ctx, r.logger.WithFields(logrus.Fields{
+		gkLog.FieldRequestID: requestID,
+		gkLog.FieldMethod:    method,
+	}))
+}
+
+// RequestIDClientInterceptor represents client interceptor, which sends request ID to server in metadata
+// and logs failed requests with request ID.
+type RequestIDClientInterceptor struct {
+	logger *logrus.Logger
+}
+
+// NewRequestIDClientInterceptor creates new RequestIDClientInterceptor object.
+func NewRequestIDClientInterceptor(logger *logrus.Logger) *RequestIDClientInterceptor {
+	return &RequestIDClientInterceptor{logger: logger}
+}
+
+// Handle sends request ID of unary request.
+func (r *RequestIDClientInterceptor) Handle(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	ctx, requestID := outgoingRequestID(ctx)
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err != nil {
+		r.requestLogger(requestID, method).Errorf("gRPC request error: %v", err)
+	}
+
+	return err
+}
+
+// HandleStream sends request ID of stream. Stream header and receive errors are logged too,
+// except end of stream and cancel by client.
+func (r *RequestIDClientInterceptor) HandleStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	ctx, requestID := outgoingRequestID(ctx)
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		r.requestLogger(requestID, method).Errorf("gRPC stream error: %v", err)
+		return nil, err
+	}
+
+	logger := r.requestLogger(requestID, method)
+	logger.Debug("gRPC stream opened")
+	return &loggingClientStream{ClientStream: cs, logger: logger}, nil
+}
+
+func (r *RequestIDClientInterceptor) requestLogger(requestID, method string) *logrus.Entry {
+	return r.logger.WithFields(logrus.Fields{gkLog.FieldRequestID: requestID, gkLog.FieldMethod: method})
+}
+
+// incomingRequestID returns valid request ID from incoming metadata or generates new one.
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(MetaRequestID); len(ids) == 1 && validRequestID(ids[0]) {
+			return ids[0]
+		}
+	}
+
+	return uuid.NewString()
+}
+
+// outgoingRequestID returns context with request ID in outgoing metadata.
+// Request ID, already set in context, is kept.
+func outgoingRequestID(ctx context.Context) (context.Context, string) {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if ids := md.Get(MetaRequestID); len(ids) == 1 {
+			return ctx, ids[0]
+		}
+	}
+
+	requestID := uuid.NewString()
+	return metadata.AppendToOutgoingContext(ctx, MetaRequestID, requestID), requestID
+}
+
+// validRequestID checks, that request ID is not too long and contains only letters, digits, '-', '_' and '.',
+// to be safely logged.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > _requestIDMaxLen {
+		return false
+	}
+
+	for _, c := range requestID {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// loggingClientStream logs client stream receive errors with request logger.
+type loggingClientStream struct {
+	grpc.ClientStream
+	logger *logrus.Entry
+}
+
+// Header receives header metadata and logs error.
+func (s *loggingClientStream) Header() (metadata.MD, error) {
+	md, err := s.ClientStream.Header()
+	s.logError(err)
+	return md, err
+}
+
+// RecvMsg receives message and logs error.
+func (s *loggingClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	s.logError(err)
+	return err
+}
+
+func (s *loggingClientStream) logError(err error) {
+	if err != nil && !errors.Is(err, io.EOF) && status.Code(err) != codes.Canceled {
+		s.logger.Errorf("gRPC stream error: %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestIDClientServer(t *testing.T) {
+	cltLogger, cltHook := logTest.NewNullLogger()
+	srvLogger, _ := logTest.NewNullLogger()
+	clt, srv := NewRequestIDClientInterceptor(cltLogger), NewRequestIDInterceptor(srvLogger)
+
+	// Client request ID is used by server request logger
+	var cltRequestID string
+	var srvFields logrus.Fields
+	err := clt.Handle(
+		context.Background(),
+		_testMethod,
+		nil,
+		nil,
+		nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			require.Len(t, md.Get(MetaRequestID), 1)
+			cltRequestID = md.Get(MetaRequestID)[0]
+
+			_, err := srv.Handle(
+				metadata.NewIncomingContext(context.Background(), md),
+				req,
+				&grpc.UnaryServerInfo{FullMethod: method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					srvFields = gkLog.FromContext(ctx, logrus.New()).Data
+					return nil, status.Error(codes.NotFound, "not found")
+				})
+			return err
+		})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = uuid.Parse(cltRequestID)
+	assert.NoError(t, err)
+	assert.Equal(t, logrus.Fields{gkLog.FieldRequestID: cltRequestID, gkLog.FieldMethod: _testMethod}, srvFields)
+
+	// Failed request is logged by client with request ID
+	require.Len(t, cltHook.AllEntries(), 1)
+	assert.Equal(t, logrus.ErrorLevel, cltHook.LastEntry().Level)
+	assert.Equal(t, cltRequestID, cltHook.LastEntry().Data[gkLog.FieldRequestID])
+	assert.Equal(t, _testMethod, cltHook.LastEntry().Data[gkLog.FieldMethod])
+}
+
+func TestRequestIDServerIncoming(t *testing.T) {
+	srvLogger, _ := logTest.NewNullLogger()
+	srv := NewRequestIDInterceptor(srvLogger)
+
+	for _, tt := range []struct {
+		name       string
+		md         metadata.MD
+		expIDValid bool
+	}{
+		{name: "no metadata"},
+		{name: "no request ID", md: metadata.Pairs("foo", "bar")},
+		{name: "valid request ID", md: metadata.Pairs(MetaRequestID, "req-1_2.3"), expIDValid: true},
+		{name: "request ID with spaces", md: metadata.Pairs(MetaRequestID, "req 1")},
+		{name: "request ID with newline", md: metadata.Pairs(MetaRequestID, "req\n1")},
+		{name: "too long request ID", md: metadata.Pairs(MetaRequestID, strings.Repeat("a", 65))},
+		{name: "several request IDs", md: metadata.Pairs(MetaRequestID, "req1", MetaRequestID, "req2")},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var requestID interface{}
+			_, err := srv.Handle(
+				ctx,
+				nil,
+				&grpc.UnaryServerInfo{FullMethod: _testMethod},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					requestID = gkLog.FromContext(ctx, logrus.New()).Data[gkLog.FieldRequestID]
+					return nil, nil
+				})
+			require.NoError(t, err)
+
+			if tt.expIDValid {
+				assert.Equal(t, tt.md.Get(MetaRequestID)[0], requestID)
+				return
+			}
+
+			s, ok := requestID.(string)
+			require.True(t, ok)
+			_, err = uuid.Parse(s)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRequestIDServerStream(t *testing.T) {
+	srvLogger, _ := logTest.NewNullLogger()
+	ss := &headerServerStream{
+		testServerStream: testServerStream{
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetaRequestID, "req1")),
+		},
+	}
+
+	err := NewRequestIDInterceptor(srvLogger).HandleStream(
+		nil,
+		ss,
+		&grpc.StreamServerInfo{FullMethod: _testMethod},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			assert.Equal(t,
+				logrus.Fields{gkLog.FieldRequestID: "req1", gkLog.FieldMethod: _testMethod},
+				gkLog.FromContext(ss.Context(), logrus.New()).Data)
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"req1"}, ss.header.Get(MetaRequestID))
+}
+
+func TestRequestIDClientStream(t *testing.T) {
+	cltLogger, cltHook := logTest.NewNullLogger()
+	clt := NewRequestIDClientInterceptor(cltLogger)
+
+	// Request ID, set by caller, is kept
+	ctx := metadata.AppendToOutgoingContext(context.Background(), MetaRequestID, "req1")
+	_, err := clt.HandleStream(
+		ctx,
+		&grpc.StreamDesc{},
+		nil,
+		_testMethod,
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			assert.Equal(t, []string{"req1"}, md.Get(MetaRequestID))
+			return nil, errors.New("stream error")
+		})
+	assert.Error(t, err)
+
+	require.Len(t, cltHook.AllEntries(), 1)
+	assert.Equal(t, "req1", cltHook.LastEntry().Data[gkLog.FieldRequestID])
+}
+
+func TestRequestIDClientStreamRecv(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		err    error
+		logged bool
+	}{
+		{name: "stream end", err: io.EOF, logged: false},
+		{name: "canceled", err: status.Error(codes.Canceled, "canceled"), logged: false},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "token=foo"), logged: true},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cltLogger, cltHook := logTest.NewNullLogger()
+			ctx := metadata.AppendToOutgoingContext(context.Background(), MetaRequestID, "req1")
+			cs, err := NewRequestIDClientInterceptor(cltLogger).HandleStream(
+				ctx,
+				&grpc.StreamDesc{},
+				nil,
+				_testMethod,
+				func(
+					ctx context.Context,
+					desc *grpc.StreamDesc,
+					cc *grpc.ClientConn,
+					method string,
+					opts ...grpc.CallOption,
+				) (grpc.ClientStream, error) {
+					return &errClientStream{err: tt.err}, nil
+				})
+			require.NoError(t, err)
+			cltHook.Reset()
+
+			_, err = cs.Header()
+			assert.ErrorIs(t, err, tt.err)
+			assert.ErrorIs(t, cs.RecvMsg(nil), tt.err)
+
+			if !tt.logged {
+				assert.Empty(t, cltHook.AllEntries())
+				return
+			}
+
+			require.Len(t, cltHook.AllEntries(), 2)
+			assert.Equal(t, "req1", cltHook.LastEntry().Data[gkLog.FieldRequestID])
+			assert.Equal(t, _testMethod, cltHook.LastEntry().Data[gkLog.FieldMethod])
+		})
+	}
+}
+
+type errClientStream struct {
+	grpc.ClientStream
+	err error
+}
+
+func (s *errClientStream) Header() (metadata.MD, error) {
+	return nil, s.err
+}
+
+func (s *errClientStream) RecvMsg(interface{}) error {
+	return s.err
+}
+
+type headerServerStream struct {
+	testServerStream
+	header metadata.MD
+}
+
+func (s *headerServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
@@ -0,0 +1,140 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const _tracerName = "github.com/devldavydov/gophkeeper/internal/grpc/interceptor"
+
+// TracingInterceptor represents server interceptor, starting span of request
+// as a child of trace context, received from client in metadata.
+type TracingInterceptor struct{}
+
+var _ Interceptor = (*TracingInterceptor)(nil)
+
+// NewTracingInterceptor creates new TracingInterceptor object.
+func NewTracingInterceptor() *TracingInterceptor {
+	return &TracingInterceptor{}
+}
+
+// Handle starts server span of unary request.
+func (t *TracingInterceptor) Handle(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, span := startRPCSpan(tracing.ExtractMetadata(ctx), info.FullMethod, trace.SpanKindServer)
+	resp, err := handler(ctx, req)
+	endRPCSpan(span, err)
+
+	return resp, err
+}
+
+// HandleStream starts server span of stream, which ends with stream.
+func (t *TracingInterceptor) HandleStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, span := startRPCSpan(tracing.ExtractMetadata(ss.Context()), info.FullMethod, trace.SpanKindServer)
+	err := handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	endRPCSpan(span, err)
+
+	return err
+}
+
+// TracingClientInterceptor represents client interceptor, starting span of request
+// and sending its trace context to server in metadata.
+type TracingClientInterceptor struct{}
+
+// NewTracingClientInterceptor creates new TracingClientInterceptor object.
+func NewTracingClientInterceptor() *TracingClientInterceptor {
+	return &TracingClientInterceptor{}
+}
+
+// Handle starts client span of unary request.
+func (t *TracingClientInterceptor) Handle(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	ctx, span := startRPCSpan(ctx, method, trace.SpanKindClient)
+	err := invoker(tracing.InjectMetadata(ctx), method, req, reply, cc, opts...)
+	endRPCSpan(span, err)
+
+	return err
+}
+
+// HandleStream starts client span of stream. Span ends, when stream receive fails or stream finished.
+func (t *TracingClientInterceptor) HandleStream(
+	ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	ctx, span := startRPCSpan(ctx, method, trace.SpanKindClient)
+	cs, err := streamer(tracing.InjectMetadata(ctx), desc, cc, method, opts...)
+	if err != nil {
+		endRPCSpan(span, err)
+		return nil, err
+	}
+
+	return &tracingClientStream{ClientStream: cs, span: span}, nil
+}
+
+// tracingClientStream ends stream span on first receive error.
+type tracingClientStream struct {
+	grpc.ClientStream
+	span trace.Span
+	once sync.Once
+}
+
+func (s *tracingClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.once.Do(func() {
+			if errors.Is(err, io.EOF) {
+				endRPCSpan(s.span, nil)
+				return
+			}
+			endRPCSpan(s.span, err)
+		})
+	}
+
+	return err
+}
+
+// startRPCSpan starts span, named as gRPC full method without leading slash.
+func startRPCSpan(ctx context.Context, fullMethod string, kind trace.SpanKind) (context.Context, trace.Span) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	attrs := []trace.SpanStartOption{trace.WithSpanKind(kind), trace.WithAttributes(semconv.RPCSystemGRPC)}
+	if service, method, ok := strings.Cut(name, "/"); ok {
+		attrs = append(attrs, trace.WithAttributes(semconv.RPCService(service), semconv.RPCMethod(method)))
+	}
+
+	return otel.Tracer(_tracerName).Start(ctx, name, attrs...)
+}
+
+// endRPCSpan sets gRPC status code of span and ends it.
+func endRPCSpan(span trace.Span, err error) {
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(status.Code(err))))
+	tracing.EndSpan(span, err)
+}
@@ -0,0 +1,137 @@
+package interceptor
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const _testMethod = "/gophkeeper.GophKeeperService/SecretGet"
+
+func newSpanRecorder() *tracetest.SpanRecorder {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+
+	return sr
+}
+
+func TestTracingClientServer(t *testing.T) {
+	sr := newSpanRecorder()
+	clt, srv := NewTracingClientInterceptor(), NewTracingInterceptor()
+
+	// Client call passes outgoing metadata to server handler as incoming one
+	var srvSpanCtx trace.SpanContext
+	err := clt.Handle(
+		context.Background(),
+		_testMethod,
+		nil,
+		nil,
+		nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			_, err := srv.Handle(
+				metadata.NewIncomingContext(context.Background(), md),
+				req,
+				&grpc.UnaryServerInfo{FullMethod: method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					srvSpanCtx = trace.SpanContextFromContext(ctx)
+					return nil, status.Error(codes.NotFound, "not found")
+				})
+			return err
+		})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	srvSpan, cltSpan := spans[0], spans[1]
+
+	assert.Equal(t, "gophkeeper.GophKeeperService/SecretGet", cltSpan.Name())
+	assert.Equal(t, trace.SpanKindClient, cltSpan.SpanKind())
+	assert.Equal(t, trace.SpanKindServer, srvSpan.SpanKind())
+	assert.Equal(t, srvSpanCtx, srvSpan.SpanContext())
+
+	assert.Equal(t, cltSpan.SpanContext().TraceID(), srvSpan.SpanContext().TraceID())
+	assert.Equal(t, cltSpan.SpanContext().SpanID(), srvSpan.Parent().SpanID())
+	assert.True(t, srvSpan.Parent().IsRemote())
+
+	for _, span := range spans {
+		assert.Equal(t, otelcodes.Error, span.Status().Code)
+		assert.Contains(t, span.Attributes(), attribute.String("rpc.service", "gophkeeper.GophKeeperService"))
+		assert.Contains(t, span.Attributes(), attribute.String("rpc.method", "SecretGet"))
+		assert.Contains(t, span.Attributes(), attribute.Int("rpc.grpc.status_code", int(codes.NotFound)))
+	}
+}
+
+func TestTracingServerStream(t *testing.T) {
+	sr := newSpanRecorder()
+
+	err := NewTracingInterceptor().HandleStream(
+		nil,
+		&testServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: _testMethod},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			assert.True(t, trace.SpanContextFromContext(ss.Context()).IsValid())
+			return nil
+		})
+	assert.NoError(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, otelcodes.Unset, spans[0].Status().Code)
+	assert.False(t, spans[0].Parent().IsValid())
+}
+
+func TestTracingClientStream(t *testing.T) {
+	sr := newSpanRecorder()
+
+	cs, err := NewTracingClientInterceptor().HandleStream(
+		context.Background(),
+		&grpc.StreamDesc{},
+		nil,
+		_testMethod,
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			assert.Len(t, md.Get("traceparent"), 1)
+			return &testClientStream{}, nil
+		})
+	require.NoError(t, err)
+	assert.Empty(t, sr.Ended())
+
+	// Span ends once on stream finish
+	assert.ErrorIs(t, cs.RecvMsg(nil), io.EOF)
+	assert.ErrorIs(t, cs.RecvMsg(nil), io.EOF)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, otelcodes.Unset, spans[0].Status().Code)
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+type testClientStream struct {
+	grpc.ClientStream
+}
+
+func (s *testClientStream) RecvMsg(interface{}) error {
+	return io.EOF
+}
@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/devldavydov/gophkeeper/internal/grpc (interfaces: AdminServiceClient)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	grpc "github.com/devldavydov/gophkeeper/internal/grpc"
+	gomock "github.com/golang/mock/gomock"
+	grpc0 "google.golang.org/grpc"
+)
+
+// MockAdminServiceClient is a mock of AdminServiceClient interface.
+type MockAdminServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminServiceClientMockRecorder
+}
+
+// MockAdminServiceClientMockRecorder is the mock recorder for MockAdminServiceClient.
+type MockAdminServiceClientMockRecorder struct {
+	mock *MockAdminServiceClient
+}
+
+// NewMockAdminServiceClient creates a new mock instance.
+func NewMockAdminServiceClient(ctrl *gomock.Controller) *MockAdminServiceClient {
+	mock := &MockAdminServiceClient{ctrl: ctrl}
+	mock.recorder = &MockAdminServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminServiceClient) EXPECT() *MockAdminServiceClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteUser mocks base method.
+func (m *MockAdminServiceClient) DeleteUser(arg0 context.Context, arg1 *grpc.AdminUserRequest, arg2 ...grpc0.CallOption) (*grpc.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteUser", varargs...)
+	ret0, _ := ret[0].(*grpc.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockAdminServiceClientMockRecorder) DeleteUser(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockAdminServiceClient)(nil).DeleteUser), varargs...)
+}
+
+// DisableUser mocks base method.
+func (m *MockAdminServiceClient) DisableUser(arg0 context.Context, arg1 *grpc.AdminUserRequest, arg2 ...grpc0.CallOption) (*grpc.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisableUser", varargs...)
+	ret0, _ := ret[0].(*grpc.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableUser indicates an expected call of DisableUser.
+func (mr *MockAdminServiceClientMockRecorder) DisableUser(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUser", reflect.TypeOf((*MockAdminServiceClient)(nil).DisableUser), varargs...)
+}
+
+// EnableUser mocks base method.
+func (m *MockAdminServiceClient) EnableUser(arg0 context.Context, arg1 *grpc.AdminUserRequest, arg2 ...grpc0.CallOption) (*grpc.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnableUser", varargs...)
+	ret0, _ := ret[0].(*grpc.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnableUser indicates an expected call of EnableUser.
+func (mr *MockAdminServiceClientMockRecorder) EnableUser(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUser", reflect.TypeOf((*MockAdminServiceClient)(nil).EnableUser), varargs...)
+}
+
+// ForceLogout mocks base method.
+func (m *MockAdminServiceClient) ForceLogout(arg0 context.Context, arg1 *grpc.AdminUserRequest, arg2 ...grpc0.CallOption) (*grpc.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ForceLogout", varargs...)
+	ret0, _ := ret[0].(*grpc.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceLogout indicates an expected call of ForceLogout.
+func (mr *MockAdminServiceClientMockRecorder) ForceLogout(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceLogout", reflect.TypeOf((*MockAdminServiceClient)(nil).ForceLogout), varargs...)
+}
+
+// GetUserStats mocks base method.
+func (m *MockAdminServiceClient) GetUserStats(arg0 context.Context, arg1 *grpc.AdminUserRequest, arg2 ...grpc0.CallOption) (*grpc.AdminUserStats, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUserStats", varargs...)
+	ret0, _ := ret[0].(*grpc.AdminUserStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStats indicates an expected call of GetUserStats.
+func (mr *MockAdminServiceClientMockRecorder) GetUserStats(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStats", reflect.TypeOf((*MockAdminServiceClient)(nil).GetUserStats), varargs...)
+}
+
+// ListUsers mocks base method.
+func (m *MockAdminServiceClient) ListUsers(arg0 context.Context, arg1 *grpc.Empty, arg2 ...grpc0.CallOption) (*grpc.AdminListUsersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUsers", varargs...)
+	ret0, _ := ret[0].(*grpc.AdminListUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAdminServiceClientMockRecorder) ListUsers(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAdminServiceClient)(nil).ListUsers), varargs...)
+}
+
+// SetUserQuota mocks base method.
+func (m *MockAdminServiceClient) SetUserQuota(arg0 context.Context, arg1 *grpc.AdminSetUserQuotaRequest, arg2 ...grpc0.CallOption) (*grpc.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetUserQuota", varargs...)
+	ret0, _ := ret[0].(*grpc.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserQuota indicates an expected call of SetUserQuota.
+func (mr *MockAdminServiceClientMockRecorder) SetUserQuota(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserQuota", reflect.TypeOf((*MockAdminServiceClient)(nil).SetUserQuota), varargs...)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/devldavydov/gophkeeper/internal/grpc (interfaces: GophKeeperServiceClient)
+// Source: github.com/devldavydov/gophkeeper/internal/grpc (interfaces: GophKeeperServiceClient,GophKeeperService_SecretWatchClient)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -11,6 +11,7 @@ import (
 	grpc "github.com/devldavydov/gophkeeper/internal/grpc"
 	gomock "github.com/golang/mock/gomock"
 	grpc0 "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
 // MockGophKeeperServiceClient is a mock of GophKeeperServiceClient interface.
@@ -76,6 +77,26 @@ func (mr *MockGophKeeperServiceClientMockRecorder) SecretCreate(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretCreate", reflect.TypeOf((*MockGophKeeperServiceClient)(nil).SecretCreate), varargs...)
 }
 
+// SecretCreateOneTimeShare mocks base method.
+func (m *MockGophKeeperServiceClient) SecretCreateOneTimeShare(arg0 context.Context, arg1 *grpc.SecretCreateOneTimeShareRequest, arg2 ...grpc0.CallOption) (*grpc.SecretCreateOneTimeShareResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SecretCreateOneTimeShare", varargs...)
+	ret0, _ := ret[0].(*grpc.SecretCreateOneTimeShareResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretCreateOneTimeShare indicates an expected call of SecretCreateOneTimeShare.
+func (mr *MockGophKeeperServiceClientMockRecorder) SecretCreateOneTimeShare(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretCreateOneTimeShare", reflect.TypeOf((*MockGophKeeperServiceClient)(nil).SecretCreateOneTimeShare), varargs...)
+}
+
 // SecretDelete mocks base method.
 func (m *MockGophKeeperServiceClient) SecretDelete(arg0 context.Context, arg1 *grpc.SecretDeleteRequest, arg2 ...grpc0.CallOption) (*grpc.Empty, error) {
 	m.ctrl.T.Helper()
@@ -117,7 +138,7 @@ func (mr *MockGophKeeperServiceClientMockRecorder) SecretGet(arg0, arg1 interfac
 }
 
 // SecretGetList mocks base method.
-func (m *MockGophKeeperServiceClient) SecretGetList(arg0 context.Context, arg1 *grpc.Empty, arg2 ...grpc0.CallOption) (*grpc.SecretGetListResponse, error) {
+func (m *MockGophKeeperServiceClient) SecretGetList(arg0 context.Context, arg1 *grpc.SecretGetListRequest, arg2 ...grpc0.CallOption) (*grpc.SecretGetListResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{arg0, arg1}
 	for _, a := range arg2 {
@@ -136,6 +157,26 @@ func (mr *MockGophKeeperServiceClientMockRecorder) SecretGetList(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretGetList", reflect.TypeOf((*MockGophKeeperServiceClient)(nil).SecretGetList), varargs...)
 }
 
+// SecretRedeemOneTimeShare mocks base method.
+func (m *MockGophKeeperServiceClient) SecretRedeemOneTimeShare(arg0 context.Context, arg1 *grpc.SecretRedeemOneTimeShareRequest, arg2 ...grpc0.CallOption) (*grpc.SecretRedeemOneTimeShareResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SecretRedeemOneTimeShare", varargs...)
+	ret0, _ := ret[0].(*grpc.SecretRedeemOneTimeShareResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretRedeemOneTimeShare indicates an expected call of SecretRedeemOneTimeShare.
+func (mr *MockGophKeeperServiceClientMockRecorder) SecretRedeemOneTimeShare(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretRedeemOneTimeShare", reflect.TypeOf((*MockGophKeeperServiceClient)(nil).SecretRedeemOneTimeShare), varargs...)
+}
+
 // SecretUpdate mocks base method.
 func (m *MockGophKeeperServiceClient) SecretUpdate(arg0 context.Context, arg1 *grpc.SecretUpdateRequest, arg2 ...grpc0.CallOption) (*grpc.Empty, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +197,26 @@ func (mr *MockGophKeeperServiceClientMockRecorder) SecretUpdate(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretUpdate", reflect.TypeOf((*MockGophKeeperServiceClient)(nil).SecretUpdate), varargs...)
 }
 
+// SecretWatch mocks base method.
+func (m *MockGophKeeperServiceClient) SecretWatch(arg0 context.Context, arg1 *grpc.Empty, arg2 ...grpc0.CallOption) (grpc.GophKeeperService_SecretWatchClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SecretWatch", varargs...)
+	ret0, _ := ret[0].(grpc.GophKeeperService_SecretWatchClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretWatch indicates an expected call of SecretWatch.
+func (mr *MockGophKeeperServiceClientMockRecorder) SecretWatch(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretWatch", reflect.TypeOf((*MockGophKeeperServiceClient)(nil).SecretWatch), varargs...)
+}
+
 // UserCreate mocks base method.
 func (m *MockGophKeeperServiceClient) UserCreate(arg0 context.Context, arg1 *grpc.User, arg2 ...grpc0.CallOption) (*grpc.UserAuthToken, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +237,46 @@ func (mr *MockGophKeeperServiceClientMockRecorder) UserCreate(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCreate", reflect.TypeOf((*MockGophKeeperServiceClient)(nil).UserCreate), varargs...)
 }
 
+// UserGetAuditLog mocks base method.
+func (m *MockGophKeeperServiceClient) UserGetAuditLog(arg0 context.Context, arg1 *grpc.UserGetAuditLogRequest, arg2 ...grpc0.CallOption) (*grpc.UserGetAuditLogResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UserGetAuditLog", varargs...)
+	ret0, _ := ret[0].(*grpc.UserGetAuditLogResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserGetAuditLog indicates an expected call of UserGetAuditLog.
+func (mr *MockGophKeeperServiceClientMockRecorder) UserGetAuditLog(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetAuditLog", reflect.TypeOf((*MockGophKeeperServiceClient)(nil).UserGetAuditLog), varargs...)
+}
+
+// UserGetUsage mocks base method.
+func (m *MockGophKeeperServiceClient) UserGetUsage(arg0 context.Context, arg1 *grpc.Empty, arg2 ...grpc0.CallOption) (*grpc.UserUsage, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UserGetUsage", varargs...)
+	ret0, _ := ret[0].(*grpc.UserUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserGetUsage indicates an expected call of UserGetUsage.
+func (mr *MockGophKeeperServiceClientMockRecorder) UserGetUsage(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetUsage", reflect.TypeOf((*MockGophKeeperServiceClient)(nil).UserGetUsage), varargs...)
+}
+
 // UserLogin mocks base method.
 func (m *MockGophKeeperServiceClient) UserLogin(arg0 context.Context, arg1 *grpc.User, arg2 ...grpc0.CallOption) (*grpc.UserAuthToken, error) {
 	m.ctrl.T.Helper()
@@ -195,3 +296,126 @@ func (mr *MockGophKeeperServiceClientMockRecorder) UserLogin(arg0, arg1 interfac
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLogin", reflect.TypeOf((*MockGophKeeperServiceClient)(nil).UserLogin), varargs...)
 }
+
+// MockGophKeeperService_SecretWatchClient is a mock of GophKeeperService_SecretWatchClient interface.
+type MockGophKeeperService_SecretWatchClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockGophKeeperService_SecretWatchClientMockRecorder
+}
+
+// MockGophKeeperService_SecretWatchClientMockRecorder is the mock recorder for MockGophKeeperService_SecretWatchClient.
+type MockGophKeeperService_SecretWatchClientMockRecorder struct {
+	mock *MockGophKeeperService_SecretWatchClient
+}
+
+// NewMockGophKeeperService_SecretWatchClient creates a new mock instance.
+func NewMockGophKeeperService_SecretWatchClient(ctrl *gomock.Controller) *MockGophKeeperService_SecretWatchClient {
+	mock := &MockGophKeeperService_SecretWatchClient{ctrl: ctrl}
+	mock.recorder = &MockGophKeeperService_SecretWatchClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGophKeeperService_SecretWatchClient) EXPECT() *MockGophKeeperService_SecretWatchClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockGophKeeperService_SecretWatchClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockGophKeeperService_SecretWatchClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockGophKeeperService_SecretWatchClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockGophKeeperService_SecretWatchClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockGophKeeperService_SecretWatchClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockGophKeeperService_SecretWatchClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockGophKeeperService_SecretWatchClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockGophKeeperService_SecretWatchClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockGophKeeperService_SecretWatchClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockGophKeeperService_SecretWatchClient) Recv() (*grpc.SecretEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*grpc.SecretEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockGophKeeperService_SecretWatchClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockGophKeeperService_SecretWatchClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m *MockGophKeeperService_SecretWatchClient) RecvMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecvMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockGophKeeperService_SecretWatchClientMockRecorder) RecvMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockGophKeeperService_SecretWatchClient)(nil).RecvMsg), arg0)
+}
+
+// SendMsg mocks base method.
+func (m *MockGophKeeperService_SecretWatchClient) SendMsg(arg0 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMsg", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockGophKeeperService_SecretWatchClientMockRecorder) SendMsg(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockGophKeeperService_SecretWatchClient)(nil).SendMsg), arg0)
+}
+
+// Trailer mocks base method.
+func (m *MockGophKeeperService_SecretWatchClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockGophKeeperService_SecretWatchClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockGophKeeperService_SecretWatchClient)(nil).Trailer))
+}
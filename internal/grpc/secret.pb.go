@@ -75,16 +75,70 @@ func (SecretType) EnumDescriptor() ([]byte, []int) {
 	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{0}
 }
 
+type SecretEventType int32
+
+const (
+	SecretEventType_SECRET_EVENT_UNKNOWN SecretEventType = 0
+	SecretEventType_SECRET_CREATED       SecretEventType = 1
+	SecretEventType_SECRET_UPDATED       SecretEventType = 2
+	SecretEventType_SECRET_DELETED       SecretEventType = 3
+)
+
+// Enum value maps for SecretEventType.
+var (
+	SecretEventType_name = map[int32]string{
+		0: "SECRET_EVENT_UNKNOWN",
+		1: "SECRET_CREATED",
+		2: "SECRET_UPDATED",
+		3: "SECRET_DELETED",
+	}
+	SecretEventType_value = map[string]int32{
+		"SECRET_EVENT_UNKNOWN": 0,
+		"SECRET_CREATED":       1,
+		"SECRET_UPDATED":       2,
+		"SECRET_DELETED":       3,
+	}
+)
+
+func (x SecretEventType) Enum() *SecretEventType {
+	p := new(SecretEventType)
+	*p = x
+	return p
+}
+
+func (x SecretEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SecretEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_grpc_proto_secret_proto_enumTypes[1].Descriptor()
+}
+
+func (SecretEventType) Type() protoreflect.EnumType {
+	return &file_internal_grpc_proto_secret_proto_enumTypes[1]
+}
+
+func (x SecretEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SecretEventType.Descriptor instead.
+func (SecretEventType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{1}
+}
+
 type Secret struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name       string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type       SecretType `protobuf:"varint,2,opt,name=type,proto3,enum=proto.SecretType" json:"type,omitempty"`
-	Version    int64      `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
-	Meta       string     `protobuf:"bytes,4,opt,name=meta,proto3" json:"meta,omitempty"`
-	PayloadRaw []byte     `protobuf:"bytes,5,opt,name=payload_raw,json=payloadRaw,proto3" json:"payload_raw,omitempty"`
+	Name        string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type        SecretType `protobuf:"varint,2,opt,name=type,proto3,enum=proto.SecretType" json:"type,omitempty"`
+	Version     int64      `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Meta        string     `protobuf:"bytes,4,opt,name=meta,proto3" json:"meta,omitempty"`
+	PayloadRaw  []byte     `protobuf:"bytes,5,opt,name=payload_raw,json=payloadRaw,proto3" json:"payload_raw,omitempty"`
+	ExpiresAt   int64      `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`       // unix time of secret expiration, 0 - never expires
+	RotateEvery int64      `protobuf:"varint,7,opt,name=rotate_every,json=rotateEvery,proto3" json:"rotate_every,omitempty"` // rotation period in seconds, 0 - no rotation
 }
 
 func (x *Secret) Reset() {
@@ -154,14 +208,32 @@ func (x *Secret) GetPayloadRaw() []byte {
 	return nil
 }
 
+func (x *Secret) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Secret) GetRotateEvery() int64 {
+	if x != nil {
+		return x.RotateEvery
+	}
+	return 0
+}
+
 type SecretListItem struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type    SecretType `protobuf:"varint,2,opt,name=type,proto3,enum=proto.SecretType" json:"type,omitempty"`
-	Version int64      `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Name             string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type             SecretType `protobuf:"varint,2,opt,name=type,proto3,enum=proto.SecretType" json:"type,omitempty"`
+	Version          int64      `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	UpdatedAt        int64      `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                        // unix time of last secret update
+	PayloadUpdatedAt int64      `protobuf:"varint,5,opt,name=payload_updated_at,json=payloadUpdatedAt,proto3" json:"payload_updated_at,omitempty"` // unix time of last secret payload change
+	ExpiresAt        int64      `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                        // unix time of secret expiration, 0 - never expires
+	RotateEvery      int64      `protobuf:"varint,7,opt,name=rotate_every,json=rotateEvery,proto3" json:"rotate_every,omitempty"`                  // rotation period in seconds, 0 - no rotation
 }
 
 func (x *SecretListItem) Reset() {
@@ -217,6 +289,81 @@ func (x *SecretListItem) GetVersion() int64 {
 	return 0
 }
 
+func (x *SecretListItem) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *SecretListItem) GetPayloadUpdatedAt() int64 {
+	if x != nil {
+		return x.PayloadUpdatedAt
+	}
+	return 0
+}
+
+func (x *SecretListItem) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *SecretListItem) GetRotateEvery() int64 {
+	if x != nil {
+		return x.RotateEvery
+	}
+	return 0
+}
+
+type SecretGetListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExpiringWithinDays int32 `protobuf:"varint,1,opt,name=expiring_within_days,json=expiringWithinDays,proto3" json:"expiring_within_days,omitempty"` // only secrets expiring or due to rotation within given days, 0 - all secrets
+}
+
+func (x *SecretGetListRequest) Reset() {
+	*x = SecretGetListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretGetListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretGetListRequest) ProtoMessage() {}
+
+func (x *SecretGetListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretGetListRequest.ProtoReflect.Descriptor instead.
+func (*SecretGetListRequest) Descriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SecretGetListRequest) GetExpiringWithinDays() int32 {
+	if x != nil {
+		return x.ExpiringWithinDays
+	}
+	return 0
+}
+
 type SecretGetListResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -228,7 +375,7 @@ type SecretGetListResponse struct {
 func (x *SecretGetListResponse) Reset() {
 	*x = SecretGetListResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_grpc_proto_secret_proto_msgTypes[2]
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -241,7 +388,7 @@ func (x *SecretGetListResponse) String() string {
 func (*SecretGetListResponse) ProtoMessage() {}
 
 func (x *SecretGetListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_grpc_proto_secret_proto_msgTypes[2]
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,7 +401,7 @@ func (x *SecretGetListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SecretGetListResponse.ProtoReflect.Descriptor instead.
 func (*SecretGetListResponse) Descriptor() ([]byte, []int) {
-	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{2}
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SecretGetListResponse) GetItems() []*SecretListItem {
@@ -275,7 +422,7 @@ type SecretGetRequest struct {
 func (x *SecretGetRequest) Reset() {
 	*x = SecretGetRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_grpc_proto_secret_proto_msgTypes[3]
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -288,7 +435,7 @@ func (x *SecretGetRequest) String() string {
 func (*SecretGetRequest) ProtoMessage() {}
 
 func (x *SecretGetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_grpc_proto_secret_proto_msgTypes[3]
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +448,7 @@ func (x *SecretGetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SecretGetRequest.ProtoReflect.Descriptor instead.
 func (*SecretGetRequest) Descriptor() ([]byte, []int) {
-	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{3}
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SecretGetRequest) GetName() string {
@@ -322,7 +469,7 @@ type SecretCreateRequest struct {
 func (x *SecretCreateRequest) Reset() {
 	*x = SecretCreateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_grpc_proto_secret_proto_msgTypes[4]
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -335,7 +482,7 @@ func (x *SecretCreateRequest) String() string {
 func (*SecretCreateRequest) ProtoMessage() {}
 
 func (x *SecretCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_grpc_proto_secret_proto_msgTypes[4]
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +495,7 @@ func (x *SecretCreateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SecretCreateRequest.ProtoReflect.Descriptor instead.
 func (*SecretCreateRequest) Descriptor() ([]byte, []int) {
-	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{4}
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SecretCreateRequest) GetSecret() *Secret {
@@ -368,12 +515,14 @@ type SecretUpdateRequest struct {
 	Meta          string `protobuf:"bytes,3,opt,name=meta,proto3" json:"meta,omitempty"`
 	PayloadRaw    []byte `protobuf:"bytes,4,opt,name=payload_raw,json=payloadRaw,proto3" json:"payload_raw,omitempty"`
 	UpdatePayload bool   `protobuf:"varint,5,opt,name=update_payload,json=updatePayload,proto3" json:"update_payload,omitempty"`
+	ExpiresAt     int64  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`       // unix time of secret expiration, 0 - never expires
+	RotateEvery   int64  `protobuf:"varint,7,opt,name=rotate_every,json=rotateEvery,proto3" json:"rotate_every,omitempty"` // rotation period in seconds, 0 - no rotation
 }
 
 func (x *SecretUpdateRequest) Reset() {
 	*x = SecretUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_grpc_proto_secret_proto_msgTypes[5]
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -386,7 +535,7 @@ func (x *SecretUpdateRequest) String() string {
 func (*SecretUpdateRequest) ProtoMessage() {}
 
 func (x *SecretUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_grpc_proto_secret_proto_msgTypes[5]
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,7 +548,7 @@ func (x *SecretUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SecretUpdateRequest.ProtoReflect.Descriptor instead.
 func (*SecretUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{5}
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SecretUpdateRequest) GetName() string {
@@ -437,6 +586,20 @@ func (x *SecretUpdateRequest) GetUpdatePayload() bool {
 	return false
 }
 
+func (x *SecretUpdateRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *SecretUpdateRequest) GetRotateEvery() int64 {
+	if x != nil {
+		return x.RotateEvery
+	}
+	return 0
+}
+
 type SecretDeleteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -448,7 +611,7 @@ type SecretDeleteRequest struct {
 func (x *SecretDeleteRequest) Reset() {
 	*x = SecretDeleteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_grpc_proto_secret_proto_msgTypes[6]
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -461,7 +624,7 @@ func (x *SecretDeleteRequest) String() string {
 func (*SecretDeleteRequest) ProtoMessage() {}
 
 func (x *SecretDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_grpc_proto_secret_proto_msgTypes[6]
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -474,7 +637,7 @@ func (x *SecretDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SecretDeleteRequest.ProtoReflect.Descriptor instead.
 func (*SecretDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{6}
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SecretDeleteRequest) GetName() string {
@@ -484,12 +647,327 @@ func (x *SecretDeleteRequest) GetName() string {
 	return ""
 }
 
+type SecretCreateOneTimeShareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name             string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // name of shared secret, only for owner's audit log
+	Type             SecretType `protobuf:"varint,2,opt,name=type,proto3,enum=proto.SecretType" json:"type,omitempty"`
+	PayloadEncrypted []byte     `protobuf:"bytes,3,opt,name=payload_encrypted,json=payloadEncrypted,proto3" json:"payload_encrypted,omitempty"` // secret payload, encrypted with key unknown to server
+	Ttl              int64      `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`                                                  // share lifetime in seconds, 0 - default
+	MaxViews         int32      `protobuf:"varint,5,opt,name=max_views,json=maxViews,proto3" json:"max_views,omitempty"`                        // number of allowed retrievals, 0 - one
+}
+
+func (x *SecretCreateOneTimeShareRequest) Reset() {
+	*x = SecretCreateOneTimeShareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretCreateOneTimeShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretCreateOneTimeShareRequest) ProtoMessage() {}
+
+func (x *SecretCreateOneTimeShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretCreateOneTimeShareRequest.ProtoReflect.Descriptor instead.
+func (*SecretCreateOneTimeShareRequest) Descriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SecretCreateOneTimeShareRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SecretCreateOneTimeShareRequest) GetType() SecretType {
+	if x != nil {
+		return x.Type
+	}
+	return SecretType_UNKNOWN
+}
+
+func (x *SecretCreateOneTimeShareRequest) GetPayloadEncrypted() []byte {
+	if x != nil {
+		return x.PayloadEncrypted
+	}
+	return nil
+}
+
+func (x *SecretCreateOneTimeShareRequest) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *SecretCreateOneTimeShareRequest) GetMaxViews() int32 {
+	if x != nil {
+		return x.MaxViews
+	}
+	return 0
+}
+
+type SecretCreateOneTimeShareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // unix time of share expiration
+}
+
+func (x *SecretCreateOneTimeShareResponse) Reset() {
+	*x = SecretCreateOneTimeShareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretCreateOneTimeShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretCreateOneTimeShareResponse) ProtoMessage() {}
+
+func (x *SecretCreateOneTimeShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretCreateOneTimeShareResponse.ProtoReflect.Descriptor instead.
+func (*SecretCreateOneTimeShareResponse) Descriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SecretCreateOneTimeShareResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SecretCreateOneTimeShareResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type SecretRedeemOneTimeShareRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *SecretRedeemOneTimeShareRequest) Reset() {
+	*x = SecretRedeemOneTimeShareRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretRedeemOneTimeShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretRedeemOneTimeShareRequest) ProtoMessage() {}
+
+func (x *SecretRedeemOneTimeShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretRedeemOneTimeShareRequest.ProtoReflect.Descriptor instead.
+func (*SecretRedeemOneTimeShareRequest) Descriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SecretRedeemOneTimeShareRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SecretRedeemOneTimeShareResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type             SecretType `protobuf:"varint,1,opt,name=type,proto3,enum=proto.SecretType" json:"type,omitempty"`
+	PayloadEncrypted []byte     `protobuf:"bytes,2,opt,name=payload_encrypted,json=payloadEncrypted,proto3" json:"payload_encrypted,omitempty"`
+	ViewsLeft        int32      `protobuf:"varint,3,opt,name=views_left,json=viewsLeft,proto3" json:"views_left,omitempty"` // share is deleted, when no views left
+}
+
+func (x *SecretRedeemOneTimeShareResponse) Reset() {
+	*x = SecretRedeemOneTimeShareResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretRedeemOneTimeShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretRedeemOneTimeShareResponse) ProtoMessage() {}
+
+func (x *SecretRedeemOneTimeShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretRedeemOneTimeShareResponse.ProtoReflect.Descriptor instead.
+func (*SecretRedeemOneTimeShareResponse) Descriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SecretRedeemOneTimeShareResponse) GetType() SecretType {
+	if x != nil {
+		return x.Type
+	}
+	return SecretType_UNKNOWN
+}
+
+func (x *SecretRedeemOneTimeShareResponse) GetPayloadEncrypted() []byte {
+	if x != nil {
+		return x.PayloadEncrypted
+	}
+	return nil
+}
+
+func (x *SecretRedeemOneTimeShareResponse) GetViewsLeft() int32 {
+	if x != nil {
+		return x.ViewsLeft
+	}
+	return 0
+}
+
+type SecretEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventType SecretEventType `protobuf:"varint,1,opt,name=event_type,json=eventType,proto3,enum=proto.SecretEventType" json:"event_type,omitempty"`
+	Name      string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type      SecretType      `protobuf:"varint,3,opt,name=type,proto3,enum=proto.SecretType" json:"type,omitempty"` // set for created events only
+	Version   int64           `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`                 // new secret version, not set for deleted events
+}
+
+func (x *SecretEvent) Reset() {
+	*x = SecretEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_grpc_proto_secret_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretEvent) ProtoMessage() {}
+
+func (x *SecretEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_grpc_proto_secret_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretEvent.ProtoReflect.Descriptor instead.
+func (*SecretEvent) Descriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_secret_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SecretEvent) GetEventType() SecretEventType {
+	if x != nil {
+		return x.EventType
+	}
+	return SecretEventType_SECRET_EVENT_UNKNOWN
+}
+
+func (x *SecretEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SecretEvent) GetType() SecretType {
+	if x != nil {
+		return x.Type
+	}
+	return SecretType_UNKNOWN
+}
+
+func (x *SecretEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 var File_internal_grpc_proto_secret_proto protoreflect.FileDescriptor
 
 var file_internal_grpc_proto_secret_proto_rawDesc = []byte{
 	0x0a, 0x20, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x92, 0x01, 0x0a, 0x06, 0x53, 0x65,
+	0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd4, 0x01, 0x0a, 0x06, 0x53, 0x65,
 	0x63, 0x72, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
@@ -498,43 +976,111 @@ var file_internal_grpc_proto_secret_proto_rawDesc = []byte{
 	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65, 0x74,
 	0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x1f, 0x0a,
 	0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x72, 0x61, 0x77, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x77, 0x22, 0x65,
-	0x0a, 0x0e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d,
+	0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x77, 0x12, 0x1d,
+	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x72, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x72, 0x79,
+	0x22, 0xf4, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x49,
+	0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x10, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x41, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x65,
+	0x76, 0x65, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f, 0x74, 0x61,
+	0x74, 0x65, 0x45, 0x76, 0x65, 0x72, 0x79, 0x22, 0x48, 0x0a, 0x14, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x30, 0x0a, 0x14, 0x65, 0x78, 0x70, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x77, 0x69, 0x74, 0x68,
+	0x69, 0x6e, 0x5f, 0x64, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x57, 0x69, 0x74, 0x68, 0x69, 0x6e, 0x44, 0x61, 0x79,
+	0x73, 0x22, 0x44, 0x0a, 0x15, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x47, 0x65, 0x74, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x74, 0x65, 0x6d,
+	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x26, 0x0a, 0x10, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
+	0x3c, 0x0a, 0x13, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0xe1, 0x01,
+	0x0a, 0x13, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x5f, 0x72, 0x61, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x77, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x72, 0x79, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x72,
+	0x79, 0x22, 0x29, 0x0a, 0x13, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xb8, 0x01, 0x0a,
+	0x1f, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
 	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x44, 0x0a, 0x15, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x47,
-	0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b,
-	0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74,
-	0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x26, 0x0a, 0x10, 0x53,
-	0x65, 0x63, 0x72, 0x65, 0x74, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x22, 0x3c, 0x0a, 0x13, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x65,
-	0x63, 0x72, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x22, 0x9f, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
-	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
-	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x72, 0x61, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0a, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x61, 0x77, 0x12, 0x25, 0x0a, 0x0e,
-	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x79, 0x6c,
-	0x6f, 0x61, 0x64, 0x22, 0x29, 0x0a, 0x13, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x2a, 0x44,
-	0x0a, 0x0a, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07,
-	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x52, 0x45,
-	0x44, 0x53, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x45, 0x58, 0x54, 0x10, 0x02, 0x12, 0x0a,
-	0x0a, 0x06, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x41,
-	0x52, 0x44, 0x10, 0x04, 0x42, 0x0f, 0x5a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
-	0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x45,
+	0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61,
+	0x78, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d,
+	0x61, 0x78, 0x56, 0x69, 0x65, 0x77, 0x73, 0x22, 0x51, 0x0a, 0x20, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x31, 0x0a, 0x1f, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x4f, 0x6e, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x95, 0x01,
+	0x0a, 0x20, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x4f, 0x6e,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x45, 0x6e, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x69, 0x65, 0x77, 0x73, 0x5f,
+	0x6c, 0x65, 0x66, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x76, 0x69, 0x65, 0x77,
+	0x73, 0x4c, 0x65, 0x66, 0x74, 0x22, 0x99, 0x01, 0x0a, 0x0b, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x2a, 0x44, 0x0a, 0x0a, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05,
+	0x43, 0x52, 0x45, 0x44, 0x53, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x45, 0x58, 0x54, 0x10,
+	0x02, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x03, 0x12, 0x08, 0x0a,
+	0x04, 0x43, 0x41, 0x52, 0x44, 0x10, 0x04, 0x2a, 0x67, 0x0a, 0x0f, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45,
+	0x43, 0x52, 0x45, 0x54, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x43,
+	0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x43, 0x52,
+	0x45, 0x54, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e,
+	0x53, 0x45, 0x43, 0x52, 0x45, 0x54, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03,
+	0x42, 0x0f, 0x5a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70,
+	0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -549,28 +1095,39 @@ func file_internal_grpc_proto_secret_proto_rawDescGZIP() []byte {
 	return file_internal_grpc_proto_secret_proto_rawDescData
 }
 
-var file_internal_grpc_proto_secret_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_grpc_proto_secret_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_internal_grpc_proto_secret_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_internal_grpc_proto_secret_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_internal_grpc_proto_secret_proto_goTypes = []interface{}{
-	(SecretType)(0),               // 0: proto.SecretType
-	(*Secret)(nil),                // 1: proto.Secret
-	(*SecretListItem)(nil),        // 2: proto.SecretListItem
-	(*SecretGetListResponse)(nil), // 3: proto.SecretGetListResponse
-	(*SecretGetRequest)(nil),      // 4: proto.SecretGetRequest
-	(*SecretCreateRequest)(nil),   // 5: proto.SecretCreateRequest
-	(*SecretUpdateRequest)(nil),   // 6: proto.SecretUpdateRequest
-	(*SecretDeleteRequest)(nil),   // 7: proto.SecretDeleteRequest
+	(SecretType)(0),                          // 0: proto.SecretType
+	(SecretEventType)(0),                     // 1: proto.SecretEventType
+	(*Secret)(nil),                           // 2: proto.Secret
+	(*SecretListItem)(nil),                   // 3: proto.SecretListItem
+	(*SecretGetListRequest)(nil),             // 4: proto.SecretGetListRequest
+	(*SecretGetListResponse)(nil),            // 5: proto.SecretGetListResponse
+	(*SecretGetRequest)(nil),                 // 6: proto.SecretGetRequest
+	(*SecretCreateRequest)(nil),              // 7: proto.SecretCreateRequest
+	(*SecretUpdateRequest)(nil),              // 8: proto.SecretUpdateRequest
+	(*SecretDeleteRequest)(nil),              // 9: proto.SecretDeleteRequest
+	(*SecretCreateOneTimeShareRequest)(nil),  // 10: proto.SecretCreateOneTimeShareRequest
+	(*SecretCreateOneTimeShareResponse)(nil), // 11: proto.SecretCreateOneTimeShareResponse
+	(*SecretRedeemOneTimeShareRequest)(nil),  // 12: proto.SecretRedeemOneTimeShareRequest
+	(*SecretRedeemOneTimeShareResponse)(nil), // 13: proto.SecretRedeemOneTimeShareResponse
+	(*SecretEvent)(nil),                      // 14: proto.SecretEvent
 }
 var file_internal_grpc_proto_secret_proto_depIdxs = []int32{
 	0, // 0: proto.Secret.type:type_name -> proto.SecretType
 	0, // 1: proto.SecretListItem.type:type_name -> proto.SecretType
-	2, // 2: proto.SecretGetListResponse.items:type_name -> proto.SecretListItem
-	1, // 3: proto.SecretCreateRequest.secret:type_name -> proto.Secret
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	3, // 2: proto.SecretGetListResponse.items:type_name -> proto.SecretListItem
+	2, // 3: proto.SecretCreateRequest.secret:type_name -> proto.Secret
+	0, // 4: proto.SecretCreateOneTimeShareRequest.type:type_name -> proto.SecretType
+	0, // 5: proto.SecretRedeemOneTimeShareResponse.type:type_name -> proto.SecretType
+	1, // 6: proto.SecretEvent.event_type:type_name -> proto.SecretEventType
+	0, // 7: proto.SecretEvent.type:type_name -> proto.SecretType
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_internal_grpc_proto_secret_proto_init() }
@@ -604,7 +1161,7 @@ func file_internal_grpc_proto_secret_proto_init() {
 			}
 		}
 		file_internal_grpc_proto_secret_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SecretGetListResponse); i {
+			switch v := v.(*SecretGetListRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -616,7 +1173,7 @@ func file_internal_grpc_proto_secret_proto_init() {
 			}
 		}
 		file_internal_grpc_proto_secret_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SecretGetRequest); i {
+			switch v := v.(*SecretGetListResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -628,7 +1185,7 @@ func file_internal_grpc_proto_secret_proto_init() {
 			}
 		}
 		file_internal_grpc_proto_secret_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SecretCreateRequest); i {
+			switch v := v.(*SecretGetRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -640,7 +1197,7 @@ func file_internal_grpc_proto_secret_proto_init() {
 			}
 		}
 		file_internal_grpc_proto_secret_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SecretUpdateRequest); i {
+			switch v := v.(*SecretCreateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -652,6 +1209,18 @@ func file_internal_grpc_proto_secret_proto_init() {
 			}
 		}
 		file_internal_grpc_proto_secret_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_grpc_proto_secret_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SecretDeleteRequest); i {
 			case 0:
 				return &v.state
@@ -663,14 +1232,74 @@ func file_internal_grpc_proto_secret_proto_init() {
 				return nil
 			}
 		}
+		file_internal_grpc_proto_secret_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretCreateOneTimeShareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_grpc_proto_secret_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretCreateOneTimeShareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_grpc_proto_secret_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretRedeemOneTimeShareRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_grpc_proto_secret_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretRedeemOneTimeShareResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_grpc_proto_secret_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_grpc_proto_secret_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      2,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -63,41 +63,69 @@ var File_internal_grpc_proto_service_proto protoreflect.FileDescriptor
 var file_internal_grpc_proto_service_proto_rawDesc = []byte{
 	0x0a, 0x21, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x69, 0x6e, 0x74, 0x65,
+	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x69, 0x6e, 0x74, 0x65,
 	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07, 0x0a, 0x05,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0xb8, 0x03, 0x0a, 0x11, 0x47, 0x6f, 0x70, 0x68, 0x4b, 0x65,
-	0x65, 0x70, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2f, 0x0a, 0x0a, 0x55,
-	0x73, 0x65, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55,
-	0x73, 0x65, 0x72, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2e, 0x0a, 0x09,
-	0x55, 0x73, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55,
-	0x73, 0x65, 0x72, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3b, 0x0a, 0x0d,
-	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x0c, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1c, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x53, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x47, 0x65, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x65, 0x63, 0x72, 0x65, 0x74, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x38,
-	0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x1a,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72,
-	0x65, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x12, 0x38, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65,
-	0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x22, 0x0a, 0x04,
-	0x50, 0x69, 0x6e, 0x67, 0x12, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x42, 0x0f, 0x5a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70,
-	0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x07, 0x0a,
+	0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x32, 0xd6, 0x06, 0x0a, 0x11, 0x47, 0x6f, 0x70, 0x68, 0x4b,
+	0x65, 0x65, 0x70, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2f, 0x0a, 0x0a,
+	0x55, 0x73, 0x65, 0x72, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2e, 0x0a,
+	0x09, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x0b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x55, 0x73, 0x65, 0x72, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x50, 0x0a,
+	0x0f, 0x55, 0x73, 0x65, 0x72, 0x47, 0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67,
+	0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x47, 0x65, 0x74,
+	0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x47, 0x65, 0x74, 0x41,
+	0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2e, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x10, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x4a, 0x0a, 0x0d, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x47,
+	0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x47, 0x65, 0x74, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x47, 0x65, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x12, 0x38, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0c, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x38, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x31,
+	0x0a, 0x0b, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x0c, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30,
+	0x01, 0x12, 0x6b, 0x0a, 0x18, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x26, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x4f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4f, 0x6e, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6b,
+	0x0a, 0x18, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x4f, 0x6e,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x26, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x4f,
+	0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x52, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x4f, 0x6e, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x04, 0x50,
+	0x69, 0x6e, 0x67, 0x12, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42,
+	0x0f, 0x5a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -114,38 +142,57 @@ func file_internal_grpc_proto_service_proto_rawDescGZIP() []byte {
 
 var file_internal_grpc_proto_service_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_internal_grpc_proto_service_proto_goTypes = []interface{}{
-	(*Empty)(nil),                 // 0: proto.Empty
-	(*User)(nil),                  // 1: proto.User
-	(*SecretGetRequest)(nil),      // 2: proto.SecretGetRequest
-	(*SecretCreateRequest)(nil),   // 3: proto.SecretCreateRequest
-	(*SecretUpdateRequest)(nil),   // 4: proto.SecretUpdateRequest
-	(*SecretDeleteRequest)(nil),   // 5: proto.SecretDeleteRequest
-	(*UserAuthToken)(nil),         // 6: proto.UserAuthToken
-	(*SecretGetListResponse)(nil), // 7: proto.SecretGetListResponse
-	(*Secret)(nil),                // 8: proto.Secret
+	(*Empty)(nil),                            // 0: proto.Empty
+	(*User)(nil),                             // 1: proto.User
+	(*UserGetAuditLogRequest)(nil),           // 2: proto.UserGetAuditLogRequest
+	(*SecretGetListRequest)(nil),             // 3: proto.SecretGetListRequest
+	(*SecretGetRequest)(nil),                 // 4: proto.SecretGetRequest
+	(*SecretCreateRequest)(nil),              // 5: proto.SecretCreateRequest
+	(*SecretUpdateRequest)(nil),              // 6: proto.SecretUpdateRequest
+	(*SecretDeleteRequest)(nil),              // 7: proto.SecretDeleteRequest
+	(*SecretCreateOneTimeShareRequest)(nil),  // 8: proto.SecretCreateOneTimeShareRequest
+	(*SecretRedeemOneTimeShareRequest)(nil),  // 9: proto.SecretRedeemOneTimeShareRequest
+	(*UserAuthToken)(nil),                    // 10: proto.UserAuthToken
+	(*UserGetAuditLogResponse)(nil),          // 11: proto.UserGetAuditLogResponse
+	(*UserUsage)(nil),                        // 12: proto.UserUsage
+	(*SecretGetListResponse)(nil),            // 13: proto.SecretGetListResponse
+	(*Secret)(nil),                           // 14: proto.Secret
+	(*SecretEvent)(nil),                      // 15: proto.SecretEvent
+	(*SecretCreateOneTimeShareResponse)(nil), // 16: proto.SecretCreateOneTimeShareResponse
+	(*SecretRedeemOneTimeShareResponse)(nil), // 17: proto.SecretRedeemOneTimeShareResponse
 }
 var file_internal_grpc_proto_service_proto_depIdxs = []int32{
-	1, // 0: proto.GophKeeperService.UserCreate:input_type -> proto.User
-	1, // 1: proto.GophKeeperService.UserLogin:input_type -> proto.User
-	0, // 2: proto.GophKeeperService.SecretGetList:input_type -> proto.Empty
-	2, // 3: proto.GophKeeperService.SecretGet:input_type -> proto.SecretGetRequest
-	3, // 4: proto.GophKeeperService.SecretCreate:input_type -> proto.SecretCreateRequest
-	4, // 5: proto.GophKeeperService.SecretUpdate:input_type -> proto.SecretUpdateRequest
-	5, // 6: proto.GophKeeperService.SecretDelete:input_type -> proto.SecretDeleteRequest
-	0, // 7: proto.GophKeeperService.Ping:input_type -> proto.Empty
-	6, // 8: proto.GophKeeperService.UserCreate:output_type -> proto.UserAuthToken
-	6, // 9: proto.GophKeeperService.UserLogin:output_type -> proto.UserAuthToken
-	7, // 10: proto.GophKeeperService.SecretGetList:output_type -> proto.SecretGetListResponse
-	8, // 11: proto.GophKeeperService.SecretGet:output_type -> proto.Secret
-	0, // 12: proto.GophKeeperService.SecretCreate:output_type -> proto.Empty
-	0, // 13: proto.GophKeeperService.SecretUpdate:output_type -> proto.Empty
-	0, // 14: proto.GophKeeperService.SecretDelete:output_type -> proto.Empty
-	0, // 15: proto.GophKeeperService.Ping:output_type -> proto.Empty
-	8, // [8:16] is the sub-list for method output_type
-	0, // [0:8] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1,  // 0: proto.GophKeeperService.UserCreate:input_type -> proto.User
+	1,  // 1: proto.GophKeeperService.UserLogin:input_type -> proto.User
+	2,  // 2: proto.GophKeeperService.UserGetAuditLog:input_type -> proto.UserGetAuditLogRequest
+	0,  // 3: proto.GophKeeperService.UserGetUsage:input_type -> proto.Empty
+	3,  // 4: proto.GophKeeperService.SecretGetList:input_type -> proto.SecretGetListRequest
+	4,  // 5: proto.GophKeeperService.SecretGet:input_type -> proto.SecretGetRequest
+	5,  // 6: proto.GophKeeperService.SecretCreate:input_type -> proto.SecretCreateRequest
+	6,  // 7: proto.GophKeeperService.SecretUpdate:input_type -> proto.SecretUpdateRequest
+	7,  // 8: proto.GophKeeperService.SecretDelete:input_type -> proto.SecretDeleteRequest
+	0,  // 9: proto.GophKeeperService.SecretWatch:input_type -> proto.Empty
+	8,  // 10: proto.GophKeeperService.SecretCreateOneTimeShare:input_type -> proto.SecretCreateOneTimeShareRequest
+	9,  // 11: proto.GophKeeperService.SecretRedeemOneTimeShare:input_type -> proto.SecretRedeemOneTimeShareRequest
+	0,  // 12: proto.GophKeeperService.Ping:input_type -> proto.Empty
+	10, // 13: proto.GophKeeperService.UserCreate:output_type -> proto.UserAuthToken
+	10, // 14: proto.GophKeeperService.UserLogin:output_type -> proto.UserAuthToken
+	11, // 15: proto.GophKeeperService.UserGetAuditLog:output_type -> proto.UserGetAuditLogResponse
+	12, // 16: proto.GophKeeperService.UserGetUsage:output_type -> proto.UserUsage
+	13, // 17: proto.GophKeeperService.SecretGetList:output_type -> proto.SecretGetListResponse
+	14, // 18: proto.GophKeeperService.SecretGet:output_type -> proto.Secret
+	0,  // 19: proto.GophKeeperService.SecretCreate:output_type -> proto.Empty
+	0,  // 20: proto.GophKeeperService.SecretUpdate:output_type -> proto.Empty
+	0,  // 21: proto.GophKeeperService.SecretDelete:output_type -> proto.Empty
+	15, // 22: proto.GophKeeperService.SecretWatch:output_type -> proto.SecretEvent
+	16, // 23: proto.GophKeeperService.SecretCreateOneTimeShare:output_type -> proto.SecretCreateOneTimeShareResponse
+	17, // 24: proto.GophKeeperService.SecretRedeemOneTimeShare:output_type -> proto.SecretRedeemOneTimeShareResponse
+	0,  // 25: proto.GophKeeperService.Ping:output_type -> proto.Empty
+	13, // [13:26] is the sub-list for method output_type
+	0,  // [0:13] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_internal_grpc_proto_service_proto_init() }
@@ -153,6 +200,7 @@ func file_internal_grpc_proto_service_proto_init() {
 	if File_internal_grpc_proto_service_proto != nil {
 		return
 	}
+	file_internal_grpc_proto_audit_proto_init()
 	file_internal_grpc_proto_secret_proto_init()
 	file_internal_grpc_proto_user_proto_init()
 	if !protoimpl.UnsafeEnabled {
@@ -19,14 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	GophKeeperService_UserCreate_FullMethodName    = "/proto.GophKeeperService/UserCreate"
-	GophKeeperService_UserLogin_FullMethodName     = "/proto.GophKeeperService/UserLogin"
-	GophKeeperService_SecretGetList_FullMethodName = "/proto.GophKeeperService/SecretGetList"
-	GophKeeperService_SecretGet_FullMethodName     = "/proto.GophKeeperService/SecretGet"
-	GophKeeperService_SecretCreate_FullMethodName  = "/proto.GophKeeperService/SecretCreate"
-	GophKeeperService_SecretUpdate_FullMethodName  = "/proto.GophKeeperService/SecretUpdate"
-	GophKeeperService_SecretDelete_FullMethodName  = "/proto.GophKeeperService/SecretDelete"
-	GophKeeperService_Ping_FullMethodName          = "/proto.GophKeeperService/Ping"
+	GophKeeperService_UserCreate_FullMethodName               = "/proto.GophKeeperService/UserCreate"
+	GophKeeperService_UserLogin_FullMethodName                = "/proto.GophKeeperService/UserLogin"
+	GophKeeperService_UserGetAuditLog_FullMethodName          = "/proto.GophKeeperService/UserGetAuditLog"
+	GophKeeperService_UserGetUsage_FullMethodName             = "/proto.GophKeeperService/UserGetUsage"
+	GophKeeperService_SecretGetList_FullMethodName            = "/proto.GophKeeperService/SecretGetList"
+	GophKeeperService_SecretGet_FullMethodName                = "/proto.GophKeeperService/SecretGet"
+	GophKeeperService_SecretCreate_FullMethodName             = "/proto.GophKeeperService/SecretCreate"
+	GophKeeperService_SecretUpdate_FullMethodName             = "/proto.GophKeeperService/SecretUpdate"
+	GophKeeperService_SecretDelete_FullMethodName             = "/proto.GophKeeperService/SecretDelete"
+	GophKeeperService_SecretWatch_FullMethodName              = "/proto.GophKeeperService/SecretWatch"
+	GophKeeperService_SecretCreateOneTimeShare_FullMethodName = "/proto.GophKeeperService/SecretCreateOneTimeShare"
+	GophKeeperService_SecretRedeemOneTimeShare_FullMethodName = "/proto.GophKeeperService/SecretRedeemOneTimeShare"
+	GophKeeperService_Ping_FullMethodName                     = "/proto.GophKeeperService/Ping"
 )
 
 // GophKeeperServiceClient is the client API for GophKeeperService service.
@@ -36,12 +41,17 @@ type GophKeeperServiceClient interface {
 	// User
 	UserCreate(ctx context.Context, in *User, opts ...grpc.CallOption) (*UserAuthToken, error)
 	UserLogin(ctx context.Context, in *User, opts ...grpc.CallOption) (*UserAuthToken, error)
+	UserGetAuditLog(ctx context.Context, in *UserGetAuditLogRequest, opts ...grpc.CallOption) (*UserGetAuditLogResponse, error)
+	UserGetUsage(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserUsage, error)
 	// Secret
-	SecretGetList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SecretGetListResponse, error)
+	SecretGetList(ctx context.Context, in *SecretGetListRequest, opts ...grpc.CallOption) (*SecretGetListResponse, error)
 	SecretGet(ctx context.Context, in *SecretGetRequest, opts ...grpc.CallOption) (*Secret, error)
 	SecretCreate(ctx context.Context, in *SecretCreateRequest, opts ...grpc.CallOption) (*Empty, error)
 	SecretUpdate(ctx context.Context, in *SecretUpdateRequest, opts ...grpc.CallOption) (*Empty, error)
 	SecretDelete(ctx context.Context, in *SecretDeleteRequest, opts ...grpc.CallOption) (*Empty, error)
+	SecretWatch(ctx context.Context, in *Empty, opts ...grpc.CallOption) (GophKeeperService_SecretWatchClient, error)
+	SecretCreateOneTimeShare(ctx context.Context, in *SecretCreateOneTimeShareRequest, opts ...grpc.CallOption) (*SecretCreateOneTimeShareResponse, error)
+	SecretRedeemOneTimeShare(ctx context.Context, in *SecretRedeemOneTimeShareRequest, opts ...grpc.CallOption) (*SecretRedeemOneTimeShareResponse, error)
 	// Other
 	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 }
@@ -72,7 +82,25 @@ func (c *gophKeeperServiceClient) UserLogin(ctx context.Context, in *User, opts
 	return out, nil
 }
 
-func (c *gophKeeperServiceClient) SecretGetList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SecretGetListResponse, error) {
+func (c *gophKeeperServiceClient) UserGetAuditLog(ctx context.Context, in *UserGetAuditLogRequest, opts ...grpc.CallOption) (*UserGetAuditLogResponse, error) {
+	out := new(UserGetAuditLogResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_UserGetAuditLog_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) UserGetUsage(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UserUsage, error) {
+	out := new(UserUsage)
+	err := c.cc.Invoke(ctx, GophKeeperService_UserGetUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) SecretGetList(ctx context.Context, in *SecretGetListRequest, opts ...grpc.CallOption) (*SecretGetListResponse, error) {
 	out := new(SecretGetListResponse)
 	err := c.cc.Invoke(ctx, GophKeeperService_SecretGetList_FullMethodName, in, out, opts...)
 	if err != nil {
@@ -117,6 +145,56 @@ func (c *gophKeeperServiceClient) SecretDelete(ctx context.Context, in *SecretDe
 	return out, nil
 }
 
+func (c *gophKeeperServiceClient) SecretWatch(ctx context.Context, in *Empty, opts ...grpc.CallOption) (GophKeeperService_SecretWatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GophKeeperService_ServiceDesc.Streams[0], GophKeeperService_SecretWatch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gophKeeperServiceSecretWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GophKeeperService_SecretWatchClient interface {
+	Recv() (*SecretEvent, error)
+	grpc.ClientStream
+}
+
+type gophKeeperServiceSecretWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *gophKeeperServiceSecretWatchClient) Recv() (*SecretEvent, error) {
+	m := new(SecretEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gophKeeperServiceClient) SecretCreateOneTimeShare(ctx context.Context, in *SecretCreateOneTimeShareRequest, opts ...grpc.CallOption) (*SecretCreateOneTimeShareResponse, error) {
+	out := new(SecretCreateOneTimeShareResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_SecretCreateOneTimeShare_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gophKeeperServiceClient) SecretRedeemOneTimeShare(ctx context.Context, in *SecretRedeemOneTimeShareRequest, opts ...grpc.CallOption) (*SecretRedeemOneTimeShareResponse, error) {
+	out := new(SecretRedeemOneTimeShareResponse)
+	err := c.cc.Invoke(ctx, GophKeeperService_SecretRedeemOneTimeShare_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gophKeeperServiceClient) Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
 	out := new(Empty)
 	err := c.cc.Invoke(ctx, GophKeeperService_Ping_FullMethodName, in, out, opts...)
@@ -133,12 +211,17 @@ type GophKeeperServiceServer interface {
 	// User
 	UserCreate(context.Context, *User) (*UserAuthToken, error)
 	UserLogin(context.Context, *User) (*UserAuthToken, error)
+	UserGetAuditLog(context.Context, *UserGetAuditLogRequest) (*UserGetAuditLogResponse, error)
+	UserGetUsage(context.Context, *Empty) (*UserUsage, error)
 	// Secret
-	SecretGetList(context.Context, *Empty) (*SecretGetListResponse, error)
+	SecretGetList(context.Context, *SecretGetListRequest) (*SecretGetListResponse, error)
 	SecretGet(context.Context, *SecretGetRequest) (*Secret, error)
 	SecretCreate(context.Context, *SecretCreateRequest) (*Empty, error)
 	SecretUpdate(context.Context, *SecretUpdateRequest) (*Empty, error)
 	SecretDelete(context.Context, *SecretDeleteRequest) (*Empty, error)
+	SecretWatch(*Empty, GophKeeperService_SecretWatchServer) error
+	SecretCreateOneTimeShare(context.Context, *SecretCreateOneTimeShareRequest) (*SecretCreateOneTimeShareResponse, error)
+	SecretRedeemOneTimeShare(context.Context, *SecretRedeemOneTimeShareRequest) (*SecretRedeemOneTimeShareResponse, error)
 	// Other
 	Ping(context.Context, *Empty) (*Empty, error)
 	mustEmbedUnimplementedGophKeeperServiceServer()
@@ -154,7 +237,13 @@ func (UnimplementedGophKeeperServiceServer) UserCreate(context.Context, *User) (
 func (UnimplementedGophKeeperServiceServer) UserLogin(context.Context, *User) (*UserAuthToken, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UserLogin not implemented")
 }
-func (UnimplementedGophKeeperServiceServer) SecretGetList(context.Context, *Empty) (*SecretGetListResponse, error) {
+func (UnimplementedGophKeeperServiceServer) UserGetAuditLog(context.Context, *UserGetAuditLogRequest) (*UserGetAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserGetAuditLog not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) UserGetUsage(context.Context, *Empty) (*UserUsage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserGetUsage not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) SecretGetList(context.Context, *SecretGetListRequest) (*SecretGetListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SecretGetList not implemented")
 }
 func (UnimplementedGophKeeperServiceServer) SecretGet(context.Context, *SecretGetRequest) (*Secret, error) {
@@ -169,6 +258,15 @@ func (UnimplementedGophKeeperServiceServer) SecretUpdate(context.Context, *Secre
 func (UnimplementedGophKeeperServiceServer) SecretDelete(context.Context, *SecretDeleteRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SecretDelete not implemented")
 }
+func (UnimplementedGophKeeperServiceServer) SecretWatch(*Empty, GophKeeperService_SecretWatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method SecretWatch not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) SecretCreateOneTimeShare(context.Context, *SecretCreateOneTimeShareRequest) (*SecretCreateOneTimeShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecretCreateOneTimeShare not implemented")
+}
+func (UnimplementedGophKeeperServiceServer) SecretRedeemOneTimeShare(context.Context, *SecretRedeemOneTimeShareRequest) (*SecretRedeemOneTimeShareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecretRedeemOneTimeShare not implemented")
+}
 func (UnimplementedGophKeeperServiceServer) Ping(context.Context, *Empty) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
@@ -221,11 +319,47 @@ func _GophKeeperService_UserLogin_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GophKeeperService_SecretGetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _GophKeeperService_UserGetAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserGetAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).UserGetAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_UserGetAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).UserGetAuditLog(ctx, req.(*UserGetAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_UserGetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).UserGetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_UserGetUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).UserGetUsage(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_SecretGetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretGetListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
 	if interceptor == nil {
 		return srv.(GophKeeperServiceServer).SecretGetList(ctx, in)
 	}
@@ -234,7 +368,7 @@ func _GophKeeperService_SecretGetList_Handler(srv interface{}, ctx context.Conte
 		FullMethod: GophKeeperService_SecretGetList_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GophKeeperServiceServer).SecretGetList(ctx, req.(*Empty))
+		return srv.(GophKeeperServiceServer).SecretGetList(ctx, req.(*SecretGetListRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -311,6 +445,63 @@ func _GophKeeperService_SecretDelete_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GophKeeperService_SecretWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GophKeeperServiceServer).SecretWatch(m, &gophKeeperServiceSecretWatchServer{stream})
+}
+
+type GophKeeperService_SecretWatchServer interface {
+	Send(*SecretEvent) error
+	grpc.ServerStream
+}
+
+type gophKeeperServiceSecretWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *gophKeeperServiceSecretWatchServer) Send(m *SecretEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GophKeeperService_SecretCreateOneTimeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretCreateOneTimeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).SecretCreateOneTimeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_SecretCreateOneTimeShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).SecretCreateOneTimeShare(ctx, req.(*SecretCreateOneTimeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GophKeeperService_SecretRedeemOneTimeShare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretRedeemOneTimeShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GophKeeperServiceServer).SecretRedeemOneTimeShare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GophKeeperService_SecretRedeemOneTimeShare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GophKeeperServiceServer).SecretRedeemOneTimeShare(ctx, req.(*SecretRedeemOneTimeShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GophKeeperService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -344,6 +535,14 @@ var GophKeeperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UserLogin",
 			Handler:    _GophKeeperService_UserLogin_Handler,
 		},
+		{
+			MethodName: "UserGetAuditLog",
+			Handler:    _GophKeeperService_UserGetAuditLog_Handler,
+		},
+		{
+			MethodName: "UserGetUsage",
+			Handler:    _GophKeeperService_UserGetUsage_Handler,
+		},
 		{
 			MethodName: "SecretGetList",
 			Handler:    _GophKeeperService_SecretGetList_Handler,
@@ -364,11 +563,25 @@ var GophKeeperService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SecretDelete",
 			Handler:    _GophKeeperService_SecretDelete_Handler,
 		},
+		{
+			MethodName: "SecretCreateOneTimeShare",
+			Handler:    _GophKeeperService_SecretCreateOneTimeShare_Handler,
+		},
+		{
+			MethodName: "SecretRedeemOneTimeShare",
+			Handler:    _GophKeeperService_SecretRedeemOneTimeShare_Handler,
+		},
 		{
 			MethodName: "Ping",
 			Handler:    _GophKeeperService_Ping_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SecretWatch",
+			Handler:       _GophKeeperService_SecretWatch_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "internal/grpc/proto/service.proto",
 }
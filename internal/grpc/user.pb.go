@@ -122,6 +122,77 @@ func (x *UserAuthToken) GetToken() string {
 	return ""
 }
 
+type UserUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bytes      int64 `protobuf:"varint,1,opt,name=bytes,proto3" json:"bytes,omitempty"` // total size of secrets payload
+	Secrets    int64 `protobuf:"varint,2,opt,name=secrets,proto3" json:"secrets,omitempty"`
+	MaxBytes   int64 `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`       // 0 - unlimited
+	MaxSecrets int64 `protobuf:"varint,4,opt,name=max_secrets,json=maxSecrets,proto3" json:"max_secrets,omitempty"` // 0 - unlimited
+}
+
+func (x *UserUsage) Reset() {
+	*x = UserUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_grpc_proto_user_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserUsage) ProtoMessage() {}
+
+func (x *UserUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_grpc_proto_user_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserUsage.ProtoReflect.Descriptor instead.
+func (*UserUsage) Descriptor() ([]byte, []int) {
+	return file_internal_grpc_proto_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UserUsage) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *UserUsage) GetSecrets() int64 {
+	if x != nil {
+		return x.Secrets
+	}
+	return 0
+}
+
+func (x *UserUsage) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *UserUsage) GetMaxSecrets() int64 {
+	if x != nil {
+		return x.MaxSecrets
+	}
+	return 0
+}
+
 var File_internal_grpc_proto_user_proto protoreflect.FileDescriptor
 
 var file_internal_grpc_proto_user_proto_rawDesc = []byte{
@@ -133,9 +204,16 @@ var file_internal_grpc_proto_user_proto_rawDesc = []byte{
 	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
 	0x64, 0x22, 0x25, 0x0a, 0x0d, 0x55, 0x73, 0x65, 0x72, 0x41, 0x75, 0x74, 0x68, 0x54, 0x6f, 0x6b,
 	0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x0f, 0x5a, 0x0d, 0x69, 0x6e, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x79, 0x0a, 0x09, 0x55, 0x73, 0x65, 0x72,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -150,10 +228,11 @@ func file_internal_grpc_proto_user_proto_rawDescGZIP() []byte {
 	return file_internal_grpc_proto_user_proto_rawDescData
 }
 
-var file_internal_grpc_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_grpc_proto_user_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_internal_grpc_proto_user_proto_goTypes = []interface{}{
 	(*User)(nil),          // 0: proto.User
 	(*UserAuthToken)(nil), // 1: proto.UserAuthToken
+	(*UserUsage)(nil),     // 2: proto.UserUsage
 }
 var file_internal_grpc_proto_user_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -193,6 +272,18 @@ func file_internal_grpc_proto_user_proto_init() {
 				return nil
 			}
 		}
+		file_internal_grpc_proto_user_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -200,7 +291,7 @@ func file_internal_grpc_proto_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_grpc_proto_user_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,275 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
+	pb "github.com/devldavydov/gophkeeper/internal/grpc"
+	"github.com/devldavydov/gophkeeper/internal/grpc/interceptor"
+	"github.com/devldavydov/gophkeeper/internal/server/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	_msgAdminUserBadRequest     = "invalid user login"
+	_msgAdminQuotaBadRequest    = "invalid user quota"
+	_msgAdminUsersFailedToGet   = "failed to get users"
+	_msgAdminUserFailedToUpdate = "failed to update user"
+	_msgAdminUserFailedToGet    = "failed to get user stats"
+	_msgAdminUserFailedToDelete = "failed to delete user"
+)
+
+// AdminServer represents gRPC server of administration service.
+type AdminServer struct {
+	pb.UnimplementedAdminServiceServer
+	stg    storage.Storage
+	logger *logrus.Logger
+}
+
+// NewAdminServer creates new AdminServer object.
+//
+// All requests are authenticated with adminAuth interceptor,
+// interceptors are called in given order after request ID interceptor and before authentication.
+func NewAdminServer(
+	stg storage.Storage,
+	tlsCredentials credentials.TransportCredentials,
+	adminAuth *interceptor.AdminAuthInterceptor,
+	logger *logrus.Logger,
+	interceptors ...interceptor.Interceptor,
+) (*grpc.Server, *AdminServer) {
+	chain := append([]interceptor.Interceptor{interceptor.NewRequestIDInterceptor(logger)}, interceptors...)
+	chain = append(chain, adminAuth)
+
+	unaryInterceptors := make([]grpc.UnaryServerInterceptor, 0, len(chain))
+	streamInterceptors := make([]grpc.StreamServerInterceptor, 0, len(chain))
+	for _, i := range chain {
+		unaryInterceptors = append(unaryInterceptors, i.Handle)
+		streamInterceptors = append(streamInterceptors, i.HandleStream)
+	}
+
+	grpcSrv := grpc.NewServer(
+		grpc.Creds(tlsCredentials),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	srv := &AdminServer{stg: stg, logger: logger}
+	pb.RegisterAdminServiceServer(grpcSrv, srv)
+	return grpcSrv, srv
+}
+
+// ListUsers - gRPC handler to list all users.
+//
+// Returns users, ordered by login, or error code:
+//
+// - Internal - unexpected error.
+func (a *AdminServer) ListUsers(ctx context.Context, _ *pb.Empty) (*pb.AdminListUsersResponse, error) {
+	dbUsers, err := a.stg.ListUsers(ctx)
+	if err != nil {
+		a.log(ctx).Errorf("list users error: %v", err)
+		return nil, status.Error(codes.Internal, _msgAdminUsersFailedToGet)
+	}
+
+	resp := &pb.AdminListUsersResponse{Users: make([]*pb.AdminUser, 0, len(dbUsers))}
+	for _, dbUser := range dbUsers {
+		resp.Users = append(resp.Users, &pb.AdminUser{
+			Id:        dbUser.ID,
+			Login:     dbUser.Login,
+			Disabled:  dbUser.Disabled,
+			CreatedAt: dbUser.CreatedAt.Unix(),
+		})
+	}
+
+	return resp, nil
+}
+
+// DisableUser - gRPC handler to disable user. Disabled user can not login and use issued tokens.
+// Accepts user login.
+//
+// Returns empty response or error code:
+//
+// - InvalidArgument - empty user login.
+//
+// - NotFound - user not found.
+//
+// - Internal - unexpected error.
+func (a *AdminServer) DisableUser(ctx context.Context, in *pb.AdminUserRequest) (*pb.Empty, error) {
+	if err := a.checkRequest(ctx, in); err != nil {
+		return nil, err
+	}
+
+	if err := a.stg.SetUserDisabled(ctx, in.Login, true); err != nil {
+		return nil, a.userError(ctx, "disable", in.Login, err, _msgAdminUserFailedToUpdate)
+	}
+
+	a.log(ctx).Infof("user [%s] disabled", in.Login)
+	return &pb.Empty{}, nil
+}
+
+// EnableUser - gRPC handler to enable disabled user. Accepts user login.
+//
+// Returns empty response or error code:
+//
+// - InvalidArgument - empty user login.
+//
+// - NotFound - user not found.
+//
+// - Internal - unexpected error.
+func (a *AdminServer) EnableUser(ctx context.Context, in *pb.AdminUserRequest) (*pb.Empty, error) {
+	if err := a.checkRequest(ctx, in); err != nil {
+		return nil, err
+	}
+
+	if err := a.stg.SetUserDisabled(ctx, in.Login, false); err != nil {
+		return nil, a.userError(ctx, "enable", in.Login, err, _msgAdminUserFailedToUpdate)
+	}
+
+	a.log(ctx).Infof("user [%s] enabled", in.Login)
+	return &pb.Empty{}, nil
+}
+
+// ForceLogout - gRPC handler to revoke all issued user's tokens. User should login again.
+// Accepts user login.
+//
+// Returns empty response or error code:
+//
+// - InvalidArgument - empty user login.
+//
+// - NotFound - user not found.
+//
+// - Internal - unexpected error.
+func (a *AdminServer) ForceLogout(ctx context.Context, in *pb.AdminUserRequest) (*pb.Empty, error) {
+	if err := a.checkRequest(ctx, in); err != nil {
+		return nil, err
+	}
+
+	if err := a.stg.LogoutUser(ctx, in.Login); err != nil {
+		return nil, a.userError(ctx, "logout", in.Login, err, _msgAdminUserFailedToUpdate)
+	}
+
+	a.log(ctx).Infof("user [%s] logged out", in.Login)
+	return &pb.Empty{}, nil
+}
+
+// GetUserStats - gRPC handler to get user's secrets count, total payload size and effective quota.
+// Accepts user login.
+//
+// Returns user stats or error code:
+//
+// - InvalidArgument - empty user login.
+//
+// - NotFound - user not found.
+//
+// - Internal - unexpected error.
+func (a *AdminServer) GetUserStats(ctx context.Context, in *pb.AdminUserRequest) (*pb.AdminUserStats, error) {
+	if err := a.checkRequest(ctx, in); err != nil {
+		return nil, err
+	}
+
+	stats, err := a.stg.GetUserStats(ctx, in.Login)
+	if err != nil {
+		return nil, a.userError(ctx, "get stats of", in.Login, err, _msgAdminUserFailedToGet)
+	}
+
+	return &pb.AdminUserStats{
+		Login:        in.Login,
+		Secrets:      stats.Secrets,
+		SecretsBytes: stats.Bytes,
+		MaxBytes:     stats.MaxBytes,
+		MaxSecrets:   stats.MaxSecrets,
+	}, nil
+}
+
+// SetUserQuota - gRPC handler to override user's quota or reset it to default. Zero limit means unlimited.
+// Accepts user login and quota.
+//
+// Returns empty response or error code:
+//
+// - InvalidArgument - empty user login or negative limit.
+//
+// - NotFound - user not found.
+//
+// - Internal - unexpected error.
+func (a *AdminServer) SetUserQuota(ctx context.Context, in *pb.AdminSetUserQuotaRequest) (*pb.Empty, error) {
+	if err := a.checkRequest(ctx, in); err != nil {
+		return nil, err
+	}
+
+	var quota *storage.Quota
+	if !in.ResetDefault {
+		if in.MaxBytes < 0 || in.MaxSecrets < 0 {
+			a.log(ctx).Errorf("invalid quota request: negative limit [%d, %d]", in.MaxBytes, in.MaxSecrets)
+			return nil, status.Error(codes.InvalidArgument, _msgAdminQuotaBadRequest)
+		}
+		quota = &storage.Quota{MaxBytes: in.MaxBytes, MaxSecrets: in.MaxSecrets}
+	}
+
+	if err := a.stg.SetUserQuota(ctx, in.Login, quota); err != nil {
+		return nil, a.userError(ctx, "set quota of", in.Login, err, _msgAdminUserFailedToUpdate)
+	}
+
+	if quota == nil {
+		a.log(ctx).Infof("user [%s] quota reset to default", in.Login)
+	} else {
+		a.log(ctx).Infof("user [%s] quota set: bytes %d, secrets %d", in.Login, quota.MaxBytes, quota.MaxSecrets)
+	}
+	return &pb.Empty{}, nil
+}
+
+// DeleteUser - gRPC handler to delete user with all secrets. User's audit log is kept.
+// Accepts user login.
+//
+// Returns empty response or error code:
+//
+// - InvalidArgument - empty user login.
+//
+// - NotFound - user not found.
+//
+// - Internal - unexpected error.
+func (a *AdminServer) DeleteUser(ctx context.Context, in *pb.AdminUserRequest) (*pb.Empty, error) {
+	if err := a.checkRequest(ctx, in); err != nil {
+		return nil, err
+	}
+
+	if err := a.stg.DeleteUser(ctx, in.Login); err != nil {
+		return nil, a.userError(ctx, "delete", in.Login, err, _msgAdminUserFailedToDelete)
+	}
+
+	a.log(ctx).Infof("user [%s] deleted", in.Login)
+	return &pb.Empty{}, nil
+}
+
+// log returns request logger.
+func (a *AdminServer) log(ctx context.Context) *logrus.Entry {
+	return gkLog.FromContext(ctx, a.logger)
+}
+
+// loginRequest is a request for user with given login.
+type loginRequest interface {
+	GetLogin() string
+}
+
+// checkRequest validates user request.
+func (a *AdminServer) checkRequest(ctx context.Context, in loginRequest) error {
+	if in.GetLogin() == "" {
+		a.log(ctx).Error("invalid user request: empty login")
+		return status.Error(codes.InvalidArgument, _msgAdminUserBadRequest)
+	}
+
+	return nil
+}
+
+// userError logs storage error of user operation and converts it to gRPC status error.
+func (a *AdminServer) userError(ctx context.Context, operation, login string, err error, msg string) error {
+	if errors.Is(err, storage.ErrUserNotFound) {
+		a.log(ctx).Errorf("failed to %s user [%s]: not found", operation, login)
+		return status.Error(codes.NotFound, _msgUserNotFound)
+	}
+
+	a.log(ctx).Errorf("failed to %s user [%s]: %v", operation, login, err)
+	return status.Error(codes.Internal, msg)
+}
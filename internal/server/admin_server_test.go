@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	pb "github.com/devldavydov/gophkeeper/internal/grpc"
+	"github.com/devldavydov/gophkeeper/internal/server/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminStorage stores users in memory, other storage methods are not implemented.
+type adminStorage struct {
+	storage.Storage
+	users map[string]*storage.UserInfo
+	stats map[string]*model.Usage
+	quota map[string]*storage.Quota
+	err   error
+}
+
+func (a *adminStorage) ListUsers(_ context.Context) ([]storage.UserInfo, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	users := make([]storage.UserInfo, 0, len(a.users))
+	for _, user := range a.users {
+		users = append(users, *user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Login < users[j].Login })
+	return users, nil
+}
+
+func (a *adminStorage) SetUserDisabled(_ context.Context, login string, disabled bool) error {
+	user, err := a.getUser(login)
+	if err != nil {
+		return err
+	}
+	user.Disabled = disabled
+	return nil
+}
+
+func (a *adminStorage) LogoutUser(_ context.Context, login string) error {
+	_, err := a.getUser(login)
+	return err
+}
+
+func (a *adminStorage) GetUserStats(_ context.Context, login string) (*model.Usage, error) {
+	if _, err := a.getUser(login); err != nil {
+		return nil, err
+	}
+	return a.stats[login], nil
+}
+
+func (a *adminStorage) SetUserQuota(_ context.Context, login string, quota *storage.Quota) error {
+	if _, err := a.getUser(login); err != nil {
+		return err
+	}
+	a.quota[login] = quota
+	return nil
+}
+
+func (a *adminStorage) DeleteUser(_ context.Context, login string) error {
+	if _, err := a.getUser(login); err != nil {
+		return err
+	}
+	delete(a.users, login)
+	return nil
+}
+
+func (a *adminStorage) getUser(login string) (*storage.UserInfo, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+
+	user, ok := a.users[login]
+	if !ok {
+		return nil, storage.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func TestAdminServer(t *testing.T) {
+	created := time.Unix(1700000000, 0)
+	stg := &adminStorage{
+		users: map[string]*storage.UserInfo{
+			"alice": {ID: 1, Login: "alice", CreatedAt: created},
+			"bob":   {ID: 2, Login: "bob", CreatedAt: created},
+		},
+		stats: map[string]*model.Usage{"alice": {Bytes: 100, Secrets: 2, MaxBytes: 1000, MaxSecrets: 10}},
+		quota: map[string]*storage.Quota{},
+	}
+	srv := &AdminServer{stg: stg, logger: logrus.New()}
+	ctx := context.Background()
+
+	t.Run("list users", func(t *testing.T) {
+		resp, err := srv.ListUsers(ctx, &pb.Empty{})
+		require.NoError(t, err)
+		require.Len(t, resp.Users, 2)
+		assert.Equal(t, "alice", resp.Users[0].Login)
+		assert.Equal(t, int64(1), resp.Users[0].Id)
+		assert.Equal(t, created.Unix(), resp.Users[0].CreatedAt)
+		assert.Equal(t, "bob", resp.Users[1].Login)
+	})
+
+	t.Run("disable and enable user", func(t *testing.T) {
+		_, err := srv.DisableUser(ctx, &pb.AdminUserRequest{Login: "bob"})
+		require.NoError(t, err)
+		assert.True(t, stg.users["bob"].Disabled)
+
+		_, err = srv.EnableUser(ctx, &pb.AdminUserRequest{Login: "bob"})
+		require.NoError(t, err)
+		assert.False(t, stg.users["bob"].Disabled)
+	})
+
+	t.Run("force logout", func(t *testing.T) {
+		_, err := srv.ForceLogout(ctx, &pb.AdminUserRequest{Login: "bob"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("get user stats", func(t *testing.T) {
+		stats, err := srv.GetUserStats(ctx, &pb.AdminUserRequest{Login: "alice"})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", stats.Login)
+		assert.Equal(t, int64(2), stats.Secrets)
+		assert.Equal(t, int64(100), stats.SecretsBytes)
+		assert.Equal(t, int64(1000), stats.MaxBytes)
+		assert.Equal(t, int64(10), stats.MaxSecrets)
+	})
+
+	t.Run("set user quota", func(t *testing.T) {
+		_, err := srv.SetUserQuota(ctx, &pb.AdminSetUserQuotaRequest{Login: "alice", MaxBytes: 2000})
+		require.NoError(t, err)
+		assert.Equal(t, &storage.Quota{MaxBytes: 2000}, stg.quota["alice"])
+
+		_, err = srv.SetUserQuota(ctx, &pb.AdminSetUserQuotaRequest{Login: "alice", MaxBytes: 2000, ResetDefault: true})
+		require.NoError(t, err)
+		assert.Nil(t, stg.quota["alice"])
+
+		_, err = srv.SetUserQuota(ctx, &pb.AdminSetUserQuotaRequest{Login: "alice", MaxSecrets: -1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("delete user", func(t *testing.T) {
+		_, err := srv.DeleteUser(ctx, &pb.AdminUserRequest{Login: "bob"})
+		require.NoError(t, err)
+		assert.NotContains(t, stg.users, "bob")
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		_, err := srv.DisableUser(ctx, &pb.AdminUserRequest{Login: "bob"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		_, err = srv.GetUserStats(ctx, &pb.AdminUserRequest{Login: "bob"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("empty login", func(t *testing.T) {
+		_, err := srv.DeleteUser(ctx, &pb.AdminUserRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestAdminServerStorageError(t *testing.T) {
+	srv := &AdminServer{stg: &adminStorage{err: errors.New("storage error")}, logger: logrus.New()}
+
+	_, err := srv.ListUsers(context.Background(), &pb.Empty{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	_, err = srv.ForceLogout(context.Background(), &pb.AdminUserRequest{Login: "alice"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
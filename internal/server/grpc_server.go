@@ -2,29 +2,50 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/devldavydov/gophkeeper/internal/common/cipher"
+	gkLog "github.com/devldavydov/gophkeeper/internal/common/log"
 	"github.com/devldavydov/gophkeeper/internal/common/model"
+	gkTLS "github.com/devldavydov/gophkeeper/internal/common/tls"
 	"github.com/devldavydov/gophkeeper/internal/common/token"
 	pb "github.com/devldavydov/gophkeeper/internal/grpc"
 	"github.com/devldavydov/gophkeeper/internal/grpc/interceptor"
 	"github.com/devldavydov/gophkeeper/internal/server/storage"
+	"github.com/devldavydov/gophkeeper/internal/server/watch"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
 const (
 	MetaUserID = "USER_ID"
 
+	_metaUserAgent = "user-agent"
+
 	_userTokenExpiration = 24 * time.Hour
+	_eventPublishTimeout = 5 * time.Second
+	_auditWriteTimeout   = 5 * time.Second
+	_auditLogLimit       = 1000
+
+	_shareDefaultTTL       = 24 * time.Hour
+	_shareMaxTTL           = 7 * 24 * time.Hour
+	_shareMaxViews         = 100
+	_shareIDLength         = 16
+	_shareRedeemRateLimit  = 10
+	_shareRedeemRateWindow = time.Minute
 
 	_msgPingFailed = "ping failed"
 	//
@@ -36,9 +57,17 @@ const (
 	_msgUserInvalidLoginOrPassword = "user invalid login or password"
 	_msgUserNotFound               = "user not found"
 	_msgUserFailedToLogin          = "failed to login"
+	_msgUserClientCertMismatch     = "client certificate does not match user"
+	_msgUserDisabled               = "user disabled"
+	_msgUserFailedToCheck          = "failed to check user"
+	_msgUserForbidden              = "forbidden"
+	_msgAuditLogBadRequest         = "invalid audit log time range"
+	_msgAuditLogFailedToGet        = "failed to get audit log"
+	_msgUsageFailedToGet           = "failed to get usage"
 	//
 	_msgSecretsNotFound           = "secrets not found" //nolint:gosec // Ok
 	_msgSecretsFailedToGet        = "failed to get secrets"
+	_msgSecretsBadFilter          = "invalid secrets filter"
 	_msgSecretBadRequest          = "invalid secret"
 	_msgSecretFailedToCreate      = "failed to create secret"
 	_msgSecretAlreadyExists       = "secret already exists"
@@ -47,35 +76,83 @@ const (
 	_msgSecretFailedToUpdate      = "failed to update secret"
 	_msgSecretOutdated            = "secret outdated"
 	_msgSecretPayloadSizeExceeded = "secret payload size exceeded"
+	_msgSecretQuotaExceeded       = "secret quota exceeded"
+	_msgSecretWatchInterrupted    = "secret watch interrupted"
+	//
+	_msgShareBadRequest     = "invalid share"
+	_msgShareFailedToCreate = "failed to create share"
+	_msgShareNotFound       = "share not found"
+	_msgShareFailedToRedeem = "failed to redeem share"
 )
 
 // GrpcServer represents gRPC server.
 type GrpcServer struct {
 	pb.UnimplementedGophKeeperServiceServer
-	stg          storage.Storage
-	serverSecret []byte
-	logger       *logrus.Logger
+	stg            storage.Storage
+	broker         watch.Broker
+	serverSecret   []byte
+	bindClientCert bool
+	logger         *logrus.Logger
 }
 
 // NewGrpcServer creates new GRPCServer object.
+//
+// If bindClientCert is set, users can create account and login only with client certificate,
+// which subject common name is equal to user login.
+//
+// interceptors are called in given order after request ID interceptor, which sets request logger,
+// and before authentication. One-time share redeem, available without authentication, is rate limited by peer host.
 func NewGrpcServer(
 	stg storage.Storage,
+	broker watch.Broker,
 	tlsCredentials credentials.TransportCredentials,
 	serverSecret []byte,
+	bindClientCert bool,
 	logger *logrus.Logger,
+	interceptors ...interceptor.Interceptor,
 ) (*grpc.Server, *GrpcServer) {
+	srv := &GrpcServer{
+		stg:            stg,
+		broker:         broker,
+		serverSecret:   serverSecret,
+		bindClientCert: bindClientCert,
+		logger:         logger,
+	}
+
+	authInterceptor := interceptor.NewAuthTokenInterceptor(
+		[]string{
+			pb.GophKeeperService_UserGetAuditLog_FullMethodName,
+			pb.GophKeeperService_UserGetUsage_FullMethodName,
+			pb.GophKeeperService_SecretGetList_FullMethodName,
+			pb.GophKeeperService_SecretGet_FullMethodName,
+			pb.GophKeeperService_SecretCreate_FullMethodName,
+			pb.GophKeeperService_SecretUpdate_FullMethodName,
+			pb.GophKeeperService_SecretDelete_FullMethodName,
+			pb.GophKeeperService_SecretWatch_FullMethodName,
+			pb.GophKeeperService_SecretCreateOneTimeShare_FullMethodName,
+			pb.GophKeeperService_Ping_FullMethodName,
+		},
+		serverSecret,
+		srv.checkUser)
+
+	chain := append([]interceptor.Interceptor{interceptor.NewRequestIDInterceptor(logger)}, interceptors...)
+	chain = append(chain,
+		interceptor.NewRateLimitInterceptor(
+			[]string{pb.GophKeeperService_SecretRedeemOneTimeShare_FullMethodName},
+			_shareRedeemRateLimit,
+			_shareRedeemRateWindow),
+		authInterceptor)
+
+	unaryInterceptors := make([]grpc.UnaryServerInterceptor, 0, len(chain))
+	streamInterceptors := make([]grpc.StreamServerInterceptor, 0, len(chain))
+	for _, i := range chain {
+		unaryInterceptors = append(unaryInterceptors, i.Handle)
+		streamInterceptors = append(streamInterceptors, i.HandleStream)
+	}
+
 	opts := []grpc.ServerOption{
-		grpc.UnaryInterceptor(
-			interceptor.NewAuthTokenInterceptor(
-				[]string{
-					pb.GophKeeperService_SecretGetList_FullMethodName,
-					pb.GophKeeperService_SecretGet_FullMethodName,
-					pb.GophKeeperService_SecretCreate_FullMethodName,
-					pb.GophKeeperService_SecretUpdate_FullMethodName,
-					pb.GophKeeperService_SecretDelete_FullMethodName,
-					pb.GophKeeperService_Ping_FullMethodName,
-				},
-				serverSecret).Handle),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 		grpc.MaxRecvMsgSize(model.MaxPayloadSizeBytes + 1024),
 		grpc.MaxSendMsgSize(model.MaxPayloadSizeBytes + 1024),
 	}
@@ -83,7 +160,6 @@ func NewGrpcServer(
 	opts = append([]grpc.ServerOption{grpc.Creds(tlsCredentials)}, opts...)
 
 	grpcSrv := grpc.NewServer(opts...)
-	srv := &GrpcServer{stg: stg, serverSecret: serverSecret, logger: logger}
 	pb.RegisterGophKeeperServiceServer(grpcSrv, srv)
 	return grpcSrv, srv
 }
@@ -96,34 +172,45 @@ func NewGrpcServer(
 //
 // - AlreadyExists - user already exists.
 //
+// - PermissionDenied - client certificate does not match user.
+//
 // - Unavailable - user token generation error.
 //
 // - Internal - unexpected error.
-func (g *GrpcServer) UserCreate(ctx context.Context, user *pb.User) (*pb.UserAuthToken, error) {
+func (g *GrpcServer) UserCreate(ctx context.Context, user *pb.User) (_ *pb.UserAuthToken, err error) {
+	audit := &model.AuditEvent{Type: model.AuditUserCreate, Login: user.Login}
+	defer g.writeAuditEvent(ctx, audit, &err)
+
 	if user.Login == "" || user.Password == "" {
-		g.logger.Errorf("invalid user credentials: user='%s' password='%s'", user.Login, user.Password)
+		g.log(ctx).Errorf("invalid user credentials: user='%s'", user.Login)
 		return nil, status.Error(codes.InvalidArgument, _msgUserCredentialsBadRequest)
 	}
 
+	certSubject, err := g.checkClientCert(ctx, user.Login)
+	if err != nil {
+		return nil, err
+	}
+
 	pwdHash, err := hashPassword(user.Password)
 	if err != nil {
-		g.logger.Errorf("create user [%s] password hash error: %v", user.Login, err)
+		g.log(ctx).Errorf("create user [%s] password hash error: %v", user.Login, err)
 		return nil, status.Error(codes.Internal, _msgUserFailedToCreate)
 	}
 
 	userID, err := g.stg.CreateUser(ctx, user.Login, pwdHash)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserAlreadyExists) {
-			g.logger.Errorf("failed to create user [%s]: already exists", user.Login)
+			g.log(ctx).Errorf("failed to create user [%s]: already exists", user.Login)
 			return nil, status.Error(codes.AlreadyExists, _msgUserAlreadyExists)
 		}
-		g.logger.Errorf("failed to create user [%s]: %v", user.Login, err)
+		g.log(ctx).Errorf("failed to create user [%s]: %v", user.Login, err)
 		return nil, status.Error(codes.Internal, _msgUserFailedToCreate)
 	}
+	audit.UserID = userID
 
-	token, err := token.NewJWTForUser(userID, _userTokenExpiration, g.serverSecret)
+	token, err := token.NewJWTForUser(userID, certSubject, _userTokenExpiration, g.serverSecret)
 	if err != nil {
-		g.logger.Errorf("failed to create token for user [%s, %d]: %v", user.Login, userID, err)
+		g.log(ctx).Errorf("failed to create token for user [%s, %d]: %v", user.Login, userID, err)
 		return nil, status.Error(codes.Unavailable, _msgUserFailedToCreateToken)
 	}
 
@@ -136,65 +223,180 @@ func (g *GrpcServer) UserCreate(ctx context.Context, user *pb.User) (*pb.UserAut
 //
 // - NotFound - user not found.
 //
-// - PermissionDenied - user authentication failed.
+// - PermissionDenied - user authentication failed, user disabled or client certificate does not match user.
 //
 // - Unavailable - user token generation error.
 //
 // - Internal - unexpected error.
-func (g *GrpcServer) UserLogin(ctx context.Context, user *pb.User) (*pb.UserAuthToken, error) {
+func (g *GrpcServer) UserLogin(ctx context.Context, user *pb.User) (_ *pb.UserAuthToken, err error) {
+	audit := &model.AuditEvent{Type: model.AuditUserLogin, Login: user.Login}
+	defer g.writeAuditEvent(ctx, audit, &err)
+
+	certSubject, err := g.checkClientCert(ctx, user.Login)
+	if err != nil {
+		return nil, err
+	}
+
 	userID, pwdHash, err := g.stg.FindUser(ctx, user.Login)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserNotFound) {
-			g.logger.Errorf("login error for [%s]: not found", user.Login)
+			g.log(ctx).Errorf("login error for [%s]: not found", user.Login)
 			return nil, status.Error(codes.NotFound, _msgUserNotFound)
 		}
 
-		g.logger.Errorf("login error for [%s]: %v", user.Login, err)
+		g.log(ctx).Errorf("login error for [%s]: %v", user.Login, err)
 		return nil, status.Error(codes.Internal, _msgUserFailedToLogin)
 	}
+	audit.UserID = userID
 
 	if err = checkPassword(user.Password, pwdHash); err != nil {
-		g.logger.Errorf("login error for [%s]: wrong password", user.Login)
+		g.log(ctx).Errorf("login error for [%s]: wrong password", user.Login)
 		return nil, status.Error(codes.PermissionDenied, _msgUserInvalidLoginOrPassword)
 	}
 
-	token, err := token.NewJWTForUser(userID, _userTokenExpiration, g.serverSecret)
+	access, err := g.stg.GetUserAccess(ctx, userID)
+	if err != nil {
+		g.log(ctx).Errorf("login error for [%s]: %v", user.Login, err)
+		return nil, status.Error(codes.Internal, _msgUserFailedToLogin)
+	}
+
+	if access.Disabled {
+		g.log(ctx).Errorf("login error for [%s]: user disabled", user.Login)
+		return nil, status.Error(codes.PermissionDenied, _msgUserDisabled)
+	}
+
+	token, err := token.NewJWTForUser(userID, certSubject, _userTokenExpiration, g.serverSecret)
 	if err != nil {
-		g.logger.Errorf("failed to create token for user [%s, %d]: %v", user.Login, userID, err)
+		g.log(ctx).Errorf("failed to create token for user [%s, %d]: %v", user.Login, userID, err)
 		return nil, status.Error(codes.Unavailable, _msgUserFailedToCreateToken)
 	}
 
 	return &pb.UserAuthToken{Token: token}, nil
 }
 
-// SecretGetList - gRPC handler to get user's secrets list. Accepts user id in context.
+// UserGetAuditLog - gRPC handler to get user's audit log. Accepts user id in context and time range.
+//
+// Returns user's audit events, newest first, or error code:
+//
+// - InvalidArgument - invalid time range.
+//
+// - Internal - unexpected error.
+func (g *GrpcServer) UserGetAuditLog(
+	ctx context.Context,
+	in *pb.UserGetAuditLogRequest,
+) (*pb.UserGetAuditLogResponse, error) {
+	userID, err := g.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.From < 0 || in.To < 0 || (in.To != 0 && in.From > in.To) {
+		g.log(ctx).Errorf("audit log get error: invalid time range [%d, %d]", in.From, in.To)
+		return nil, status.Error(codes.InvalidArgument, _msgAuditLogBadRequest)
+	}
+
+	var from, to time.Time
+	if in.From != 0 {
+		from = time.Unix(in.From, 0)
+	}
+	if in.To != 0 {
+		to = time.Unix(in.To, 0)
+	}
+
+	dbEvents, err := g.stg.GetAuditEvents(ctx, userID, from, to, _auditLogLimit)
+	if err != nil {
+		g.log(ctx).Errorf("audit log get error: %v", err)
+		return nil, status.Error(codes.Internal, _msgAuditLogFailedToGet)
+	}
+
+	resp := &pb.UserGetAuditLogResponse{Items: make([]*pb.AuditEvent, 0, len(dbEvents))}
+	for _, dbEvent := range dbEvents {
+		resp.Items = append(resp.Items, &pb.AuditEvent{
+			EventType:   pb.AuditEventType(dbEvent.Type),
+			SecretName:  dbEvent.SecretName,
+			PeerAddress: dbEvent.PeerAddress,
+			UserAgent:   dbEvent.UserAgent,
+			Outcome:     dbEvent.Outcome,
+			CreatedAt:   dbEvent.CreatedAt.Unix(),
+		})
+	}
+
+	return resp, nil
+}
+
+// UserGetUsage - gRPC handler to get user's storage usage and quota. Accepts user id in context.
+//
+// Returns user's usage or error code:
+//
+// - Internal - unexpected error.
+func (g *GrpcServer) UserGetUsage(ctx context.Context, _ *pb.Empty) (*pb.UserUsage, error) {
+	userID, err := g.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := g.stg.GetUserUsage(ctx, userID)
+	if err != nil {
+		g.log(ctx).Errorf("usage get error: %v", err)
+		return nil, status.Error(codes.Internal, _msgUsageFailedToGet)
+	}
+
+	return &pb.UserUsage{
+		Bytes:      usage.Bytes,
+		Secrets:    usage.Secrets,
+		MaxBytes:   usage.MaxBytes,
+		MaxSecrets: usage.MaxSecrets,
+	}, nil
+}
+
+// SecretGetList - gRPC handler to get user's secrets list. Accepts user id in context and
+// optional filter of secrets expiring or due to rotation within N days.
 //
 // Returns user's secrets list or error code:
 //
+// - InvalidArgument - negative days filter.
+//
 // - NotFound - user's secrets not found.
 //
 // - Internal - unexpected error.
-func (g *GrpcServer) SecretGetList(ctx context.Context, _ *pb.Empty) (*pb.SecretGetListResponse, error) {
+func (g *GrpcServer) SecretGetList(
+	ctx context.Context,
+	in *pb.SecretGetListRequest,
+) (*pb.SecretGetListResponse, error) {
 	userID, err := g.getUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	dbSecretList, err := g.stg.GetAllSecrets(ctx, userID)
+	if in.ExpiringWithinDays < 0 {
+		g.log(ctx).Errorf("secret get list error: invalid expiring within days [%d]", in.ExpiringWithinDays)
+		return nil, status.Error(codes.InvalidArgument, _msgSecretsBadFilter)
+	}
+
+	var dueBefore time.Time
+	if in.ExpiringWithinDays > 0 {
+		dueBefore = time.Now().AddDate(0, 0, int(in.ExpiringWithinDays))
+	}
+
+	dbSecretList, err := g.stg.GetAllSecrets(ctx, userID, dueBefore)
 	if err != nil {
 		if errors.Is(err, storage.ErrNoSecrets) {
 			return nil, status.Error(codes.NotFound, _msgSecretsNotFound)
 		}
-		g.logger.Errorf("[user=%d] secret get list error %v", userID, err)
+		g.log(ctx).Errorf("secret get list error %v", err)
 		return nil, status.Error(codes.Internal, _msgSecretsFailedToGet)
 	}
 
 	respList := &pb.SecretGetListResponse{Items: make([]*pb.SecretListItem, 0, len(dbSecretList))}
 	for _, dbItem := range dbSecretList {
 		respList.Items = append(respList.Items, &pb.SecretListItem{
-			Name:    dbItem.Name,
-			Type:    pb.SecretType(dbItem.Type),
-			Version: dbItem.Version,
+			Name:             dbItem.Name,
+			Type:             pb.SecretType(dbItem.Type),
+			Version:          dbItem.Version,
+			UpdatedAt:        dbItem.UpdatedAt.Unix(),
+			PayloadUpdatedAt: dbItem.PayloadUpdatedAt.Unix(),
+			ExpiresAt:        toUnix(dbItem.ExpiresAt),
+			RotateEvery:      int64(dbItem.RotateEvery / time.Second),
 		})
 	}
 
@@ -208,36 +410,40 @@ func (g *GrpcServer) SecretGetList(ctx context.Context, _ *pb.Empty) (*pb.Secret
 // - NotFound - user's secret not found.
 //
 // - Internal - unexpected error.
-func (g *GrpcServer) SecretGet(ctx context.Context, in *pb.SecretGetRequest) (*pb.Secret, error) {
+func (g *GrpcServer) SecretGet(ctx context.Context, in *pb.SecretGetRequest) (_ *pb.Secret, err error) {
 	userID, err := g.getUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer g.writeAuditEvent(
+		ctx, &model.AuditEvent{Type: model.AuditSecretRead, UserID: userID, SecretName: in.Name}, &err)
 
 	// Get from storage
 	dbSecret, err := g.stg.GetSecret(ctx, userID, in.Name)
 	if err != nil {
 		if errors.Is(err, storage.ErrSecretNotFound) {
-			g.logger.Errorf("[user=%d] secret [%s] get error: not found", userID, in.Name)
+			g.log(ctx).Errorf("secret [%s] get error: not found", in.Name)
 			return nil, status.Error(codes.NotFound, _msgSecretNotFound)
 		}
-		g.logger.Errorf("[user=%d] secret [%s] get error: %v", userID, in.Name, err)
+		g.log(ctx).Errorf("secret [%s] get error: %v", in.Name, err)
 		return nil, status.Error(codes.Internal, _msgSecretsFailedToGet)
 	}
 
 	// Decode payload
-	payloadRaw, err := cipher.AESDecrypt(dbSecret.PayloadRaw, g.serverSecret)
+	payloadRaw, err := cipher.AESDecryptContext(ctx, dbSecret.PayloadRaw, g.serverSecret)
 	if err != nil {
-		g.logger.Errorf("[user=%d] secret [%s] get error: decrypt payload error: %v", userID, in.Name, err)
+		g.log(ctx).Errorf("secret [%s] get error: decrypt payload error: %v", in.Name, err)
 		return nil, status.Error(codes.Internal, _msgSecretsFailedToGet)
 	}
 
 	secret := &pb.Secret{
-		Name:       dbSecret.Name,
-		Type:       pb.SecretType(dbSecret.Type),
-		Version:    dbSecret.Version,
-		Meta:       dbSecret.Meta,
-		PayloadRaw: payloadRaw,
+		Name:        dbSecret.Name,
+		Type:        pb.SecretType(dbSecret.Type),
+		Version:     dbSecret.Version,
+		Meta:        dbSecret.Meta,
+		PayloadRaw:  payloadRaw,
+		ExpiresAt:   toUnix(dbSecret.ExpiresAt),
+		RotateEvery: int64(dbSecret.RotateEvery / time.Second),
 	}
 
 	return secret, nil
@@ -249,61 +455,82 @@ func (g *GrpcServer) SecretGet(ctx context.Context, in *pb.SecretGetRequest) (*p
 //
 // - InvalidArgument - secret invalid.
 //
-// - ResourceExhausted - secret payload too big.
+// - ResourceExhausted - secret payload too big or user's quota exceeded (with QuotaFailure details).
 //
 // - AlreadyExists - secret already exists.
 //
 // - Internal - unexpected error.
-func (g *GrpcServer) SecretCreate(ctx context.Context, in *pb.SecretCreateRequest) (*pb.Empty, error) {
+func (g *GrpcServer) SecretCreate(ctx context.Context, in *pb.SecretCreateRequest) (_ *pb.Empty, err error) {
 	userID, err := g.getUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer g.writeAuditEvent(
+		ctx, &model.AuditEvent{Type: model.AuditSecretCreate, UserID: userID, SecretName: in.Secret.GetName()}, &err)
 
 	// Check secret
 	if err = model.ValidSecretType(model.SecretType(in.Secret.Type)); err != nil {
-		g.logger.Errorf("[user=%d] secret create error: invalid type [%d]", userID, in.Secret.Type)
+		g.log(ctx).Errorf("secret create error: invalid type [%d]", in.Secret.Type)
 		return nil, status.Error(codes.InvalidArgument, _msgSecretBadRequest)
 	}
 
 	if in.Secret.Name == "" {
-		g.logger.Errorf("[user=%d] secret create error: empty name", userID)
+		g.log(ctx).Error("secret create error: empty name")
+		return nil, status.Error(codes.InvalidArgument, _msgSecretBadRequest)
+	}
+
+	if in.Secret.ExpiresAt < 0 || in.Secret.RotateEvery < 0 {
+		g.log(ctx).Errorf("secret [%s] create error: invalid expiration", in.Secret.Name)
 		return nil, status.Error(codes.InvalidArgument, _msgSecretBadRequest)
 	}
 
 	if len(in.Secret.PayloadRaw) > model.MaxPayloadSizeBytes {
-		g.logger.Errorf(
-			"[user=%d] secret payload size (%d) exceed limit (%d)", userID, len(in.Secret.PayloadRaw), model.MaxPayloadSizeBytes)
+		g.log(ctx).Errorf(
+			"secret payload size (%d) exceed limit (%d)", len(in.Secret.PayloadRaw), model.MaxPayloadSizeBytes)
 		return nil, status.Error(codes.ResourceExhausted, _msgSecretPayloadSizeExceeded)
 	}
 
 	// Encrypt payload
-	encPayload, err := cipher.AESEncrpyt(in.Secret.PayloadRaw, g.serverSecret)
+	encPayload, err := cipher.AESEncryptContext(ctx, in.Secret.PayloadRaw, g.serverSecret)
 	if err != nil {
-		g.logger.Errorf("[user=%d] secret [%s] create error: encrypt payload error: %v", userID, in.Secret.Name, err)
+		g.log(ctx).Errorf("secret [%s] create error: encrypt payload error: %v", in.Secret.Name, err)
 		return nil, status.Error(codes.Internal, _msgSecretFailedToCreate)
 	}
 
 	// Create secret
 	secret := &model.Secret{
-		Type:       model.SecretType(in.Secret.Type),
-		Name:       in.Secret.Name,
-		Meta:       in.Secret.Meta,
-		Version:    in.Secret.Version,
-		PayloadRaw: encPayload,
+		Type:        model.SecretType(in.Secret.Type),
+		Name:        in.Secret.Name,
+		Meta:        in.Secret.Meta,
+		Version:     in.Secret.Version,
+		PayloadRaw:  encPayload,
+		ExpiresAt:   fromUnix(in.Secret.ExpiresAt),
+		RotateEvery: time.Duration(in.Secret.RotateEvery) * time.Second,
 	}
 
 	err = g.stg.CreateSecret(ctx, userID, secret)
 	if err != nil {
 		if errors.Is(err, storage.ErrSecretAlreadyExists) {
-			g.logger.Errorf("[user=%d] secret [%s] create error: already exists", userID, in.Secret.Name)
+			g.log(ctx).Errorf("secret [%s] create error: already exists", in.Secret.Name)
 			return nil, status.Error(codes.AlreadyExists, _msgSecretFailedToCreate)
 		}
 
-		g.logger.Errorf("[user=%d] secret [%s] create error: %v", userID, in.Secret.Name, err)
+		if errors.Is(err, storage.ErrQuotaExceeded) {
+			g.log(ctx).Errorf("secret [%s] create error: %v", in.Secret.Name, err)
+			return nil, quotaExceededError(err)
+		}
+
+		g.log(ctx).Errorf("secret [%s] create error: %v", in.Secret.Name, err)
 		return nil, status.Error(codes.Internal, _msgSecretFailedToCreate)
 	}
 
+	g.publishEvent(ctx, userID, model.SecretEvent{
+		EventType:  model.SecretCreated,
+		Name:       secret.Name,
+		SecretType: secret.Type,
+		Version:    secret.Version,
+	})
+
 	return &pb.Empty{}, nil
 }
 
@@ -317,27 +544,34 @@ func (g *GrpcServer) SecretCreate(ctx context.Context, in *pb.SecretCreateReques
 //
 // - FailedPrecondition - secret outdated (update version less than in storage).
 //
-// - ResourceExhausted - secret payload too big.
+// - ResourceExhausted - secret payload too big or user's quota exceeded (with QuotaFailure details).
 //
 // - Internal - unexpected error.
-func (g *GrpcServer) SecretUpdate(ctx context.Context, in *pb.SecretUpdateRequest) (*pb.Empty, error) {
+func (g *GrpcServer) SecretUpdate(ctx context.Context, in *pb.SecretUpdateRequest) (_ *pb.Empty, err error) {
 	userID, err := g.getUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer g.writeAuditEvent(
+		ctx, &model.AuditEvent{Type: model.AuditSecretUpdate, UserID: userID, SecretName: in.Name}, &err)
+
+	if in.ExpiresAt < 0 || in.RotateEvery < 0 {
+		g.log(ctx).Errorf("secret [%s] update error: invalid expiration", in.Name)
+		return nil, status.Error(codes.InvalidArgument, _msgSecretBadRequest)
+	}
 
 	if len(in.PayloadRaw) > model.MaxPayloadSizeBytes {
-		g.logger.Errorf(
-			"[user=%d] secret payload size (%d) exceed limit (%d)", userID, len(in.PayloadRaw), model.MaxPayloadSizeBytes)
+		g.log(ctx).Errorf(
+			"secret payload size (%d) exceed limit (%d)", len(in.PayloadRaw), model.MaxPayloadSizeBytes)
 		return nil, status.Error(codes.ResourceExhausted, _msgSecretPayloadSizeExceeded)
 	}
 
 	// Encrypt payload
 	var encPayload []byte
 	if in.UpdatePayload {
-		encPayload, err = cipher.AESEncrpyt(in.PayloadRaw, g.serverSecret)
+		encPayload, err = cipher.AESEncryptContext(ctx, in.PayloadRaw, g.serverSecret)
 		if err != nil {
-			g.logger.Errorf("[user=%d] secret [%s] update error: encrypt payload error: %v", userID, in.Name, err)
+			g.log(ctx).Errorf("secret [%s] update error: encrypt payload error: %v", in.Name, err)
 			return nil, status.Error(codes.Internal, _msgSecretFailedToUpdate)
 		}
 	}
@@ -348,6 +582,8 @@ func (g *GrpcServer) SecretUpdate(ctx context.Context, in *pb.SecretUpdateReques
 		Version:       in.Version,
 		PayloadRaw:    encPayload,
 		UpdatePayload: in.UpdatePayload,
+		ExpiresAt:     fromUnix(in.ExpiresAt),
+		RotateEvery:   time.Duration(in.RotateEvery) * time.Second,
 	}
 	err = g.stg.UpdateSecret(ctx, userID, in.Name, updSecret)
 	if err != nil {
@@ -355,22 +591,27 @@ func (g *GrpcServer) SecretUpdate(ctx context.Context, in *pb.SecretUpdateReques
 
 		switch {
 		case errors.Is(err, storage.ErrSecretNotFound):
-			g.logger.Errorf("[user=%d] secret [%s] update error: not found", userID, in.Name)
+			g.log(ctx).Errorf("secret [%s] update error: not found", in.Name)
 			return nil, status.Error(codes.NotFound, _msgSecretNotFound)
 		case errors.Is(err, storage.ErrSecretOutdated):
-			g.logger.Errorf("[user=%d] secret [%s] update error: secret outdated", userID, in.Name)
+			g.log(ctx).Errorf("secret [%s] update error: secret outdated", in.Name)
 			return nil, status.Error(codes.FailedPrecondition, _msgSecretOutdated)
 		case errors.Is(err, storage.ErrSecretWrongVersion):
-			g.logger.Errorf("[user=%d] secret [%s] update error: secret wrong version", userID, in.Name)
+			g.log(ctx).Errorf("secret [%s] update error: secret wrong version", in.Name)
 			return nil, status.Error(codes.InvalidArgument, _msgSecretBadRequest)
+		case errors.Is(err, storage.ErrQuotaExceeded):
+			g.log(ctx).Errorf("secret [%s] update error: %v", in.Name, err)
+			return nil, quotaExceededError(err)
 		default:
-			g.logger.Errorf("[user=%d] secret [%s] update error: %v", userID, in.Name, err)
+			g.log(ctx).Errorf("secret [%s] update error: %v", in.Name, err)
 			errStatus = status.Error(codes.Internal, _msgSecretFailedToUpdate)
 		}
 
 		return nil, errStatus
 	}
 
+	g.publishEvent(ctx, userID, model.SecretEvent{EventType: model.SecretUpdated, Name: in.Name, Version: in.Version})
+
 	return &pb.Empty{}, nil
 }
 
@@ -379,21 +620,180 @@ func (g *GrpcServer) SecretUpdate(ctx context.Context, in *pb.SecretUpdateReques
 // Returns nil or error code:
 //
 // - Internal - unexpected error.
-func (g *GrpcServer) SecretDelete(ctx context.Context, in *pb.SecretDeleteRequest) (*pb.Empty, error) {
+func (g *GrpcServer) SecretDelete(ctx context.Context, in *pb.SecretDeleteRequest) (_ *pb.Empty, err error) {
 	userID, err := g.getUserIDFromContext(ctx)
 	if err != nil {
 		return nil, err
 	}
+	defer g.writeAuditEvent(
+		ctx, &model.AuditEvent{Type: model.AuditSecretDelete, UserID: userID, SecretName: in.Name}, &err)
 
 	err = g.stg.DeleteSecret(ctx, userID, in.Name)
 	if err != nil {
-		g.logger.Errorf("[user=%d] secret [%s] delete error: %v", userID, in.Name, err)
+		g.log(ctx).Errorf("secret [%s] delete error: %v", in.Name, err)
 		return nil, status.Error(codes.Internal, _msgSecretFailedToDelete)
 	}
 
+	g.publishEvent(ctx, userID, model.SecretEvent{EventType: model.SecretDeleted, Name: in.Name})
+
 	return &pb.Empty{}, nil
 }
 
+// SecretWatch - gRPC handler to stream user's secret change events. Accepts user id in context.
+//
+// Streams events till client disconnects or returns error code:
+//
+// - Unavailable - watch interrupted by server (client was too slow, server shutdown),
+// events could be lost and client should reload secrets and watch again.
+//
+// - Internal - unexpected error.
+func (g *GrpcServer) SecretWatch(_ *pb.Empty, stream pb.GophKeeperService_SecretWatchServer) error {
+	ctx := stream.Context()
+
+	userID, err := g.getUserIDFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	sub := g.broker.Subscribe(userID)
+	defer sub.Close()
+
+	// Headers notify client, that subscription is ready
+	if err = stream.SendHeader(metadata.MD{}); err != nil {
+		g.log(ctx).Errorf("secret watch send header error: %v", err)
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.C:
+			if !ok {
+				g.log(ctx).Warn("secret watch interrupted")
+				return status.Error(codes.Unavailable, _msgSecretWatchInterrupted)
+			}
+
+			err = stream.Send(&pb.SecretEvent{
+				EventType: pb.SecretEventType(event.EventType),
+				Name:      event.Name,
+				Type:      pb.SecretType(event.SecretType),
+				Version:   event.Version,
+			})
+			if err != nil {
+				g.log(ctx).Errorf("secret watch send error: %v", err)
+				return err
+			}
+		}
+	}
+}
+
+// SecretCreateOneTimeShare - gRPC handler to create one-time share of secret. Accepts user id in context
+// and share with payload, encrypted on client with key, which is not sent to server.
+//
+// Zero TTL means default TTL, zero max views means single view.
+//
+// Returns share id with expiration time or error code:
+//
+// - InvalidArgument - share invalid.
+//
+// - ResourceExhausted - payload too big or user's quota exceeded (with QuotaFailure details).
+//
+// - Internal - unexpected error.
+func (g *GrpcServer) SecretCreateOneTimeShare(
+	ctx context.Context,
+	in *pb.SecretCreateOneTimeShareRequest,
+) (_ *pb.SecretCreateOneTimeShareResponse, err error) {
+	userID, err := g.getUserIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer g.writeAuditEvent(
+		ctx, &model.AuditEvent{Type: model.AuditShareCreate, UserID: userID, SecretName: in.Name}, &err)
+
+	ttl := time.Duration(in.Ttl) * time.Second
+	if ttl == 0 {
+		ttl = _shareDefaultTTL
+	}
+	maxViews := in.MaxViews
+	if maxViews == 0 {
+		maxViews = 1
+	}
+
+	if model.ValidSecretType(model.SecretType(in.Type)) != nil || len(in.PayloadEncrypted) == 0 ||
+		ttl < 0 || ttl > _shareMaxTTL || maxViews < 0 || maxViews > _shareMaxViews {
+		g.log(ctx).Errorf("share of secret [%s] create error: invalid share", in.Name)
+		return nil, status.Error(codes.InvalidArgument, _msgShareBadRequest)
+	}
+
+	if len(in.PayloadEncrypted) > model.MaxPayloadSizeBytes {
+		g.log(ctx).Errorf(
+			"share payload size (%d) exceed limit (%d)", len(in.PayloadEncrypted), model.MaxPayloadSizeBytes)
+		return nil, status.Error(codes.ResourceExhausted, _msgSecretPayloadSizeExceeded)
+	}
+
+	shareID, err := newShareID()
+	if err != nil {
+		g.log(ctx).Errorf("share of secret [%s] create error: id generation error: %v", in.Name, err)
+		return nil, status.Error(codes.Internal, _msgShareFailedToCreate)
+	}
+
+	share := &model.Share{
+		ID:               shareID,
+		UserID:           userID,
+		SecretName:       in.Name,
+		Type:             model.SecretType(in.Type),
+		PayloadEncrypted: in.PayloadEncrypted,
+		ViewsLeft:        maxViews,
+		ExpiresAt:        time.Now().Add(ttl),
+	}
+	if err = g.stg.CreateShare(ctx, share); err != nil {
+		g.log(ctx).Errorf("share of secret [%s] create error: %v", in.Name, err)
+		if errors.Is(err, storage.ErrQuotaExceeded) {
+			return nil, quotaExceededError(err)
+		}
+		return nil, status.Error(codes.Internal, _msgShareFailedToCreate)
+	}
+
+	return &pb.SecretCreateOneTimeShareResponse{Id: share.ID, ExpiresAt: share.ExpiresAt.Unix()}, nil
+}
+
+// SecretRedeemOneTimeShare - gRPC handler to retrieve one-time share. Accepts share id, authentication
+// is not required. Share is deleted, when no views left.
+//
+// Successful redeem is written to audit log of share owner.
+//
+// Returns encrypted payload or error code:
+//
+// - NotFound - share not found, expired or already viewed.
+//
+// - ResourceExhausted - too many requests from peer (by rate limit interceptor).
+//
+// - Internal - unexpected error.
+func (g *GrpcServer) SecretRedeemOneTimeShare(
+	ctx context.Context,
+	in *pb.SecretRedeemOneTimeShareRequest,
+) (*pb.SecretRedeemOneTimeShareResponse, error) {
+	share, err := g.stg.RedeemShare(ctx, in.Id)
+	if err != nil {
+		if errors.Is(err, storage.ErrShareNotFound) {
+			g.log(ctx).Error("share redeem error: not found")
+			return nil, status.Error(codes.NotFound, _msgShareNotFound)
+		}
+		g.log(ctx).Errorf("share redeem error: %v", err)
+		return nil, status.Error(codes.Internal, _msgShareFailedToRedeem)
+	}
+
+	g.writeAuditEvent(
+		ctx, &model.AuditEvent{Type: model.AuditShareRedeem, UserID: share.UserID, SecretName: share.SecretName}, &err)
+
+	return &pb.SecretRedeemOneTimeShareResponse{
+		Type:             pb.SecretType(share.Type),
+		PayloadEncrypted: share.PayloadEncrypted,
+		ViewsLeft:        share.ViewsLeft,
+	}, nil
+}
+
 // Ping - gRPC handler to check storage availability. Accepts user id.
 //
 // Returns nil or error code:
@@ -407,6 +807,107 @@ func (g *GrpcServer) Ping(ctx context.Context, _ *pb.Empty) (*pb.Empty, error) {
 	return nil, status.Error(codes.Unavailable, _msgPingFailed)
 }
 
+// publishEvent publishes secret event to user's watchers.
+// Secret already changed in storage, so publish error is only logged.
+func (g *GrpcServer) publishEvent(ctx context.Context, userID int64, event model.SecretEvent) {
+	ctxPublish, cancel := context.WithTimeout(context.Background(), _eventPublishTimeout)
+	defer cancel()
+
+	if err := g.broker.Publish(ctxPublish, watch.Event{UserID: userID, Secret: event}); err != nil {
+		g.log(ctx).Errorf("secret [%s] %s event publish error: %v", event.Name, event.EventType, err)
+	}
+}
+
+// writeAuditEvent writes event of request to audit log with peer, user agent and outcome by request error.
+// Audit log write error is only logged and does not fail request.
+func (g *GrpcServer) writeAuditEvent(ctx context.Context, event *model.AuditEvent, errRequest *error) {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		event.PeerAddress = p.Addr.String()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		event.UserAgent = strings.Join(md.Get(_metaUserAgent), " ")
+	}
+	event.Outcome = status.Code(*errRequest).String()
+
+	// Request context could be already canceled
+	ctxWrite, cancel := context.WithTimeout(context.Background(), _auditWriteTimeout)
+	defer cancel()
+
+	if err := g.stg.AddAuditEvent(ctxWrite, event); err != nil {
+		g.log(ctx).Errorf("%s audit event write error: %v", event.Type, err)
+	}
+}
+
+// log returns request logger or server logger, if request logger is not set.
+func (g *GrpcServer) log(ctx context.Context) *logrus.Entry {
+	return gkLog.FromContext(ctx, g.logger)
+}
+
+// checkClientCert returns subject common name of client certificate or empty string, if not given.
+//
+// If certificates are bound to users, certificate is required and its subject should be equal to login,
+// otherwise returns PermissionDenied.
+func (g *GrpcServer) checkClientCert(ctx context.Context, login string) (string, error) {
+	certSubject, ok := gkTLS.PeerCommonName(ctx)
+	if g.bindClientCert && (!ok || certSubject != login) {
+		g.log(ctx).Errorf("client certificate [%s] does not match user [%s]", certSubject, login)
+		return "", status.Error(codes.PermissionDenied, _msgUserClientCertMismatch)
+	}
+
+	return certSubject, nil
+}
+
+// quotaExceededError returns ResourceExhausted status with QuotaFailure details from storage quota error.
+func quotaExceededError(err error) error {
+	st := status.New(codes.ResourceExhausted, _msgSecretQuotaExceeded)
+
+	var quotaErr *storage.QuotaError
+	if !errors.As(err, &quotaErr) {
+		return st.Err()
+	}
+
+	stDetails, errDetails := st.WithDetails(&errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{
+				Subject: quotaErr.Resource,
+				Description: fmt.Sprintf(
+					"used %d, requested %d, limit %d", quotaErr.Used, quotaErr.Requested, quotaErr.Limit),
+			},
+		},
+	})
+	if errDetails != nil {
+		return st.Err()
+	}
+
+	return stDetails.Err()
+}
+
+// toUnix converts time to unix seconds, zero time is 0.
+func toUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// fromUnix converts unix seconds to time, 0 is zero time.
+func fromUnix(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// newShareID returns random URL safe share id.
+func newShareID() (string, error) {
+	b := make([]byte, _shareIDLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
 func hashPassword(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 13)
 	return string(bytes), err
@@ -416,22 +917,62 @@ func checkPassword(password, pwdHash string) error {
 	return bcrypt.CompareHashAndPassword([]byte(pwdHash), []byte(password))
 }
 
+// checkUser checks that user from valid token still exists, is not disabled
+// and token was not revoked by force logout.
+//
+// Returns nil or error code:
+//
+// - PermissionDenied - user not found, disabled or token revoked.
+//
+// - Internal - unexpected error.
+func (g *GrpcServer) checkUser(ctx context.Context, sUserID string, issuedAt time.Time) error {
+	userID, err := strconv.ParseInt(sUserID, 10, 64)
+	if err != nil {
+		g.log(ctx).Errorf("user check error: %v", err)
+		return status.Error(codes.PermissionDenied, _msgUserForbidden)
+	}
+
+	access, err := g.stg.GetUserAccess(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			g.log(ctx).Errorf("user check error: user [%d] not found", userID)
+			return status.Error(codes.PermissionDenied, _msgUserForbidden)
+		}
+		g.log(ctx).Errorf("user check error: %v", err)
+		return status.Error(codes.Internal, _msgUserFailedToCheck)
+	}
+
+	if access.Disabled {
+		g.log(ctx).Errorf("user check error: user [%d] disabled", userID)
+		return status.Error(codes.PermissionDenied, _msgUserDisabled)
+	}
+
+	// Token issue time has seconds precision, so token issued in the same second as logout is revoked too,
+	// otherwise token issued right before logout is still valid
+	if !access.TokensValidAfter.IsZero() && !issuedAt.After(access.TokensValidAfter.Truncate(time.Second)) {
+		g.log(ctx).Errorf("user check error: user [%d] token revoked", userID)
+		return status.Error(codes.PermissionDenied, _msgUserForbidden)
+	}
+
+	return nil
+}
+
 func (g *GrpcServer) getUserIDFromContext(ctx context.Context) (int64, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		g.logger.Error("user token error: no metadata")
+		g.log(ctx).Error("user token error: no metadata")
 		return 0, status.Error(codes.Internal, _msgUserTokenError)
 	}
 
 	sUserID := md.Get(interceptor.MetaUserID)
 	if len(sUserID) != 1 {
-		g.logger.Error("user token error: wrong metadata length")
+		g.log(ctx).Error("user token error: wrong metadata length")
 		return 0, status.Error(codes.Internal, _msgUserTokenError)
 	}
 
 	userID, err := strconv.ParseInt(sUserID[0], 10, 64)
 	if err != nil {
-		g.logger.Errorf("user token error: %v", err)
+		g.log(ctx).Errorf("user token error: %v", err)
 		return 0, status.Error(codes.Internal, _msgUserTokenError)
 	}
 
@@ -0,0 +1,95 @@
+// Package health contains standard gRPC health service of GophKeeper server, driven by storage checks.
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// ServiceLiveness - health service name of liveness check. Serving, while server is running.
+	ServiceLiveness = "liveness"
+	// ServiceReadiness - health service name of readiness check. Serving, while storage is available.
+	// Overall server status ("" service name) is the same as readiness.
+	ServiceReadiness = "readiness"
+
+	// DefaultCheckInterval - default interval of storage checks.
+	DefaultCheckInterval = 5 * time.Second
+)
+
+// Pinger is an interface of storage availability check.
+type Pinger interface {
+	Ping(ctx context.Context) bool
+}
+
+// Checker represents gRPC health service, which readiness is updated by background storage checks.
+type Checker struct {
+	srv      *health.Server
+	pinger   Pinger
+	interval time.Duration
+	logger   *logrus.Logger
+}
+
+// NewChecker creates new Checker object. Until first check server is live, but not ready.
+func NewChecker(pinger Pinger, interval time.Duration, logger *logrus.Logger) *Checker {
+	c := &Checker{
+		srv:      health.NewServer(),
+		pinger:   pinger,
+		interval: interval,
+		logger:   logger,
+	}
+
+	c.srv.SetServingStatus(ServiceLiveness, healthpb.HealthCheckResponse_SERVING)
+	c.setReadiness(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return c
+}
+
+// Register registers health service on gRPC server.
+func (c *Checker) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, c.srv)
+}
+
+// Run checks storage with interval and updates readiness till context canceled.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown sets all services NOT_SERVING. Further status updates are ignored.
+func (c *Checker) Shutdown() {
+	c.srv.Shutdown()
+}
+
+func (c *Checker) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	if c.pinger.Ping(ctx) {
+		c.setReadiness(healthpb.HealthCheckResponse_SERVING)
+		return
+	}
+
+	c.logger.Errorf("health check failed: storage unavailable")
+	c.setReadiness(healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func (c *Checker) setReadiness(status healthpb.HealthCheckResponse_ServingStatus) {
+	c.srv.SetServingStatus(ServiceReadiness, status)
+	c.srv.SetServingStatus("", status)
+}
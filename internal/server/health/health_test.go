@@ -0,0 +1,98 @@
+package health
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const _testCheckInterval = 10 * time.Millisecond
+
+func TestChecker(t *testing.T) {
+	pinger := &testPinger{}
+	checker := NewChecker(pinger, _testCheckInterval, logrus.New())
+	clt := startTestServer(t, checker)
+
+	fnStatus := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := clt.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.Status
+	}
+	fnWaitReadiness := func(expStatus healthpb.HealthCheckResponse_ServingStatus) {
+		assert.Eventually(t, func() bool {
+			return fnStatus(ServiceReadiness) == expStatus && fnStatus("") == expStatus
+		}, time.Second, _testCheckInterval)
+	}
+
+	// Before checks
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, fnStatus(ServiceLiveness))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, fnStatus(ServiceReadiness))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go checker.Run(ctx)
+
+	// Storage unavailable
+	fnWaitReadiness(healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, fnStatus(ServiceLiveness))
+
+	// Storage available
+	pinger.ok.Store(true)
+	fnWaitReadiness(healthpb.HealthCheckResponse_SERVING)
+
+	// Storage failed again
+	pinger.ok.Store(false)
+	fnWaitReadiness(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	// Shutdown is not overridden by checks
+	pinger.ok.Store(true)
+	fnWaitReadiness(healthpb.HealthCheckResponse_SERVING)
+	checker.Shutdown()
+
+	time.Sleep(3 * _testCheckInterval)
+	for _, service := range []string{"", ServiceLiveness, ServiceReadiness} {
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, fnStatus(service), service)
+	}
+}
+
+func TestCheckerUnknownService(t *testing.T) {
+	clt := startTestServer(t, NewChecker(&testPinger{}, _testCheckInterval, logrus.New()))
+
+	_, err := clt.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "foobar"})
+	assert.Error(t, err)
+}
+
+func startTestServer(t *testing.T, checker *Checker) healthpb.HealthClient {
+	t.Helper()
+
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	checker.Register(srv)
+	go func() { _ = srv.Serve(listen) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial(listen.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
+
+type testPinger struct {
+	ok atomic.Bool
+}
+
+func (p *testPinger) Ping(context.Context) bool {
+	return p.ok.Load()
+}
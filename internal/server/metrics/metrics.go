@@ -0,0 +1,173 @@
+// Package metrics contains Prometheus metrics of GophKeeper server.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"time"
+
+	pb "github.com/devldavydov/gophkeeper/internal/grpc"
+	"github.com/devldavydov/gophkeeper/internal/grpc/interceptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Path - HTTP path of metrics endpoint.
+	Path = "/metrics"
+
+	_namespace = "gophkeeper"
+)
+
+// Metrics represents server metrics registry and gRPC interceptor, collecting request metrics.
+type Metrics struct {
+	registry        *prometheus.Registry
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	authFailures    *prometheus.CounterVec
+	payloadSize     *prometheus.HistogramVec
+	activeStreams   *prometheus.GaugeVec
+}
+
+var _ interceptor.Interceptor = (*Metrics)(nil)
+
+// New creates new Metrics object with registered gRPC, Go runtime and process metrics.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: _namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Total number of gRPC requests by method and status code.",
+		}, []string{"method", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: _namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "gRPC request duration by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		authFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: _namespace,
+			Name:      "auth_failures_total",
+			Help:      "Total number of rejected user logins and invalid tokens by method.",
+		}, []string{"method"}),
+		payloadSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: _namespace,
+			Name:      "secret_payload_size_bytes",
+			Help:      "Size of secret payloads, sent to and received from server, by method.",
+			Buckets:   prometheus.ExponentialBuckets(64, 4, 10),
+		}, []string{"method"}),
+		activeStreams: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: _namespace,
+			Name:      "grpc_active_streams",
+			Help:      "Number of active gRPC streams by method.",
+		}, []string{"method"}),
+	}
+
+	m.registry.MustRegister(
+		m.requestsTotal,
+		m.requestDuration,
+		m.authFailures,
+		m.payloadSize,
+		m.activeStreams,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Register - registers additional collector.
+func (m *Metrics) Register(c prometheus.Collector) error {
+	return m.registry.Register(c)
+}
+
+// Handler returns HTTP handler of metrics endpoint.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Handle collects unary request metrics.
+func (m *Metrics) Handle(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	method := path.Base(info.FullMethod)
+	m.observePayload(method, req)
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.observeRequest(method, start, err)
+
+	if err == nil {
+		m.observePayload(method, resp)
+	}
+
+	return resp, err
+}
+
+// HandleStream collects stream metrics. Stream duration is observed, when stream finished.
+func (m *Metrics) HandleStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	method := path.Base(info.FullMethod)
+
+	m.activeStreams.WithLabelValues(method).Inc()
+	defer m.activeStreams.WithLabelValues(method).Dec()
+
+	start := time.Now()
+	err := handler(srv, ss)
+	m.observeRequest(method, start, err)
+
+	return err
+}
+
+func (m *Metrics) observeRequest(method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	m.requestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+	m.requestsTotal.WithLabelValues(method, code.String()).Inc()
+
+	if isAuthFailure(method, code) {
+		m.authFailures.WithLabelValues(method).Inc()
+	}
+}
+
+func (m *Metrics) observePayload(method string, msg interface{}) {
+	var payloadRaw []byte
+
+	switch msg := msg.(type) {
+	case *pb.SecretCreateRequest:
+		payloadRaw = msg.GetSecret().GetPayloadRaw()
+	case *pb.SecretUpdateRequest:
+		if !msg.UpdatePayload {
+			return
+		}
+		payloadRaw = msg.PayloadRaw
+	case *pb.Secret:
+		payloadRaw = msg.PayloadRaw
+	default:
+		return
+	}
+
+	m.payloadSize.WithLabelValues(method).Observe(float64(len(payloadRaw)))
+}
+
+// isAuthFailure checks, that request was rejected because of invalid token or user credentials.
+func isAuthFailure(method string, code codes.Code) bool {
+	if code == codes.PermissionDenied {
+		return true
+	}
+
+	return method == path.Base(pb.GophKeeperService_UserLogin_FullMethodName) && code == codes.NotFound
+}
@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/devldavydov/gophkeeper/internal/grpc"
+	"github.com/devldavydov/gophkeeper/internal/server/storage"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandle(t *testing.T) {
+	m := New()
+
+	fnCall := func(fullMethod string, req, resp interface{}, err error) {
+		_, _ = m.Handle(
+			context.Background(),
+			req,
+			&grpc.UnaryServerInfo{FullMethod: fullMethod},
+			func(context.Context, interface{}) (interface{}, error) { return resp, err })
+	}
+
+	fnCall(pb.GophKeeperService_SecretCreate_FullMethodName,
+		&pb.SecretCreateRequest{Secret: &pb.Secret{PayloadRaw: make([]byte, 100)}}, &pb.Empty{}, nil)
+	fnCall(pb.GophKeeperService_SecretGet_FullMethodName,
+		&pb.SecretGetRequest{}, &pb.Secret{PayloadRaw: make([]byte, 200)}, nil)
+	fnCall(pb.GophKeeperService_SecretGet_FullMethodName,
+		&pb.SecretGetRequest{}, nil, status.Error(codes.PermissionDenied, ""))
+	fnCall(pb.GophKeeperService_SecretUpdate_FullMethodName,
+		&pb.SecretUpdateRequest{PayloadRaw: make([]byte, 300)}, &pb.Empty{}, nil)
+	fnCall(pb.GophKeeperService_UserLogin_FullMethodName,
+		&pb.User{}, nil, status.Error(codes.NotFound, ""))
+	fnCall(pb.GophKeeperService_SecretGet_FullMethodName,
+		&pb.SecretGetRequest{}, nil, status.Error(codes.NotFound, ""))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requestsTotal.WithLabelValues("SecretCreate", "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requestsTotal.WithLabelValues("SecretGet", "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requestsTotal.WithLabelValues("SecretGet", "PermissionDenied")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requestsTotal.WithLabelValues("SecretGet", "NotFound")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requestsTotal.WithLabelValues("UserLogin", "NotFound")))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.authFailures.WithLabelValues("SecretGet")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.authFailures.WithLabelValues("UserLogin")))
+
+	// Update without payload is not observed
+	assert.Equal(t, 2, testutil.CollectAndCount(m.payloadSize))
+	assert.Equal(t, 4, testutil.CollectAndCount(m.requestDuration))
+}
+
+func TestHandleStream(t *testing.T) {
+	m := New()
+	info := &grpc.StreamServerInfo{FullMethod: pb.GophKeeperService_SecretWatch_FullMethodName}
+
+	err := m.HandleStream(nil, nil, info, func(interface{}, grpc.ServerStream) error {
+		assert.Equal(t, 1.0, testutil.ToFloat64(m.activeStreams.WithLabelValues("SecretWatch")))
+		return status.Error(codes.Unavailable, "")
+	})
+	assert.Error(t, err)
+
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.activeStreams.WithLabelValues("SecretWatch")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requestsTotal.WithLabelValues("SecretWatch", "Unavailable")))
+}
+
+func TestHandler(t *testing.T) {
+	m := New()
+	require.NoError(t, m.Register(NewStorageCollector(&testStorage{stats: &storage.Stats{Users: 3, Secrets: 7}}, logrus.New())))
+
+	_, _ = m.Handle(
+		context.Background(),
+		&pb.Empty{},
+		&grpc.UnaryServerInfo{FullMethod: pb.GophKeeperService_Ping_FullMethodName},
+		func(context.Context, interface{}) (interface{}, error) { return &pb.Empty{}, nil })
+
+	srv := httptest.NewServer(m.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + Path)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	for _, line := range []string{
+		`gophkeeper_grpc_requests_total{code="OK",method="Ping"} 1`,
+		`gophkeeper_users 3`,
+		`gophkeeper_secrets 7`,
+	} {
+		assert.True(t, strings.Contains(string(body), line), line)
+	}
+}
+
+func TestStorageCollectorError(t *testing.T) {
+	c := NewStorageCollector(&testStorage{err: errors.New("db error")}, logrus.New())
+	assert.Equal(t, 0, testutil.CollectAndCount(c))
+}
+
+type testStorage struct {
+	stats *storage.Stats
+	err   error
+}
+
+func (s *testStorage) GetStats(context.Context) (*storage.Stats, error) {
+	return s.stats, s.err
+}
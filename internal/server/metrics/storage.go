@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/server/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const _storageStatsTimeout = 5 * time.Second
+
+// StorageStats is an interface of storage, providing totals.
+type StorageStats interface {
+	GetStats(ctx context.Context) (*storage.Stats, error)
+}
+
+// StorageCollector collects users and secrets totals from storage on scrape.
+type StorageCollector struct {
+	stg         StorageStats
+	usersDesc   *prometheus.Desc
+	secretsDesc *prometheus.Desc
+	logger      *logrus.Logger
+}
+
+var _ prometheus.Collector = (*StorageCollector)(nil)
+
+// NewStorageCollector creates new StorageCollector object.
+func NewStorageCollector(stg StorageStats, logger *logrus.Logger) *StorageCollector {
+	return &StorageCollector{
+		stg: stg,
+		usersDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(_namespace, "", "users"),
+			"Number of registered users.",
+			nil, nil),
+		secretsDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(_namespace, "", "secrets"),
+			"Number of stored secrets.",
+			nil, nil),
+		logger: logger,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *StorageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.usersDesc
+	ch <- c.secretsDesc
+}
+
+// Collect implements prometheus.Collector. On storage error metrics are not reported.
+func (c *StorageCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), _storageStatsTimeout)
+	defer cancel()
+
+	stats, err := c.stg.GetStats(ctx)
+	if err != nil {
+		c.logger.Errorf("metrics storage stats error: %v", err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.usersDesc, prometheus.GaugeValue, float64(stats.Users))
+	ch <- prometheus.MustNewConstMetric(c.secretsDesc, prometheus.GaugeValue, float64(stats.Secrets))
+}
@@ -3,46 +3,161 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"time"
 
+	"github.com/devldavydov/gophkeeper/internal/common/nettools"
+	gkTLS "github.com/devldavydov/gophkeeper/internal/common/tls"
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
+	"github.com/devldavydov/gophkeeper/internal/grpc/interceptor"
+	"github.com/devldavydov/gophkeeper/internal/server/health"
+	"github.com/devldavydov/gophkeeper/internal/server/metrics"
 	"github.com/devldavydov/gophkeeper/internal/server/storage"
+	"github.com/devldavydov/gophkeeper/internal/server/watch"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/sirupsen/logrus"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+const (
+	_metricsReadHeaderTimeout = 10 * time.Second
+	_tracingServiceName       = "gophkeeper-server"
 )
 
 // Service represents GophKeeper server.
 type Service struct {
 	settings *ServiceSettings
+	reloadCh chan *ServiceSettings
 	logger   *logrus.Logger
 }
 
 // NewService creates new Service object.
 func NewService(settings *ServiceSettings, logger *logrus.Logger) *Service {
-	return &Service{settings: settings, logger: logger}
+	return &Service{settings: settings, reloadCh: make(chan *ServiceSettings, 1), logger: logger}
+}
+
+// grpcStopper is an interface of gRPC server stop.
+type grpcStopper interface {
+	GracefulStop()
+	Stop()
 }
 
 // Start - starts GophKeeper server service.
+//
+// If administration service is enabled, it is started on separate listener.
+//
+// On context cancel service is stopped: health is set NOT_SERVING, background workers are canceled,
+// in-flight requests are drained within shutdown timeout and then storage is closed.
 func (s *Service) Start(ctx context.Context) error {
+	// In-flight requests are tracked first, to wait for them before storage close
+	inFlight := interceptor.NewInFlightInterceptor()
+	interceptors := []interceptor.Interceptor{inFlight}
+
+	// Tracing
+	if s.settings.Tracing != nil {
+		tp, err := tracing.Init(ctx, s.settings.Tracing, _tracingServiceName, os.Stdout, s.logger)
+		if err != nil {
+			return fmt.Errorf("failed to init tracing: %w", err)
+		}
+		defer s.stopTracing(tp)
+
+		interceptors = append(interceptors, interceptor.NewTracingInterceptor())
+	}
+
 	// Storage
-	stg, err := storage.NewPgStorage(s.settings.DatabaseDsn, s.logger)
+	stg, err := storage.NewPgStorage(s.settings.DatabaseDsn, s.settings.DefaultQuota, s.logger)
 	if err != nil {
 		return fmt.Errorf("failed to create storage: %w", err)
 	}
 	defer stg.Close()
 
-	// TLS
-	tlsCredentials, err := s.settings.GRPCServerTLS.Load()
+	// TLS, certificate is reloaded on files change
+	tlsCredentials, certReloader, err := s.settings.GRPCServerTLS.LoadReloadable(s.logger)
 	if err != nil {
 		return fmt.Errorf("failed to load TLS: %w", err)
 	}
 
+	// Secret events broker, shared between server instances via database
+	broker, err := watch.NewPgBroker(s.settings.DatabaseDsn, watch.DefaultSubscriberBuffer, s.logger)
+	if err != nil {
+		return fmt.Errorf("failed to create secret events broker: %w", err)
+	}
+
 	// gRPC server
 	listen, err := net.Listen("tcp", s.settings.GRPCAddress.String())
 	if err != nil {
+		broker.Close()
 		return fmt.Errorf("failed to setup gRPC listen: %w", err)
 	}
 
-	grpcSrv, _ := NewGrpcServer(stg, tlsCredentials, []byte(s.settings.ServerSecret), s.logger)
+	// Metrics
+	var metricsSrv *http.Server
+	if s.settings.MetricsAddress != nil {
+		srvMetrics := metrics.New()
+		if err = srvMetrics.Register(metrics.NewStorageCollector(stg, s.logger)); err != nil {
+			broker.Close()
+			return fmt.Errorf("failed to register storage metrics: %w", err)
+		}
+		if err = srvMetrics.Register(collectors.NewDBStatsCollector(stg.DB(), "gophkeeper")); err != nil {
+			broker.Close()
+			return fmt.Errorf("failed to register database metrics: %w", err)
+		}
+
+		interceptors = append(interceptors, srvMetrics)
+
+		mux := http.NewServeMux()
+		mux.Handle(metrics.Path, srvMetrics.Handler())
+		metricsSrv = &http.Server{
+			Addr:              s.settings.MetricsAddress.String(),
+			Handler:           mux,
+			ReadHeaderTimeout: _metricsReadHeaderTimeout,
+		}
+	}
+
+	grpcSrv, _ := NewGrpcServer(
+		stg,
+		broker,
+		tlsCredentials,
+		[]byte(s.settings.ServerSecret),
+		s.settings.BindClientCert,
+		s.logger,
+		interceptors...,
+	)
+	grpcSrvs := []grpcStopper{grpcSrv}
+
+	// Administration service, shares TLS certificate and interceptors with gRPC server
+	var adminSrv *grpc.Server
+	var adminListen net.Listener
+	if s.settings.Admin != nil {
+		adminSrv, adminListen, err = s.newAdminServer(stg, certReloader, interceptors)
+		if err != nil {
+			broker.Close()
+			listen.Close()
+			return err
+		}
+		grpcSrvs = append(grpcSrvs, adminSrv)
+	}
+
+	// Health, not protected by token to be available for probes
+	checker := health.NewChecker(stg, health.DefaultCheckInterval, s.logger)
+	checker.Register(grpcSrv)
+
+	checkCtx, checkCancel := context.WithCancel(ctx)
+	defer checkCancel()
+	go checker.Run(checkCtx)
+	go certReloader.Watch(checkCtx, gkTLS.DefaultCertCheckInterval)
+
+	if s.settings.Reflection {
+		reflection.Register(grpcSrv)
+	}
 
 	errChan := make(chan error)
 	go func(ch chan error) {
@@ -50,15 +165,212 @@ func (s *Service) Start(ctx context.Context) error {
 		ch <- grpcSrv.Serve(listen)
 	}(errChan)
 
+	adminErrChan := make(chan error, 1)
+	if adminSrv != nil {
+		go func(ch chan error) {
+			s.logger.Infof("admin service started on [%s]", s.settings.Admin.Address.String())
+			ch <- adminSrv.Serve(adminListen)
+		}(adminErrChan)
+	}
+
+	metricsErrChan := make(chan error, 1)
+	if metricsSrv != nil {
+		go func(ch chan error) {
+			s.logger.Infof("metrics service started on [%s]", metricsSrv.Addr)
+			ch <- metricsSrv.ListenAndServe()
+		}(metricsErrChan)
+	}
+
+	for {
+		select {
+		case settings := <-s.reloadCh:
+			s.applyReload(settings, certReloader)
+		case err = <-errChan:
+			s.stopMetrics(metricsSrv)
+			broker.Close()
+			stopGrpcNow(grpcSrvs)
+			return fmt.Errorf("gRPC service exited with err: %w", err)
+		case err = <-adminErrChan:
+			s.stopMetrics(metricsSrv)
+			broker.Close()
+			stopGrpcNow(grpcSrvs)
+			return fmt.Errorf("admin service exited with err: %w", err)
+		case err = <-metricsErrChan:
+			broker.Close()
+			stopGrpcNow(grpcSrvs)
+			return fmt.Errorf("metrics service exited with err: %w", err)
+		case <-ctx.Done():
+			s.logger.Infof("gRPC service context canceled")
+
+			// Report NOT_SERVING to probes, while in-flight requests finish
+			checker.Shutdown()
+			checkCancel()
+
+			// Interrupt watch streams, otherwise graceful stop waits for them
+			broker.Close()
+			s.stopGrpc(inFlight, grpcSrvs...)
+			s.stopMetrics(metricsSrv)
+
+			s.logger.Info("gRPC service finished")
+			return nil
+		}
+	}
+}
+
+// Reload requests to apply reloadable settings of running service: TLS certificate files are re-read,
+// shutdown timeout is updated. Changes of other settings require restart and are only reported.
+// If previous reload is pending, settings are ignored.
+func (s *Service) Reload(settings *ServiceSettings) {
+	select {
+	case s.reloadCh <- settings:
+	default:
+		s.logger.Warn("service reload is already pending, skipped")
+	}
+}
+
+// applyReload applies reloadable settings, errors are logged and current settings are kept.
+func (s *Service) applyReload(settings *ServiceSettings, certReloader *gkTLS.CertReloader) {
+	for _, name := range restartRequired(s.settings, settings) {
+		s.logger.Warnf("setting [%s] changed, restart required to apply", name)
+	}
+
+	if err := certReloader.SetFiles(settings.GRPCServerTLS.ServerCertPath, settings.GRPCServerTLS.ServerKeyPath); err != nil {
+		s.logger.Errorf("TLS certificate reload error: %v", err)
+	} else {
+		s.settings.GRPCServerTLS.ServerCertPath = settings.GRPCServerTLS.ServerCertPath
+		s.settings.GRPCServerTLS.ServerKeyPath = settings.GRPCServerTLS.ServerKeyPath
+	}
+
+	s.settings.ShutdownTimeout = settings.ShutdownTimeout
+	s.logger.Info("service settings reloaded")
+}
+
+// restartRequired returns names of changed settings, which are not reloadable.
+func restartRequired(cur, upd *ServiceSettings) []string {
+	fnAddress := func(a *nettools.Address) string {
+		if a == nil {
+			return ""
+		}
+		return a.String()
+	}
+
+	var names []string
+	for _, v := range []struct {
+		name    string
+		changed bool
+	}{
+		{"GRPCAddress", fnAddress(cur.GRPCAddress) != fnAddress(upd.GRPCAddress)},
+		{"GRPCServerTLS.ClientCACertPath", cur.GRPCServerTLS.ClientCACertPath != upd.GRPCServerTLS.ClientCACertPath},
+		{"GRPCServerTLS.RequireClientCert", cur.GRPCServerTLS.RequireClientCert != upd.GRPCServerTLS.RequireClientCert},
+		{"DatabaseDsn", cur.DatabaseDsn != upd.DatabaseDsn},
+		{"ServerSecret", cur.ServerSecret != upd.ServerSecret},
+		{"MetricsAddress", fnAddress(cur.MetricsAddress) != fnAddress(upd.MetricsAddress)},
+		{"Tracing", !reflect.DeepEqual(cur.Tracing, upd.Tracing)},
+		{"Reflection", cur.Reflection != upd.Reflection},
+		{"BindClientCert", cur.BindClientCert != upd.BindClientCert},
+		{"Admin", !reflect.DeepEqual(cur.Admin, upd.Admin)},
+		{"DefaultQuota", cur.DefaultQuota != upd.DefaultQuota},
+	} {
+		if v.changed {
+			names = append(names, v.name)
+		}
+	}
+
+	return names
+}
+
+// newAdminServer creates administration gRPC server and its listener.
+// Server certificate is served by gRPC server certificate reloader.
+func (s *Service) newAdminServer(
+	stg storage.Storage,
+	certReloader *gkTLS.CertReloader,
+	interceptors []interceptor.Interceptor,
+) (*grpc.Server, net.Listener, error) {
+	adminTLS := &gkTLS.ServerSettings{ClientCACertPath: s.settings.Admin.ClientCACertPath}
+	tlsCredentials, err := adminTLS.LoadWithReloader(certReloader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load admin TLS: %w", err)
+	}
+
+	listen, err := net.Listen("tcp", s.settings.Admin.Address.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to setup admin listen: %w", err)
+	}
+
+	adminAuth := interceptor.NewAdminAuthInterceptor(
+		s.settings.Admin.Login,
+		s.settings.Admin.Password,
+		s.settings.Admin.ClientNames,
+	)
+	adminSrv, _ := NewAdminServer(stg, tlsCredentials, adminAuth, s.logger, interceptors...)
+
+	return adminSrv, listen, nil
+}
+
+// stopGrpc stops gRPC servers gracefully in parallel, waiting in-flight requests within shutdown timeout.
+// When timeout expires, servers are stopped forcibly, canceling requests in progress.
+// Returns after request handlers finish, but no longer than another shutdown timeout.
+func (s *Service) stopGrpc(inFlight *interceptor.InFlightInterceptor, grpcSrvs ...grpcStopper) {
+	stopped := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for _, grpcSrv := range grpcSrvs {
+			wg.Add(1)
+			go func(grpcSrv grpcStopper) {
+				defer wg.Done()
+				grpcSrv.GracefulStop()
+			}(grpcSrv)
+		}
+		wg.Wait()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(s.settings.ShutdownTimeout)
+	defer timer.Stop()
+
 	select {
-	case err = <-errChan:
-		return fmt.Errorf("gRPC service exited with err: %w", err)
-	case <-ctx.Done():
-		s.logger.Infof("gRPC service context canceled")
+	case <-stopped:
+		return
+	case <-timer.C:
+		s.logger.Warnf("gRPC service graceful stop timeout [%s] expired, stopping forcibly", s.settings.ShutdownTimeout)
+		stopGrpcNow(grpcSrvs)
+	}
+
+	// Stop cancels contexts of requests in progress, but does not wait for their handlers
+	ctx, cancel := context.WithTimeout(context.Background(), s.settings.ShutdownTimeout)
+	defer cancel()
+
+	if err := inFlight.Wait(ctx); err != nil {
+		s.logger.Errorf("gRPC service requests not finished after stop: %v", err)
+	}
+}
+
+// stopGrpcNow stops gRPC servers forcibly.
+func stopGrpcNow(grpcSrvs []grpcStopper) {
+	for _, grpcSrv := range grpcSrvs {
+		grpcSrv.Stop()
+	}
+}
+
+func (s *Service) stopMetrics(metricsSrv *http.Server) {
+	if metricsSrv == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.settings.ShutdownTimeout)
+	defer cancel()
+
+	if err := metricsSrv.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.logger.Errorf("metrics service shutdown error: %v", err)
+	}
+}
 
-		grpcSrv.GracefulStop()
+// stopTracing flushes not exported spans.
+func (s *Service) stopTracing(tp *sdktrace.TracerProvider) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.settings.ShutdownTimeout)
+	defer cancel()
 
-		s.logger.Info("gRPC service finished")
-		return nil
+	if err := tp.Shutdown(ctx); err != nil {
+		s.logger.Errorf("tracing shutdown error: %v", err)
 	}
 }
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/common/nettools"
+	gkTLS "github.com/devldavydov/gophkeeper/internal/common/tls"
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
+	"github.com/devldavydov/gophkeeper/internal/grpc/interceptor"
+	"github.com/devldavydov/gophkeeper/internal/server/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestServiceStopGrpc(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	for _, tt := range []struct {
+		name           string
+		timeout        time.Duration
+		handler        func(ctx context.Context)
+		expGraceful    bool
+		expHandlerDone bool
+		expMinTime     time.Duration
+		expMaxTime     time.Duration
+	}{
+		{
+			name:           "handler finished before timeout",
+			timeout:        time.Second,
+			handler:        func(ctx context.Context) { time.Sleep(100 * time.Millisecond) },
+			expGraceful:    true,
+			expHandlerDone: true,
+			expMaxTime:     time.Second,
+		},
+		{
+			name:    "handler canceled on timeout",
+			timeout: 100 * time.Millisecond,
+			handler: func(ctx context.Context) {
+				select {
+				case <-ctx.Done():
+				case <-time.After(10 * time.Second):
+				}
+			},
+			expHandlerDone: true,
+			expMinTime:     100 * time.Millisecond,
+			expMaxTime:     time.Second,
+		},
+		{
+			name:           "handler ignoring cancel is waited",
+			timeout:        100 * time.Millisecond,
+			handler:        func(ctx context.Context) { time.Sleep(150 * time.Millisecond) },
+			expHandlerDone: true,
+			expMinTime:     100 * time.Millisecond,
+			expMaxTime:     time.Second,
+		},
+		{
+			name:       "stuck handler does not hang stop",
+			timeout:    100 * time.Millisecond,
+			handler:    func(ctx context.Context) { <-release },
+			expMinTime: 200 * time.Millisecond,
+			expMaxTime: time.Second,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var handlerDone atomic.Bool
+			inFlight := interceptor.NewInFlightInterceptor()
+			grpcSrv, conn := newTestSlowServer(t, inFlight, func(ctx context.Context) {
+				tt.handler(ctx)
+				handlerDone.Store(true)
+			})
+
+			callErr := make(chan error, 1)
+			go func() {
+				callErr <- conn.Invoke(
+					context.Background(),
+					"/test.Slow/Call",
+					&healthpb.HealthCheckRequest{},
+					&healthpb.HealthCheckResponse{})
+			}()
+
+			// Wait request in progress
+			require.Eventually(t, func() bool { return inFlight.Count() == 1 }, time.Second, 10*time.Millisecond)
+
+			s := NewService(&ServiceSettings{ShutdownTimeout: tt.timeout}, logrus.New())
+			start := time.Now()
+			s.stopGrpc(inFlight, grpcSrv)
+			elapsed := time.Since(start)
+
+			assert.GreaterOrEqual(t, elapsed, tt.expMinTime)
+			assert.Less(t, elapsed, tt.expMaxTime)
+			assert.Equal(t, tt.expHandlerDone, handlerDone.Load())
+
+			if tt.expGraceful {
+				assert.NoError(t, <-callErr)
+				return
+			}
+
+			assert.Error(t, <-callErr)
+		})
+	}
+}
+
+func TestServiceStopGrpcParallel(t *testing.T) {
+	inFlight := interceptor.NewInFlightInterceptor()
+	handler := func(ctx context.Context) { time.Sleep(300 * time.Millisecond) }
+	grpcSrv1, conn1 := newTestSlowServer(t, inFlight, handler)
+	grpcSrv2, conn2 := newTestSlowServer(t, inFlight, handler)
+
+	callErr := make(chan error, 2)
+	for _, conn := range []*grpc.ClientConn{conn1, conn2} {
+		go func(conn *grpc.ClientConn) {
+			callErr <- conn.Invoke(
+				context.Background(),
+				"/test.Slow/Call",
+				&healthpb.HealthCheckRequest{},
+				&healthpb.HealthCheckResponse{})
+		}(conn)
+	}
+
+	// Wait requests in progress
+	require.Eventually(t, func() bool { return inFlight.Count() == 2 }, time.Second, 10*time.Millisecond)
+
+	s := NewService(&ServiceSettings{ShutdownTimeout: time.Second}, logrus.New())
+	start := time.Now()
+	s.stopGrpc(inFlight, grpcSrv1, grpcSrv2)
+
+	// Servers are stopped in parallel
+	assert.Less(t, time.Since(start), 550*time.Millisecond)
+	assert.NoError(t, <-callErr)
+	assert.NoError(t, <-callErr)
+}
+
+func newTestSlowServer(
+	t *testing.T,
+	inFlight *interceptor.InFlightInterceptor,
+	handler func(ctx context.Context),
+) (*grpc.Server, *grpc.ClientConn) {
+	t.Helper()
+
+	listen := bufconn.Listen(1024 * 1024)
+	grpcSrv := grpc.NewServer(
+		grpc.StreamInterceptor(inFlight.HandleStream),
+		grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+			if err := stream.RecvMsg(&healthpb.HealthCheckRequest{}); err != nil {
+				return err
+			}
+			handler(stream.Context())
+			return stream.SendMsg(&healthpb.HealthCheckResponse{})
+		}),
+	)
+	go func() { _ = grpcSrv.Serve(listen) }()
+	t.Cleanup(grpcSrv.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listen.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return grpcSrv, conn
+}
+
+func TestServiceApplyReload(t *testing.T) {
+	fnSettings := func(certPath string, shutdownTimeout time.Duration, dsn string) *ServiceSettings {
+		tlsSettings, err := gkTLS.NewServerSettings(certPath, getTLSFile("server-key.pem"), "", false)
+		require.NoError(t, err)
+		return &ServiceSettings{GRPCServerTLS: tlsSettings, DatabaseDsn: dsn, ShutdownTimeout: shutdownTimeout}
+	}
+
+	s := NewService(fnSettings(getTLSFile("server-cert.pem"), time.Second, "postgre:5432"), logrus.New())
+	certReloader, err := gkTLS.NewCertReloader(getTLSFile("server-cert.pem"), getTLSFile("server-key.pem"), logrus.New())
+	require.NoError(t, err)
+
+	// Reloadable settings are applied
+	s.applyReload(fnSettings(getTLSFile("server-cert.pem"), 5*time.Second, "postgre:5432"), certReloader)
+	assert.Equal(t, 5*time.Second, s.settings.ShutdownTimeout)
+
+	// Invalid certificate is not applied, other settings are
+	s.applyReload(fnSettings(getTLSFile("ca-key.pem"), 3*time.Second, "postgre:5433"), certReloader)
+	assert.Equal(t, 3*time.Second, s.settings.ShutdownTimeout)
+	assert.Equal(t, getTLSFile("server-cert.pem"), s.settings.GRPCServerTLS.ServerCertPath)
+	assert.Equal(t, "postgre:5432", s.settings.DatabaseDsn)
+}
+
+func TestServiceReloadPending(t *testing.T) {
+	s := NewService(&ServiceSettings{}, logrus.New())
+
+	first, second := &ServiceSettings{ShutdownTimeout: time.Second}, &ServiceSettings{ShutdownTimeout: time.Minute}
+	s.Reload(first)
+	s.Reload(second)
+
+	assert.Same(t, first, <-s.reloadCh)
+	assert.Empty(t, s.reloadCh)
+}
+
+func TestRestartRequired(t *testing.T) {
+	fnAddress := func(addr string) *nettools.Address {
+		a, err := nettools.NewAddress(addr)
+		require.NoError(t, err)
+		return a
+	}
+
+	cur := &ServiceSettings{
+		GRPCAddress:     fnAddress("127.0.0.1:8080"),
+		GRPCServerTLS:   &gkTLS.ServerSettings{ServerCertPath: "/tmp/tls.cert", ServerKeyPath: "/tmp/tls.key"},
+		DatabaseDsn:     "postgre:5432",
+		ShutdownTimeout: time.Second,
+		Tracing:         &tracing.Settings{Exporter: tracing.ExporterStdout},
+	}
+
+	upd := *cur
+	upd.GRPCServerTLS = &gkTLS.ServerSettings{ServerCertPath: "/tmp/new.cert", ServerKeyPath: "/tmp/new.key"}
+	upd.ShutdownTimeout = time.Minute
+	upd.Tracing = &tracing.Settings{Exporter: tracing.ExporterStdout}
+	assert.Empty(t, restartRequired(cur, &upd))
+
+	upd.GRPCAddress = fnAddress("127.0.0.1:9090")
+	upd.MetricsAddress = fnAddress("127.0.0.1:9091")
+	upd.GRPCServerTLS.RequireClientCert = true
+	upd.Tracing = nil
+	upd.BindClientCert = true
+	upd.Admin = &AdminSettings{Address: fnAddress("127.0.0.1:8081"), Login: "admin", Password: "secret"}
+	upd.DefaultQuota = storage.Quota{MaxSecrets: 10}
+	assert.Equal(t,
+		[]string{
+			"GRPCAddress", "GRPCServerTLS.RequireClientCert", "MetricsAddress", "Tracing", "BindClientCert", "Admin",
+			"DefaultQuota",
+		},
+		restartRequired(cur, &upd))
+}
@@ -1,10 +1,13 @@
 package server
 
 import (
+	"errors"
 	"time"
 
 	"github.com/devldavydov/gophkeeper/internal/common/nettools"
 	gkTLS "github.com/devldavydov/gophkeeper/internal/common/tls"
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
+	"github.com/devldavydov/gophkeeper/internal/server/storage"
 )
 
 // ServiceSettings represents settings for GophKeeper server.
@@ -23,6 +26,82 @@ type ServiceSettings struct {
 
 	// ShutdownTimeout - server shitdown timeout.
 	ShutdownTimeout time.Duration
+
+	// MetricsAddress - listen address of HTTP metrics endpoint. If nil, metrics are disabled.
+	MetricsAddress *nettools.Address
+
+	// Tracing - tracing exporter settings. If nil, tracing is disabled.
+	Tracing *tracing.Settings
+
+	// Reflection - enables gRPC server reflection.
+	Reflection bool
+
+	// BindClientCert - users can login only with client certificate, issued for their login.
+	BindClientCert bool
+
+	// Admin - administration service settings. If nil, administration service is disabled.
+	Admin *AdminSettings
+
+	// DefaultQuota - quota of users without own quota, set by administrator.
+	DefaultQuota storage.Quota
+}
+
+// AdminSettings represents settings for GophKeeper administration service.
+type AdminSettings struct {
+	// Address - listen address of administration service. Server TLS certificate is shared with gRPC server.
+	Address *nettools.Address
+
+	// Login - administrator login. If empty, password authentication is disabled.
+	Login string
+
+	// Password - administrator password.
+	Password string
+
+	// ClientCACertPath - CA bundle to verify administrator client certificates.
+	// If empty, client certificate authentication is disabled.
+	ClientCACertPath string
+
+	// ClientNames - subject common names or DNS/email SANs of administrator client certificates.
+	// Required with ClientCACertPath, certificates of other subjects are rejected.
+	ClientNames []string
+}
+
+// NewAdminSettings creates new AdminSettings object.
+//
+// At least one of authentication methods is required: login with password or client certificates CA
+// with allowed client names.
+func NewAdminSettings(
+	address *nettools.Address,
+	login, password, clientCACertPath string,
+	clientNames []string,
+) (*AdminSettings, error) {
+	if address == nil {
+		return nil, errors.New("invalid admin settings: address required")
+	}
+
+	if login == "" && clientCACertPath == "" {
+		return nil, errors.New("invalid admin settings: login or client CA required")
+	}
+
+	if login != "" && password == "" {
+		return nil, errors.New("invalid admin settings: password required")
+	}
+
+	if clientCACertPath != "" && len(clientNames) == 0 {
+		return nil, errors.New("invalid admin settings: client names required with client CA")
+	}
+
+	if clientCACertPath == "" && len(clientNames) > 0 {
+		return nil, errors.New("invalid admin settings: client CA required with client names")
+	}
+
+	return &AdminSettings{
+		Address:          address,
+		Login:            login,
+		Password:         password,
+		ClientCACertPath: clientCACertPath,
+		ClientNames:      clientNames,
+	}, nil
 }
 
 // NewServiceSettings creates new ServiceSettings object.
@@ -32,6 +111,12 @@ func NewServiceSettings(
 	databaseDsn string,
 	serverSecret string,
 	shutdownTimeout time.Duration,
+	metricsAddress *nettools.Address,
+	tracingSettings *tracing.Settings,
+	reflection bool,
+	bindClientCert bool,
+	admin *AdminSettings,
+	defaultQuota storage.Quota,
 ) *ServiceSettings {
 	return &ServiceSettings{
 		GRPCAddress:     grpcAddress,
@@ -39,5 +124,11 @@ func NewServiceSettings(
 		DatabaseDsn:     databaseDsn,
 		ServerSecret:    serverSecret,
 		ShutdownTimeout: shutdownTimeout,
+		MetricsAddress:  metricsAddress,
+		Tracing:         tracingSettings,
+		Reflection:      reflection,
+		BindClientCert:  bindClientCert,
+		Admin:           admin,
+		DefaultQuota:    defaultQuota,
 	}
 }
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/common/nettools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAdminSettings(t *testing.T) {
+	address, err := nettools.NewAddress("127.0.0.1:8081")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name        string
+		address     *nettools.Address
+		login       string
+		password    string
+		clientCA    string
+		clientNames []string
+		expErr      bool
+	}{
+		{name: "password authentication", address: address, login: "admin", password: "secret"},
+		{
+			name:        "certificate authentication",
+			address:     address,
+			clientCA:    "/tmp/ca.pem",
+			clientNames: []string{"operator"},
+		},
+		{
+			name:        "both authentications",
+			address:     address,
+			login:       "admin",
+			password:    "secret",
+			clientCA:    "/tmp/ca.pem",
+			clientNames: []string{"operator"},
+		},
+		{name: "no address", login: "admin", password: "secret", expErr: true},
+		{name: "no authentication", address: address, expErr: true},
+		{name: "no password", address: address, login: "admin", expErr: true},
+		{name: "no client names", address: address, clientCA: "/tmp/ca.pem", expErr: true},
+		{
+			name:        "no client CA",
+			address:     address,
+			login:       "admin",
+			password:    "secret",
+			clientNames: []string{"operator"},
+			expErr:      true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := NewAdminSettings(tt.address, tt.login, tt.password, tt.clientCA, tt.clientNames)
+			if tt.expErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, &AdminSettings{
+				Address:          tt.address,
+				Login:            tt.login,
+				Password:         tt.password,
+				ClientCACertPath: tt.clientCA,
+				ClientNames:      tt.clientNames,
+			}, settings)
+		})
+	}
+}
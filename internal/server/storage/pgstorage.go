@@ -7,8 +7,12 @@ import (
 	"time"
 
 	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/devldavydov/gophkeeper/internal/common/tracing"
 	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -17,6 +21,9 @@ const (
 	_constraintUniqueViolation pq.ErrorCode = "23505"
 	_constraintUsernameCheck   string       = "users_username_key"
 	_constraintSecretCheck     string       = "secrets_pkey"
+	_constraintShareCheck      string       = "shares_pkey"
+
+	_tracerName = "github.com/devldavydov/gophkeeper/internal/server/storage"
 )
 
 var (
@@ -27,22 +34,25 @@ var (
 	ErrNoSecrets           = errors.New("no secrets")
 	ErrSecretOutdated      = errors.New("secret outdated")
 	ErrSecretWrongVersion  = errors.New("secret wrong version")
+	ErrShareAlreadyExists  = errors.New("share already exists")
+	ErrShareNotFound       = errors.New("share not found")
 )
 
 // PgStorage is a Storage implementation for PostgreSQL database.
 type PgStorage struct {
-	db     *sql.DB
-	logger *logrus.Logger
+	db           *sql.DB
+	defaultQuota Quota
+	logger       *logrus.Logger
 }
 
-// NewPgStorage creates PgStorage new object.
-func NewPgStorage(pgConnString string, logger *logrus.Logger) (*PgStorage, error) {
+// NewPgStorage creates PgStorage new object. Default quota applies to users without own quota.
+func NewPgStorage(pgConnString string, defaultQuota Quota, logger *logrus.Logger) (*PgStorage, error) {
 	db, err := sql.Open("postgres", pgConnString)
 	if err != nil {
 		return nil, err
 	}
 
-	pgstorage := &PgStorage{db: db, logger: logger}
+	pgstorage := &PgStorage{db: db, defaultQuota: defaultQuota, logger: logger}
 
 	if err = pgstorage.init(); err != nil {
 		return nil, err
@@ -60,9 +70,12 @@ var _ Storage = (*PgStorage)(nil)
 // - ErrUserAlreadyExists - if user already exists.
 //
 // - internal PG error.
-func (pg *PgStorage) CreateUser(ctx context.Context, login, password string) (int64, error) {
+func (pg *PgStorage) CreateUser(ctx context.Context, login, password string) (_ int64, err error) {
+	ctx, span := startSpan(ctx, "CreateUser")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	var userID int64
-	err := pg.db.QueryRowContext(ctx, _sqlCreateUser, login, password).Scan(&userID)
+	err = pg.db.QueryRowContext(ctx, _sqlCreateUser, login, password).Scan(&userID)
 	if err != nil {
 		var pqErr *pq.Error
 		if !errors.As(err, &pqErr) {
@@ -86,10 +99,13 @@ func (pg *PgStorage) CreateUser(ctx context.Context, login, password string) (in
 // - ErrUserNotFound - if user not exists.
 //
 // - internal PG error.
-func (pg *PgStorage) FindUser(ctx context.Context, login string) (int64, string, error) {
+func (pg *PgStorage) FindUser(ctx context.Context, login string) (_ int64, _ string, err error) {
+	ctx, span := startSpan(ctx, "FindUser")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	var userID int64
 	var userPassword string
-	err := pg.db.QueryRowContext(ctx, _sqlFindUser, login).Scan(&userID, &userPassword)
+	err = pg.db.QueryRowContext(ctx, _sqlFindUser, login).Scan(&userID, &userPassword)
 	switch {
 	case err == sql.ErrNoRows:
 		return 0, "", ErrUserNotFound
@@ -100,15 +116,231 @@ func (pg *PgStorage) FindUser(ctx context.Context, login string) (int64, string,
 	return userID, userPassword, nil
 }
 
+// ListUsers - gets all users from storage, ordered by login.
+//
+// Returns users (empty if no users) or internal PG error.
+func (pg *PgStorage) ListUsers(ctx context.Context) (_ []UserInfo, err error) {
+	ctx, span := startSpan(ctx, "ListUsers")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	rows, err := pg.db.QueryContext(ctx, _sqlListUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]UserInfo, 0)
+
+	for rows.Next() {
+		var user UserInfo
+		if err = rows.Scan(&user.ID, &user.Login, &user.Disabled, &user.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// SetUserDisabled - disables or enables user in storage. Accepts login and disabled flag.
+//
+// Returns nil or error:
+//
+// - ErrUserNotFound - if user not exists.
+//
+// - internal PG error.
+func (pg *PgStorage) SetUserDisabled(ctx context.Context, login string, disabled bool) (err error) {
+	ctx, span := startSpan(ctx, "SetUserDisabled")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	res, err := pg.db.ExecContext(ctx, _sqlSetUserDisabled, login, disabled)
+	if err != nil {
+		return err
+	}
+
+	return checkUserAffected(res)
+}
+
+// LogoutUser - revokes all user's tokens, issued before now. Accepts login.
+//
+// Returns nil or error:
+//
+// - ErrUserNotFound - if user not exists.
+//
+// - internal PG error.
+func (pg *PgStorage) LogoutUser(ctx context.Context, login string) (err error) {
+	ctx, span := startSpan(ctx, "LogoutUser")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	res, err := pg.db.ExecContext(ctx, _sqlLogoutUser, login)
+	if err != nil {
+		return err
+	}
+
+	return checkUserAffected(res)
+}
+
+// GetUserAccess - gets user's access state from storage. Accepts userID.
+//
+// Returns access state or error:
+//
+// - ErrUserNotFound - if user not exists.
+//
+// - internal PG error.
+func (pg *PgStorage) GetUserAccess(ctx context.Context, userID int64) (_ *UserAccess, err error) {
+	ctx, span := startSpan(ctx, "GetUserAccess")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var access UserAccess
+	var validAfter sql.NullTime
+	err = pg.db.QueryRowContext(ctx, _sqlGetUserAccess, userID).Scan(&access.Disabled, &validAfter)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, ErrUserNotFound
+	case err != nil:
+		return nil, err
+	}
+	access.TokensValidAfter = validAfter.Time
+
+	return &access, nil
+}
+
+// GetUserStats - gets user's storage usage and effective quota from storage. Accepts login.
+//
+// Returns usage or error:
+//
+// - ErrUserNotFound - if user not exists.
+//
+// - internal PG error.
+func (pg *PgStorage) GetUserStats(ctx context.Context, login string) (_ *model.Usage, err error) {
+	ctx, span := startSpan(ctx, "GetUserStats")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return queryUsage(pg.db.QueryRowContext(
+		ctx, _sqlGetUserStats, login, pg.defaultQuota.MaxBytes, pg.defaultQuota.MaxSecrets))
+}
+
+// GetUserUsage - gets user's storage usage and effective quota from storage. Accepts userID.
+//
+// Returns usage or error:
+//
+// - ErrUserNotFound - if user not exists.
+//
+// - internal PG error.
+func (pg *PgStorage) GetUserUsage(ctx context.Context, userID int64) (_ *model.Usage, err error) {
+	ctx, span := startSpan(ctx, "GetUserUsage")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return queryUsage(pg.db.QueryRowContext(
+		ctx, _sqlGetUserUsage, userID, pg.defaultQuota.MaxBytes, pg.defaultQuota.MaxSecrets))
+}
+
+// SetUserQuota - sets user's own quota in storage. Nil quota resets user to default quota. Accepts login and quota.
+//
+// Returns nil or error:
+//
+// - ErrUserNotFound - if user not exists.
+//
+// - internal PG error.
+func (pg *PgStorage) SetUserQuota(ctx context.Context, login string, quota *Quota) (err error) {
+	ctx, span := startSpan(ctx, "SetUserQuota")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var maxBytes, maxSecrets sql.NullInt64
+	if quota != nil {
+		maxBytes = sql.NullInt64{Int64: quota.MaxBytes, Valid: true}
+		maxSecrets = sql.NullInt64{Int64: quota.MaxSecrets, Valid: true}
+	}
+
+	res, err := pg.db.ExecContext(ctx, _sqlSetUserQuota, login, maxBytes, maxSecrets)
+	if err != nil {
+		return err
+	}
+
+	return checkUserAffected(res)
+}
+
+// DeleteUser - deletes user with all secrets from storage. User's audit events are kept. Accepts login.
+//
+// Returns nil or error:
+//
+// - ErrUserNotFound - if user not exists.
+//
+// - internal PG error.
+func (pg *PgStorage) DeleteUser(ctx context.Context, login string) (err error) {
+	ctx, span := startSpan(ctx, "DeleteUser")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var userID int64
+	err = tx.QueryRowContext(ctx, _sqlLockUser, login).Scan(&userID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrUserNotFound
+	case err != nil:
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, _sqlDeleteAllSecrets, userID); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, _sqlDeleteAllShares, userID); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, _sqlDeleteUser, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 // CreateSecret - create user's secret in storage. Accepts userID and secret.
 //
 // Returns nil or error:
 //
+// - ErrUserNotFound - if user not exists.
+//
 // - ErrSecretAlreadyExists - if secret already exists.
 //
+// - *QuotaError - if secret exceeds user's quota, matches ErrQuotaExceeded.
+//
 // - internal PG error.
-func (pg *PgStorage) CreateSecret(ctx context.Context, userID int64, secret *model.Secret) error {
-	_, err := pg.db.ExecContext(
+func (pg *PgStorage) CreateSecret(ctx context.Context, userID int64, secret *model.Secret) (err error) {
+	ctx, span := startSpan(ctx, "CreateSecret")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	usage, err := pg.lockUsage(ctx, tx, userID)
+	if err != nil {
+		return err
+	}
+
+	secretBytes := int64(len(secret.PayloadRaw))
+	if err = checkQuota(usage, secretBytes, 1); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
 		ctx,
 		_sqlCreateSecret,
 		userID,
@@ -117,6 +349,8 @@ func (pg *PgStorage) CreateSecret(ctx context.Context, userID int64, secret *mod
 		secret.Meta,
 		secret.Version,
 		secret.PayloadRaw,
+		nullTime(secret.ExpiresAt),
+		nullDuration(secret.RotateEvery),
 	)
 	if err != nil {
 		var pqErr *pq.Error
@@ -131,7 +365,11 @@ func (pg *PgStorage) CreateSecret(ctx context.Context, userID int64, secret *mod
 		return err
 	}
 
-	return nil
+	if _, err = tx.ExecContext(ctx, _sqlAddUserUsage, userID, secretBytes, 1); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // GetSecret - gets user's secret from storage. Accepts userID and secret name.
@@ -141,30 +379,47 @@ func (pg *PgStorage) CreateSecret(ctx context.Context, userID int64, secret *mod
 // - ErrSecretNotFound - if secret not exists.
 //
 // - internal PG error.
-func (pg *PgStorage) GetSecret(ctx context.Context, userID int64, name string) (*model.Secret, error) {
+func (pg *PgStorage) GetSecret(ctx context.Context, userID int64, name string) (_ *model.Secret, err error) {
+	ctx, span := startSpan(ctx, "GetSecret")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	secret := &model.Secret{}
-	err := pg.db.
+	var expiresAt sql.NullTime
+	var rotateEvery sql.NullInt64
+	err = pg.db.
 		QueryRowContext(ctx, _sqlGetSecret, userID, name).
-		Scan(&secret.Type, &secret.Name, &secret.Meta, &secret.Version, &secret.PayloadRaw)
+		Scan(&secret.Type, &secret.Name, &secret.Meta, &secret.Version, &secret.PayloadRaw, &expiresAt, &rotateEvery)
 	switch {
 	case err == sql.ErrNoRows:
 		return nil, ErrSecretNotFound
 	case err != nil:
 		return nil, err
 	}
+	secret.ExpiresAt = expiresAt.Time
+	secret.RotateEvery = time.Duration(rotateEvery.Int64) * time.Second
 
 	return secret, nil
 }
 
-// GetAllSecrets - gets all user's secrets from storage. Accepts userID.
+// GetAllSecrets - gets user's secrets from storage. Accepts userID and due time.
+//
+// If dueBefore is not zero, only secrets expiring or due to rotation before it are returned,
+// including already expired.
 //
 // Returns secrets or error:
 //
 // - ErrNoSecrets - if no secrets exist.
 //
 // - internal PG error.
-func (pg *PgStorage) GetAllSecrets(ctx context.Context, userID int64) ([]model.SecretInfo, error) {
-	rows, err := pg.db.QueryContext(ctx, _sqlGetAllSecrets, userID)
+func (pg *PgStorage) GetAllSecrets(
+	ctx context.Context,
+	userID int64,
+	dueBefore time.Time,
+) (_ []model.SecretInfo, err error) {
+	ctx, span := startSpan(ctx, "GetAllSecrets")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	rows, err := pg.db.QueryContext(ctx, _sqlGetAllSecrets, userID, nullTime(dueBefore))
 	if err != nil {
 		return nil, err
 	}
@@ -174,10 +429,22 @@ func (pg *PgStorage) GetAllSecrets(ctx context.Context, userID int64) ([]model.S
 
 	for rows.Next() {
 		secretItem := model.SecretInfo{}
-		err = rows.Scan(&secretItem.Type, &secretItem.Name, &secretItem.Version)
+		var expiresAt sql.NullTime
+		var rotateEvery sql.NullInt64
+		err = rows.Scan(
+			&secretItem.Type,
+			&secretItem.Name,
+			&secretItem.Version,
+			&secretItem.UpdatedAt,
+			&secretItem.PayloadUpdatedAt,
+			&expiresAt,
+			&rotateEvery,
+		)
 		if err != nil {
 			return nil, err
 		}
+		secretItem.ExpiresAt = expiresAt.Time
+		secretItem.RotateEvery = time.Duration(rotateEvery.Int64) * time.Second
 		items = append(items, secretItem)
 	}
 
@@ -197,9 +464,40 @@ func (pg *PgStorage) GetAllSecrets(ctx context.Context, userID int64) ([]model.S
 // Returns nil or error:
 //
 // - internal PG error.
-func (pg *PgStorage) DeleteSecret(ctx context.Context, userID int64, name string) error {
-	_, err := pg.db.ExecContext(ctx, _sqlDeleteSecret, userID, name)
-	return err
+func (pg *PgStorage) DeleteSecret(ctx context.Context, userID int64, name string) (err error) {
+	ctx, span := startSpan(ctx, "DeleteSecret")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// Lock user before secret, same order as in create and update
+	if _, err = pg.lockUsage(ctx, tx, userID); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var secretBytes int64
+	err = tx.QueryRowContext(ctx, _sqlDeleteSecret, userID, name).Scan(&secretBytes)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, _sqlAddUserUsage, userID, -secretBytes, -1); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // DeleteSecret - deletes all user's secrets from storage. Accepts userID.
@@ -207,9 +505,34 @@ func (pg *PgStorage) DeleteSecret(ctx context.Context, userID int64, name string
 // Returns nil or error:
 //
 // - internal PG error.
-func (pg *PgStorage) DeleteAllSecrets(ctx context.Context, userID int64) error {
-	_, err := pg.db.ExecContext(ctx, _sqlDeleteAllSecrets, userID)
-	return err
+func (pg *PgStorage) DeleteAllSecrets(ctx context.Context, userID int64) (err error) {
+	ctx, span := startSpan(ctx, "DeleteAllSecrets")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = pg.lockUsage(ctx, tx, userID); err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, _sqlDeleteAllSecrets, userID); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, _sqlResetUserUsage, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // UpdateSecret - updates user's secret in storage. Accepts userIDm secret name and secret update.
@@ -224,9 +547,19 @@ func (pg *PgStorage) DeleteAllSecrets(ctx context.Context, userID int64) error {
 //
 // Correct update - when updateVersion - currentVersion = 1.
 //
+// - *QuotaError - if updated payload exceeds user's quota, matches ErrQuotaExceeded.
+//
 // - internal PG error.
-func (pg *PgStorage) UpdateSecret(ctx context.Context, userID int64, name string, update *model.SecretUpdate) error {
-	tx, err := pg.db.Begin()
+func (pg *PgStorage) UpdateSecret(
+	ctx context.Context,
+	userID int64,
+	name string,
+	update *model.SecretUpdate,
+) (err error) {
+	ctx, span := startSpan(ctx, "UpdateSecret")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	tx, err := pg.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -234,9 +567,17 @@ func (pg *PgStorage) UpdateSecret(ctx context.Context, userID int64, name string
 		_ = tx.Rollback()
 	}()
 
-	// Lock current secret
-	var curVersion int64
-	err = tx.QueryRowContext(ctx, _sqlLockSecret, userID, name).Scan(&curVersion)
+	// Lock user usage, then current secret
+	usage, err := pg.lockUsage(ctx, tx, userID)
+	switch {
+	case errors.Is(err, ErrUserNotFound):
+		return ErrSecretNotFound
+	case err != nil:
+		return err
+	}
+
+	var curVersion, curBytes int64
+	err = tx.QueryRowContext(ctx, _sqlLockSecret, userID, name).Scan(&curVersion, &curBytes)
 	switch {
 	case errors.Is(err, sql.ErrNoRows):
 		return ErrSecretNotFound
@@ -253,18 +594,272 @@ func (pg *PgStorage) UpdateSecret(ctx context.Context, userID int64, name string
 	}
 
 	// Update
-	if update.UpdatePayload {
-		_, err = tx.ExecContext(ctx, _sqlUpdateSecret, userID, name, update.Meta, update.Version, update.PayloadRaw)
-	} else {
-		_, err = tx.ExecContext(ctx, _sqlUpdateSecretWithoutPayload, userID, name, update.Meta, update.Version)
+	if !update.UpdatePayload {
+		_, err = tx.ExecContext(
+			ctx,
+			_sqlUpdateSecretWithoutPayload,
+			userID,
+			name,
+			update.Meta,
+			update.Version,
+			nullTime(update.ExpiresAt),
+			nullDuration(update.RotateEvery),
+		)
+		if err != nil {
+			return err
+		}
+
+		return tx.Commit()
 	}
+
+	deltaBytes := int64(len(update.PayloadRaw)) - curBytes
+	if err = checkQuota(usage, deltaBytes, 0); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		_sqlUpdateSecret,
+		userID,
+		name,
+		update.Meta,
+		update.Version,
+		nullTime(update.ExpiresAt),
+		nullDuration(update.RotateEvery),
+		update.PayloadRaw,
+	)
 	if err != nil {
 		return err
 	}
 
+	if _, err = tx.ExecContext(ctx, _sqlAddUserUsage, userID, deltaBytes, 0); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
+// AddAuditEvent - adds event to audit log. Audit log is append-only. Accepts event.
+//
+// Returns nil or internal PG error.
+func (pg *PgStorage) AddAuditEvent(ctx context.Context, event *model.AuditEvent) (err error) {
+	ctx, span := startSpan(ctx, "AddAuditEvent")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	_, err = pg.db.ExecContext(
+		ctx,
+		_sqlAddAuditEvent,
+		event.Type,
+		sql.NullInt64{Int64: event.UserID, Valid: event.UserID != 0},
+		event.Login,
+		event.SecretName,
+		event.PeerAddress,
+		event.UserAgent,
+		event.Outcome,
+	)
+	return err
+}
+
+// GetAuditEvents - gets user's audit events from storage, newest first.
+//
+// Accepts userID, time range and max count of events. Zero from or to time is not limited.
+//
+// Returns events (empty if no events) or internal PG error.
+func (pg *PgStorage) GetAuditEvents(
+	ctx context.Context,
+	userID int64,
+	from, to time.Time,
+	limit int,
+) (_ []model.AuditEvent, err error) {
+	ctx, span := startSpan(ctx, "GetAuditEvents")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	rows, err := pg.db.QueryContext(
+		ctx,
+		_sqlGetAuditEvents,
+		userID,
+		nullTime(from),
+		nullTime(to),
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]model.AuditEvent, 0)
+
+	for rows.Next() {
+		var event model.AuditEvent
+		var eventUserID sql.NullInt64
+		err = rows.Scan(
+			&event.Type,
+			&eventUserID,
+			&event.Login,
+			&event.SecretName,
+			&event.PeerAddress,
+			&event.UserAgent,
+			&event.Outcome,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		event.UserID = eventUserID.Int64
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// CreateShare - creates one-time share in storage. Expired shares are deleted before.
+//
+// Active share is counted in owner's usage as secret with encrypted payload size, till it is deleted.
+//
+// Returns nil or error:
+//
+// - ErrUserNotFound - if share owner not exists.
+//
+// - ErrShareAlreadyExists - if share with the same id already exists.
+//
+// - *QuotaError - if share exceeds owner's quota, matches ErrQuotaExceeded.
+//
+// - internal PG error.
+func (pg *PgStorage) CreateShare(ctx context.Context, share *model.Share) (err error) {
+	ctx, span := startSpan(ctx, "CreateShare")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	// Cleanup before lock of owner's usage, it releases usage of other users too
+	if _, err := pg.db.ExecContext(ctx, _sqlDeleteExpiredShares); err != nil {
+		return err
+	}
+
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	usage, err := pg.lockUsage(ctx, tx, share.UserID)
+	if err != nil {
+		return err
+	}
+
+	shareBytes := int64(len(share.PayloadEncrypted))
+	if err = checkQuota(usage, shareBytes, 1); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		_sqlCreateShare,
+		share.ID,
+		share.UserID,
+		share.SecretName,
+		share.Type,
+		share.PayloadEncrypted,
+		share.ViewsLeft,
+		share.ExpiresAt,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) &&
+			pqErr.Code == _constraintUniqueViolation && pqErr.Constraint == _constraintShareCheck {
+			return ErrShareAlreadyExists
+		}
+
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, _sqlAddUserUsage, share.UserID, shareBytes, 1); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RedeemShare - retrieves one-time share from storage and decrements its views.
+// Share is deleted and its size is released from owner's usage, when no views left. Accepts share id.
+//
+// Returns share with remaining views or error:
+//
+// - ErrShareNotFound - if share not exists, expired or has no views left.
+//
+// - internal PG error.
+func (pg *PgStorage) RedeemShare(ctx context.Context, id string) (_ *model.Share, err error) {
+	ctx, span := startSpan(ctx, "RedeemShare")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	tx, err := pg.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	share := &model.Share{ID: id}
+	err = tx.QueryRowContext(ctx, _sqlRedeemShare, id).Scan(
+		&share.UserID,
+		&share.SecretName,
+		&share.Type,
+		&share.PayloadEncrypted,
+		&share.ViewsLeft,
+		&share.ExpiresAt,
+	)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// Expired or already viewed share is not needed anymore
+		if _, err = tx.ExecContext(ctx, _sqlDeleteShare, id); err != nil {
+			return nil, err
+		}
+		if err = tx.Commit(); err != nil {
+			return nil, err
+		}
+		return nil, ErrShareNotFound
+	case err != nil:
+		return nil, err
+	}
+
+	if share.ViewsLeft == 0 {
+		if _, err = tx.ExecContext(ctx, _sqlDeleteShare, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return share, nil
+}
+
+// GetStats - gets users and secrets totals from storage.
+//
+// Returns stats or internal PG error.
+func (pg *PgStorage) GetStats(ctx context.Context) (_ *Stats, err error) {
+	ctx, span := startSpan(ctx, "GetStats")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	stats := &Stats{}
+	if err := pg.db.QueryRowContext(ctx, _sqlGetStats).Scan(&stats.Users, &stats.Secrets); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// DB - returns database connection pool. Used to collect pool metrics.
+func (pg *PgStorage) DB() *sql.DB {
+	return pg.db
+}
+
 // Ping - checks storage availability.
 //
 // Returns true or false in case of error.
@@ -293,7 +888,16 @@ func (pg *PgStorage) init() error {
 	ctx, cancel := context.WithTimeout(context.Background(), _databaseInitTimeout)
 	defer cancel()
 
-	for _, createTbl := range []string{_sqlCreateTableUser, _sqlCreateTableSecret} {
+	for _, createTbl := range []string{
+		_sqlCreateTableUser,
+		_sqlCreateTableSecret,
+		_sqlMigrateSecretUpdatedAt,
+		_sqlCreateTableAuditEvent,
+		_sqlMigrateUserAccess,
+		_sqlMigrateUserUsage,
+		_sqlMigrateSecretExpiration,
+		_sqlCreateTableShare,
+	} {
 		_, err := pg.db.ExecContext(ctx, createTbl)
 		if err != nil {
 			return err
@@ -302,3 +906,59 @@ func (pg *PgStorage) init() error {
 
 	return nil
 }
+
+// lockUsage locks user's row in transaction till commit.
+//
+// Returns user's usage with effective quota or ErrUserNotFound.
+func (pg *PgStorage) lockUsage(ctx context.Context, tx *sql.Tx, userID int64) (*model.Usage, error) {
+	return queryUsage(tx.QueryRowContext(
+		ctx, _sqlLockUserUsage, userID, pg.defaultQuota.MaxBytes, pg.defaultQuota.MaxSecrets))
+}
+
+// queryUsage scans user's usage and quota from query result.
+func queryUsage(row *sql.Row) (*model.Usage, error) {
+	var usage model.Usage
+	err := row.Scan(&usage.Bytes, &usage.Secrets, &usage.MaxBytes, &usage.MaxSecrets)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, ErrUserNotFound
+	case err != nil:
+		return nil, err
+	}
+
+	return &usage, nil
+}
+
+// nullTime converts time to nullable database value, zero time is NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// nullDuration converts duration to nullable database value in seconds, zero duration is NULL.
+func nullDuration(d time.Duration) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(d / time.Second), Valid: d > 0}
+}
+
+// checkUserAffected returns ErrUserNotFound if user update affected no rows.
+func checkUserAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// startSpan starts span of storage operation.
+func startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return otel.Tracer(_tracerName).Start(
+		ctx,
+		"PgStorage."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL, semconv.DBOperation(operation)),
+	)
+}
@@ -23,6 +23,98 @@ const (
 		SELECT id, password FROM users
 		WHERE username = $1
 		`
+	_sqlMigrateUserAccess = `
+		ALTER TABLE users
+		ADD COLUMN IF NOT EXISTS disabled boolean NOT NULL DEFAULT false,
+		ADD COLUMN IF NOT EXISTS tokens_valid_after timestamp with time zone;
+		`
+	_sqlListUsers = `
+		SELECT id, username, disabled, created_at
+		FROM users
+		ORDER BY username;
+		`
+	_sqlSetUserDisabled = `
+		UPDATE users
+		SET disabled = $2
+		WHERE username = $1;
+		`
+	_sqlLogoutUser = `
+		UPDATE users
+		SET tokens_valid_after = now()
+		WHERE username = $1;
+		`
+	_sqlGetUserAccess = `
+		SELECT disabled, tokens_valid_after
+		FROM users
+		WHERE id = $1;
+		`
+	_sqlMigrateUserUsage = `
+		ALTER TABLE users
+		ADD COLUMN IF NOT EXISTS quota_bytes bigint,
+		ADD COLUMN IF NOT EXISTS quota_secrets bigint;
+		DO $$
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = 'users' AND column_name = 'used_bytes'
+			) THEN
+				ALTER TABLE users
+				ADD COLUMN used_bytes bigint NOT NULL DEFAULT 0,
+				ADD COLUMN used_secrets bigint NOT NULL DEFAULT 0;
+
+				UPDATE users u
+				SET used_bytes = s.bytes, used_secrets = s.cnt
+				FROM (
+					SELECT user_id, sum(octet_length(payload_raw)) AS bytes, count(*) AS cnt
+					FROM secrets
+					GROUP BY user_id
+				) s
+				WHERE s.user_id = u.id;
+			END IF;
+		END $$;
+		`
+	_sqlGetUserStats = `
+		SELECT used_bytes, used_secrets, coalesce(quota_bytes, $2), coalesce(quota_secrets, $3)
+		FROM users
+		WHERE username = $1;
+		`
+	_sqlGetUserUsage = `
+		SELECT used_bytes, used_secrets, coalesce(quota_bytes, $2), coalesce(quota_secrets, $3)
+		FROM users
+		WHERE id = $1;
+		`
+	_sqlLockUserUsage = `
+		SELECT used_bytes, used_secrets, coalesce(quota_bytes, $2), coalesce(quota_secrets, $3)
+		FROM users
+		WHERE id = $1
+		FOR UPDATE;
+		`
+	_sqlAddUserUsage = `
+		UPDATE users
+		SET used_bytes = used_bytes + $2, used_secrets = used_secrets + $3
+		WHERE id = $1;
+		`
+	_sqlResetUserUsage = `
+		UPDATE users
+		SET
+			used_bytes = (SELECT coalesce(sum(octet_length(payload_encrypted)), 0) FROM shares WHERE user_id = $1),
+			used_secrets = (SELECT count(*) FROM shares WHERE user_id = $1)
+		WHERE id = $1;
+		`
+	_sqlSetUserQuota = `
+		UPDATE users
+		SET quota_bytes = $2, quota_secrets = $3
+		WHERE username = $1;
+		`
+	_sqlLockUser = `
+		SELECT id FROM users
+		WHERE username = $1
+		FOR UPDATE;
+		`
+	_sqlDeleteUser = `
+		DELETE FROM users
+		WHERE id = $1;
+		`
 	// Secrets.
 	_sqlCreateTableSecret = `
 		CREATE TABLE IF NOT EXISTS secrets (
@@ -32,47 +124,159 @@ const (
 			meta        text,
 			version     bigint    NOT NULL,
 			payload_raw bytea     NOT NULL,
+			updated_at  timestamp with time zone NOT NULL DEFAULT now(),
+			payload_updated_at timestamp with time zone NOT NULL DEFAULT now(),
 
 			PRIMARY KEY (user_id, name),
 			FOREIGN KEY(user_id) REFERENCES users(id)
 		);
 		`
+	_sqlMigrateSecretUpdatedAt = `
+		ALTER TABLE secrets
+		ADD COLUMN IF NOT EXISTS updated_at timestamp with time zone NOT NULL DEFAULT now(),
+		ADD COLUMN IF NOT EXISTS payload_updated_at timestamp with time zone NOT NULL DEFAULT now();
+		`
+	_sqlMigrateSecretExpiration = `
+		ALTER TABLE secrets
+		ADD COLUMN IF NOT EXISTS expires_at timestamp with time zone,
+		ADD COLUMN IF NOT EXISTS rotate_every bigint;
+		`
 	_sqlCreateSecret = `
-		INSERT INTO secrets (user_id, type, name, meta, version, payload_raw)
-		VALUES ($1, $2, $3, $4, $5, $6);
+		INSERT INTO secrets (user_id, type, name, meta, version, payload_raw, expires_at, rotate_every)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 		`
 	_sqlGetSecret = `
-		SELECT type, name, meta, version, payload_raw
+		SELECT type, name, meta, version, payload_raw, expires_at, rotate_every
 		FROM secrets
 		WHERE user_id = $1 AND name = $2;
 		`
 	_sqlGetAllSecrets = `
-		SELECT type, name, version
+		SELECT type, name, version, updated_at, payload_updated_at, expires_at, rotate_every
 		FROM secrets
 		WHERE user_id = $1
+			AND (
+				$2::timestamp with time zone IS NULL
+				OR expires_at <= $2
+				OR payload_updated_at + make_interval(secs => rotate_every) <= $2
+			)
 		ORDER BY name;
 		`
 	_sqlDeleteSecret = `
 		DELETE FROM secrets
-		WHERE user_id = $1 AND name = $2;
+		WHERE user_id = $1 AND name = $2
+		RETURNING octet_length(payload_raw);
 		`
 	_sqlDeleteAllSecrets = `
 		DELETE FROM secrets
 		WHERE user_id = $1
 		`
 	_sqlLockSecret = `
-		SELECT version FROM secrets
+		SELECT version, octet_length(payload_raw) FROM secrets
 		WHERE user_id = $1 AND name = $2
 		FOR UPDATE;
 		`
 	_sqlUpdateSecret = `
 		UPDATE secrets
-		SET meta = $3, version = $4, payload_raw = $5
+		SET meta = $3, version = $4, expires_at = $5, rotate_every = $6, payload_raw = $7,
+			updated_at = now(), payload_updated_at = now()
 		WHERE user_id = $1 AND name = $2;
 		`
 	_sqlUpdateSecretWithoutPayload = `
 	UPDATE secrets
-	SET meta = $3, version = $4
+	SET meta = $3, version = $4, expires_at = $5, rotate_every = $6, updated_at = now()
 	WHERE user_id = $1 AND name = $2;
 	`
+	// Audit.
+	_sqlCreateTableAuditEvent = `
+		CREATE TABLE IF NOT EXISTS audit_events (
+			id           bigserial                NOT NULL,
+			event_type   int                      NOT NULL,
+			user_id      bigint,
+			login        text                     NOT NULL,
+			secret_name  text                     NOT NULL,
+			peer_address text                     NOT NULL,
+			user_agent   text                     NOT NULL,
+			outcome      text                     NOT NULL,
+			created_at   timestamp with time zone NOT NULL DEFAULT now(),
+
+			PRIMARY KEY (id)
+		);
+		CREATE INDEX IF NOT EXISTS audit_events_user_id_created_at_idx ON audit_events (user_id, created_at);
+		CREATE OR REPLACE RULE audit_events_no_update AS ON UPDATE TO audit_events DO INSTEAD NOTHING;
+		CREATE OR REPLACE RULE audit_events_no_delete AS ON DELETE TO audit_events DO INSTEAD NOTHING;
+		`
+	_sqlAddAuditEvent = `
+		INSERT INTO audit_events (event_type, user_id, login, secret_name, peer_address, user_agent, outcome)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+		`
+	_sqlGetAuditEvents = `
+		SELECT event_type, user_id, login, secret_name, peer_address, user_agent, outcome, created_at
+		FROM audit_events
+		WHERE user_id = $1
+			AND ($2::timestamp with time zone IS NULL OR created_at >= $2)
+			AND ($3::timestamp with time zone IS NULL OR created_at <= $3)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $4;
+		`
+	// Shares.
+	_sqlCreateTableShare = `
+		CREATE TABLE IF NOT EXISTS shares (
+			id                text                     NOT NULL,
+			user_id           bigint                   NOT NULL,
+			secret_name       text                     NOT NULL,
+			type              int                      NOT NULL,
+			payload_encrypted bytea                    NOT NULL,
+			views_left        int                      NOT NULL,
+			expires_at        timestamp with time zone NOT NULL,
+
+			PRIMARY KEY (id),
+			FOREIGN KEY(user_id) REFERENCES users(id)
+		);
+		`
+	_sqlCreateShare = `
+		INSERT INTO shares (id, user_id, secret_name, type, payload_encrypted, views_left, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+		`
+	_sqlRedeemShare = `
+		UPDATE shares
+		SET views_left = views_left - 1
+		WHERE id = $1 AND views_left > 0 AND expires_at > now()
+		RETURNING user_id, secret_name, type, payload_encrypted, views_left, expires_at;
+		`
+	_sqlDeleteShare = `
+		WITH deleted AS (
+			DELETE FROM shares
+			WHERE id = $1
+			RETURNING user_id, octet_length(payload_encrypted) AS bytes
+		)
+		UPDATE users u
+		SET used_bytes = u.used_bytes - d.bytes, used_secrets = u.used_secrets - 1
+		FROM deleted d
+		WHERE u.id = d.user_id;
+		`
+	_sqlDeleteExpiredShares = `
+		WITH deleted AS (
+			DELETE FROM shares
+			WHERE expires_at <= now()
+			RETURNING user_id, octet_length(payload_encrypted) AS bytes
+		)
+		UPDATE users u
+		SET used_bytes = u.used_bytes - d.bytes, used_secrets = u.used_secrets - d.cnt
+		FROM (
+			SELECT user_id, sum(bytes) AS bytes, count(*) AS cnt
+			FROM deleted
+			GROUP BY user_id
+		) d
+		WHERE u.id = d.user_id;
+		`
+	_sqlDeleteAllShares = `
+		DELETE FROM shares
+		WHERE user_id = $1;
+		`
+	// Stats.
+	_sqlGetStats = `
+		SELECT
+			(SELECT count(*) FROM users),
+			(SELECT count(*) FROM secrets);
+		`
 )
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"database/sql"
 	"os"
 	"testing"
 	"time"
@@ -13,9 +14,17 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
-var logger = logrus.New() //nolint:gochecknoglobals // OK
+var (
+	logger            = logrus.New()                              //nolint:gochecknoglobals // OK
+	_testDefaultQuota = Quota{MaxBytes: 1 << 20, MaxSecrets: 100} //nolint:gochecknoglobals // OK
+)
 
 const (
 	_envTestDatabaseDsn = "TEST_DATABASE_DSN"
@@ -29,7 +38,7 @@ type PgStorageSuite struct {
 
 func (pg *PgStorageSuite) SetupSuite() {
 	var err error
-	pg.stg, err = NewPgStorage(os.Getenv(_envTestDatabaseDsn), logger)
+	pg.stg, err = NewPgStorage(os.Getenv(_envTestDatabaseDsn), _testDefaultQuota, logger)
 	require.NoError(pg.T(), err)
 }
 
@@ -44,6 +53,50 @@ func (pg *PgStorageSuite) TestPing() {
 	pg.True(pg.stg.Ping(ctx))
 }
 
+func (pg *PgStorageSuite) TestGetStats() {
+	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
+	defer cancel()
+
+	statsBefore, err := pg.stg.GetStats(ctx)
+	pg.Require().NoError(err)
+
+	userID, err := pg.stg.CreateUser(ctx, uuid.NewString(), uuid.NewString())
+	pg.Require().NoError(err)
+	pg.Require().NoError(pg.stg.CreateSecret(ctx, userID, &model.Secret{
+		Type:       model.TextSecret,
+		Name:       "test",
+		Version:    1,
+		PayloadRaw: []byte("test"),
+	}))
+
+	statsAfter, err := pg.stg.GetStats(ctx)
+	pg.Require().NoError(err)
+	pg.Equal(statsBefore.Users+1, statsAfter.Users)
+	pg.Equal(statsBefore.Secrets+1, statsAfter.Secrets)
+}
+
+func (pg *PgStorageSuite) TestTracing() {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
+	defer cancel()
+
+	ctx, parent := otel.Tracer("test").Start(ctx, "parent")
+	_, err := pg.stg.GetSecret(ctx, 0, uuid.NewString())
+	pg.ErrorIs(err, ErrSecretNotFound)
+	parent.End()
+
+	spans := sr.Ended()
+	pg.Require().Len(spans, 2)
+	pg.Equal("PgStorage.GetSecret", spans[0].Name())
+	pg.Equal(parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	pg.Contains(spans[0].Attributes(), attribute.String("db.system", "postgresql"))
+	pg.Contains(spans[0].Attributes(), attribute.String("db.operation", "GetSecret"))
+	pg.Equal(codes.Error, spans[0].Status().Code)
+	pg.Equal(ErrSecretNotFound.Error(), spans[0].Status().Description)
+}
+
 func (pg *PgStorageSuite) TestCreateUser() {
 	userName, userPassword := uuid.NewString(), uuid.NewString()
 	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
@@ -148,7 +201,7 @@ func (pg *PgStorageSuite) TestGetAllSecrets() {
 	})
 
 	pg.Run("get all secrets - no secrets", func() {
-		_, err = pg.stg.GetAllSecrets(ctx, userID)
+		_, err = pg.stg.GetAllSecrets(ctx, userID, time.Time{})
 		pg.ErrorIs(err, ErrNoSecrets)
 	})
 
@@ -167,10 +220,112 @@ func (pg *PgStorageSuite) TestGetAllSecrets() {
 
 	pg.Run("get all secrets", func() {
 		var lst []model.SecretInfo
-		lst, err = pg.stg.GetAllSecrets(ctx, userID)
+		lst, err = pg.stg.GetAllSecrets(ctx, userID, time.Time{})
 		pg.Equal(2, len(lst))
 		pg.Equal(secretName2, lst[0].Name)
 		pg.Equal(secretName1, lst[1].Name)
+		pg.False(lst[0].UpdatedAt.IsZero())
+		pg.False(lst[0].PayloadUpdatedAt.IsZero())
+	})
+}
+
+func (pg *PgStorageSuite) TestGetExpiringSecrets() {
+	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
+	defer cancel()
+
+	userID := pg.createTestUser(ctx)
+	now := time.Now()
+
+	pg.Run("create secrets", func() {
+		for _, secret := range []*model.Secret{
+			{Name: "expired", ExpiresAt: now.Add(-time.Hour)},
+			{Name: "expiring", ExpiresAt: now.Add(5 * 24 * time.Hour)},
+			{Name: "rotate", RotateEvery: 24 * time.Hour},
+			{Name: "later", ExpiresAt: now.Add(60 * 24 * time.Hour), RotateEvery: 90 * 24 * time.Hour},
+			{Name: "never"},
+		} {
+			secret.Type = model.CredsSecret
+			secret.Version = 1
+			secret.PayloadRaw = []byte("123")
+			pg.NoError(pg.stg.CreateSecret(ctx, userID, secret))
+		}
+	})
+
+	pg.Run("get expiring secrets", func() {
+		lst, err := pg.stg.GetAllSecrets(ctx, userID, now.Add(7*24*time.Hour))
+		pg.NoError(err)
+
+		var names []string
+		for _, item := range lst {
+			names = append(names, item.Name)
+		}
+		pg.Equal([]string{"expired", "expiring", "rotate"}, names)
+		pg.Equal(24*time.Hour, lst[2].RotateEvery)
+	})
+
+	pg.Run("get all secrets", func() {
+		lst, err := pg.stg.GetAllSecrets(ctx, userID, time.Time{})
+		pg.NoError(err)
+		pg.Equal(5, len(lst))
+	})
+
+	pg.Run("update expiration", func() {
+		pg.NoError(pg.stg.UpdateSecret(ctx, userID, "later", &model.SecretUpdate{
+			Version:   2,
+			ExpiresAt: now.Add(time.Hour),
+		}))
+
+		secret, err := pg.stg.GetSecret(ctx, userID, "later")
+		pg.NoError(err)
+		pg.WithinDuration(now.Add(time.Hour), secret.ExpiresAt, time.Millisecond)
+		pg.Zero(secret.RotateEvery)
+	})
+}
+
+func (pg *PgStorageSuite) TestRotationNotResetByMetaUpdate() {
+	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
+	defer cancel()
+
+	userID := pg.createTestUser(ctx)
+
+	pg.Run("create secret with overdue rotation", func() {
+		pg.NoError(pg.stg.CreateSecret(ctx, userID, &model.Secret{
+			Type:        model.CredsSecret,
+			Name:        "rotate",
+			Version:     1,
+			PayloadRaw:  []byte("123"),
+			RotateEvery: 24 * time.Hour,
+		}))
+		_, err := pg.stg.db.ExecContext(ctx,
+			"UPDATE secrets SET updated_at = $3, payload_updated_at = $3 WHERE user_id = $1 AND name = $2",
+			userID, "rotate", time.Now().Add(-48*time.Hour))
+		pg.NoError(err)
+	})
+
+	pg.Run("update meta only - rotation still due", func() {
+		pg.NoError(pg.stg.UpdateSecret(ctx, userID, "rotate", &model.SecretUpdate{
+			Meta:        "new meta",
+			Version:     2,
+			RotateEvery: 24 * time.Hour,
+		}))
+
+		lst, err := pg.stg.GetAllSecrets(ctx, userID, time.Now())
+		pg.NoError(err)
+		pg.Equal(1, len(lst))
+		pg.WithinDuration(time.Now(), lst[0].UpdatedAt, time.Minute)
+		pg.WithinDuration(time.Now().Add(-48*time.Hour), lst[0].PayloadUpdatedAt, time.Minute)
+	})
+
+	pg.Run("update payload - rotation done", func() {
+		pg.NoError(pg.stg.UpdateSecret(ctx, userID, "rotate", &model.SecretUpdate{
+			Version:       3,
+			PayloadRaw:    []byte("456"),
+			UpdatePayload: true,
+			RotateEvery:   24 * time.Hour,
+		}))
+
+		_, err := pg.stg.GetAllSecrets(ctx, userID, time.Now())
+		pg.ErrorIs(err, ErrNoSecrets)
 	})
 }
 
@@ -256,6 +411,368 @@ func (pg *PgStorageSuite) TestUpdateSecret() {
 	})
 }
 
+func (pg *PgStorageSuite) TestAuditEvents() {
+	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
+	defer cancel()
+
+	userID := pg.createTestUser(ctx)
+	start := time.Now().Add(-time.Second)
+
+	pg.Run("get empty audit events", func() {
+		events, err := pg.stg.GetAuditEvents(ctx, userID, time.Time{}, time.Time{}, 10)
+		pg.NoError(err)
+		pg.Empty(events)
+	})
+
+	expEvents := []model.AuditEvent{
+		{Type: model.AuditUserLogin, UserID: userID, PeerAddress: "127.0.0.1:1", UserAgent: "ua", Outcome: "OK"},
+		{Type: model.AuditSecretRead, UserID: userID, SecretName: "foo", PeerAddress: "127.0.0.1:1", Outcome: "NotFound"},
+		{Type: model.AuditSecretDelete, UserID: userID, SecretName: "bar", PeerAddress: "127.0.0.1:1", Outcome: "OK"},
+	}
+
+	pg.Run("add audit events", func() {
+		for i := range expEvents {
+			pg.Require().NoError(pg.stg.AddAuditEvent(ctx, &expEvents[i]))
+		}
+		// Event of unknown user
+		pg.Require().NoError(pg.stg.AddAuditEvent(ctx, &model.AuditEvent{Type: model.AuditUserLogin, Login: "foo"}))
+	})
+
+	pg.Run("get audit events", func() {
+		events, err := pg.stg.GetAuditEvents(ctx, userID, start, time.Time{}, 10)
+		pg.Require().NoError(err)
+		pg.Require().Len(events, len(expEvents))
+		for i, event := range events {
+			expEvent := expEvents[len(expEvents)-1-i]
+			pg.False(event.CreatedAt.IsZero())
+			event.CreatedAt = time.Time{}
+			pg.Equal(expEvent, event)
+		}
+	})
+
+	pg.Run("get audit events with limit", func() {
+		events, err := pg.stg.GetAuditEvents(ctx, userID, time.Time{}, time.Time{}, 1)
+		pg.Require().NoError(err)
+		pg.Require().Len(events, 1)
+		pg.Equal(model.AuditSecretDelete, events[0].Type)
+	})
+
+	pg.Run("get audit events out of time range", func() {
+		events, err := pg.stg.GetAuditEvents(ctx, userID, time.Time{}, start, 10)
+		pg.NoError(err)
+		pg.Empty(events)
+	})
+
+	pg.Run("audit events are append-only", func() {
+		_, err := pg.stg.db.ExecContext(ctx, "DELETE FROM audit_events WHERE user_id = $1", userID)
+		pg.Require().NoError(err)
+		events, err := pg.stg.GetAuditEvents(ctx, userID, time.Time{}, time.Time{}, 10)
+		pg.NoError(err)
+		pg.Len(events, len(expEvents))
+	})
+}
+
+func (pg *PgStorageSuite) TestUserAdministration() {
+	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
+	defer cancel()
+
+	userName := uuid.NewString()
+	userID, err := pg.stg.CreateUser(ctx, userName, uuid.NewString())
+	pg.Require().NoError(err)
+	_, expSecret := pg.createTestSecret(ctx, userID)
+
+	pg.Run("list users", func() {
+		users, err := pg.stg.ListUsers(ctx)
+		pg.Require().NoError(err)
+		found := false
+		for _, user := range users {
+			if user.ID == userID {
+				found = true
+				pg.Equal(userName, user.Login)
+				pg.False(user.Disabled)
+				pg.False(user.CreatedAt.IsZero())
+			}
+		}
+		pg.True(found)
+	})
+
+	pg.Run("get user stats", func() {
+		stats, err := pg.stg.GetUserStats(ctx, userName)
+		pg.Require().NoError(err)
+		pg.Equal(&model.Usage{
+			Bytes:      int64(len(expSecret.PayloadRaw)),
+			Secrets:    1,
+			MaxBytes:   _testDefaultQuota.MaxBytes,
+			MaxSecrets: _testDefaultQuota.MaxSecrets,
+		}, stats)
+	})
+
+	pg.Run("disable and enable user", func() {
+		pg.Require().NoError(pg.stg.SetUserDisabled(ctx, userName, true))
+		access, err := pg.stg.GetUserAccess(ctx, userID)
+		pg.Require().NoError(err)
+		pg.True(access.Disabled)
+		pg.True(access.TokensValidAfter.IsZero())
+
+		pg.Require().NoError(pg.stg.SetUserDisabled(ctx, userName, false))
+		access, err = pg.stg.GetUserAccess(ctx, userID)
+		pg.Require().NoError(err)
+		pg.False(access.Disabled)
+	})
+
+	pg.Run("logout user", func() {
+		pg.Require().NoError(pg.stg.LogoutUser(ctx, userName))
+		access, err := pg.stg.GetUserAccess(ctx, userID)
+		pg.Require().NoError(err)
+		pg.False(access.TokensValidAfter.IsZero())
+	})
+
+	pg.Run("delete user", func() {
+		pg.Require().NoError(pg.stg.DeleteUser(ctx, userName))
+		_, err := pg.stg.GetUserAccess(ctx, userID)
+		pg.ErrorIs(err, ErrUserNotFound)
+		_, err = pg.stg.GetAllSecrets(ctx, userID, time.Time{})
+		pg.ErrorIs(err, ErrNoSecrets)
+	})
+
+	pg.Run("not exist user", func() {
+		pg.ErrorIs(pg.stg.SetUserDisabled(ctx, userName, true), ErrUserNotFound)
+		pg.ErrorIs(pg.stg.LogoutUser(ctx, userName), ErrUserNotFound)
+		pg.ErrorIs(pg.stg.DeleteUser(ctx, userName), ErrUserNotFound)
+		pg.ErrorIs(pg.stg.SetUserQuota(ctx, userName, nil), ErrUserNotFound)
+		_, err := pg.stg.GetUserStats(ctx, userName)
+		pg.ErrorIs(err, ErrUserNotFound)
+	})
+}
+
+func (pg *PgStorageSuite) TestUserQuota() {
+	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
+	defer cancel()
+
+	userName := uuid.NewString()
+	userID, err := pg.stg.CreateUser(ctx, userName, uuid.NewString())
+	pg.Require().NoError(err)
+
+	pg.Run("default quota", func() {
+		usage, err := pg.stg.GetUserUsage(ctx, userID)
+		pg.Require().NoError(err)
+		pg.Equal(&model.Usage{MaxBytes: _testDefaultQuota.MaxBytes, MaxSecrets: _testDefaultQuota.MaxSecrets}, usage)
+	})
+
+	pg.Require().NoError(pg.stg.SetUserQuota(ctx, userName, &Quota{MaxBytes: 10, MaxSecrets: 2}))
+
+	pg.Run("usage tracked", func() {
+		pg.Require().NoError(pg.stg.CreateSecret(ctx, userID, &model.Secret{
+			Type: model.TextSecret, Name: "first", Version: 1, PayloadRaw: []byte("12345"),
+		}))
+		pg.Require().NoError(pg.stg.CreateSecret(ctx, userID, &model.Secret{
+			Type: model.TextSecret, Name: "second", Version: 1, PayloadRaw: []byte("123"),
+		}))
+
+		usage, err := pg.stg.GetUserUsage(ctx, userID)
+		pg.Require().NoError(err)
+		pg.Equal(&model.Usage{Bytes: 8, Secrets: 2, MaxBytes: 10, MaxSecrets: 2}, usage)
+	})
+
+	pg.Run("secrets quota exceeded", func() {
+		err := pg.stg.CreateSecret(ctx, userID, &model.Secret{
+			Type: model.TextSecret, Name: "third", Version: 1, PayloadRaw: []byte("1"),
+		})
+		pg.ErrorIs(err, ErrQuotaExceeded)
+
+		var quotaErr *QuotaError
+		pg.Require().ErrorAs(err, &quotaErr)
+		pg.Equal(QuotaResourceSecrets, quotaErr.Resource)
+	})
+
+	pg.Run("bytes quota exceeded on update", func() {
+		err := pg.stg.UpdateSecret(ctx, userID, "second", &model.SecretUpdate{
+			Version: 2, UpdatePayload: true, PayloadRaw: []byte("123456"),
+		})
+		pg.ErrorIs(err, ErrQuotaExceeded)
+
+		pg.Require().NoError(pg.stg.UpdateSecret(ctx, userID, "second", &model.SecretUpdate{
+			Version: 2, UpdatePayload: true, PayloadRaw: []byte("1"),
+		}))
+		usage, err := pg.stg.GetUserUsage(ctx, userID)
+		pg.Require().NoError(err)
+		pg.Equal(int64(6), usage.Bytes)
+	})
+
+	pg.Run("usage released on delete", func() {
+		pg.Require().NoError(pg.stg.DeleteSecret(ctx, userID, "first"))
+		usage, err := pg.stg.GetUserUsage(ctx, userID)
+		pg.Require().NoError(err)
+		pg.Equal(&model.Usage{Bytes: 1, Secrets: 1, MaxBytes: 10, MaxSecrets: 2}, usage)
+
+		pg.Require().NoError(pg.stg.DeleteAllSecrets(ctx, userID))
+		usage, err = pg.stg.GetUserUsage(ctx, userID)
+		pg.Require().NoError(err)
+		pg.Equal(&model.Usage{MaxBytes: 10, MaxSecrets: 2}, usage)
+	})
+
+	pg.Run("reset quota", func() {
+		pg.Require().NoError(pg.stg.SetUserQuota(ctx, userName, nil))
+		usage, err := pg.stg.GetUserStats(ctx, userName)
+		pg.Require().NoError(err)
+		pg.Equal(&model.Usage{MaxBytes: _testDefaultQuota.MaxBytes, MaxSecrets: _testDefaultQuota.MaxSecrets}, usage)
+	})
+}
+
+func (pg *PgStorageSuite) TestShares() {
+	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
+	defer cancel()
+
+	userID := pg.createTestUser(ctx)
+	newShare := func(views int32, expiresAt time.Time) *model.Share {
+		return &model.Share{
+			ID:               uuid.NewString(),
+			UserID:           userID,
+			SecretName:       "foo",
+			Type:             model.TextSecret,
+			PayloadEncrypted: []byte("encrypted"),
+			ViewsLeft:        views,
+			ExpiresAt:        expiresAt,
+		}
+	}
+
+	pg.Run("redeem not existing share", func() {
+		_, err := pg.stg.RedeemShare(ctx, uuid.NewString())
+		pg.ErrorIs(err, ErrShareNotFound)
+	})
+
+	pg.Run("create share twice", func() {
+		share := newShare(1, time.Now().Add(time.Hour))
+		pg.NoError(pg.stg.CreateShare(ctx, share))
+		pg.ErrorIs(pg.stg.CreateShare(ctx, share), ErrShareAlreadyExists)
+	})
+
+	pg.Run("redeem share till no views left", func() {
+		share := newShare(2, time.Now().Add(time.Hour))
+		pg.NoError(pg.stg.CreateShare(ctx, share))
+
+		redeemed, err := pg.stg.RedeemShare(ctx, share.ID)
+		pg.NoError(err)
+		pg.Equal(int32(1), redeemed.ViewsLeft)
+		pg.Equal(share.PayloadEncrypted, redeemed.PayloadEncrypted)
+		pg.Equal(userID, redeemed.UserID)
+
+		redeemed, err = pg.stg.RedeemShare(ctx, share.ID)
+		pg.NoError(err)
+		pg.Equal(int32(0), redeemed.ViewsLeft)
+
+		_, err = pg.stg.RedeemShare(ctx, share.ID)
+		pg.ErrorIs(err, ErrShareNotFound)
+	})
+
+	pg.Run("redeem expired share", func() {
+		share := newShare(1, time.Now().Add(-time.Second))
+		pg.NoError(pg.stg.CreateShare(ctx, share))
+
+		_, err := pg.stg.RedeemShare(ctx, share.ID)
+		pg.ErrorIs(err, ErrShareNotFound)
+	})
+}
+
+func (pg *PgStorageSuite) TestShareQuota() {
+	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
+	defer cancel()
+
+	userName := uuid.NewString()
+	userID, err := pg.stg.CreateUser(ctx, userName, uuid.NewString())
+	pg.Require().NoError(err)
+	pg.Require().NoError(pg.stg.SetUserQuota(ctx, userName, &Quota{MaxBytes: 10, MaxSecrets: 2}))
+
+	newShare := func(payload string, expiresAt time.Time) *model.Share {
+		return &model.Share{
+			ID:               uuid.NewString(),
+			UserID:           userID,
+			SecretName:       "foo",
+			Type:             model.TextSecret,
+			PayloadEncrypted: []byte(payload),
+			ViewsLeft:        1,
+			ExpiresAt:        expiresAt,
+		}
+	}
+	fnUsage := func() *model.Usage {
+		usage, err := pg.stg.GetUserUsage(ctx, userID)
+		pg.Require().NoError(err)
+		return usage
+	}
+
+	redeemed := newShare("12345", time.Now().Add(time.Hour))
+	expired := newShare("123", time.Now().Add(time.Second))
+
+	pg.Run("usage tracked", func() {
+		pg.Require().NoError(pg.stg.CreateShare(ctx, redeemed))
+		pg.Require().NoError(pg.stg.CreateShare(ctx, expired))
+		pg.Equal(&model.Usage{Bytes: 8, Secrets: 2, MaxBytes: 10, MaxSecrets: 2}, fnUsage())
+	})
+
+	pg.Run("quota exceeded", func() {
+		err := pg.stg.CreateShare(ctx, newShare("1", time.Now().Add(time.Hour)))
+		pg.ErrorIs(err, ErrQuotaExceeded)
+
+		var quotaErr *QuotaError
+		pg.Require().ErrorAs(err, &quotaErr)
+		pg.Equal(QuotaResourceSecrets, quotaErr.Resource)
+	})
+
+	pg.Run("usage released on redeem", func() {
+		_, err := pg.stg.RedeemShare(ctx, redeemed.ID)
+		pg.Require().NoError(err)
+		pg.Equal(&model.Usage{Bytes: 3, Secrets: 1, MaxBytes: 10, MaxSecrets: 2}, fnUsage())
+	})
+
+	pg.Run("usage kept on delete all secrets", func() {
+		pg.Require().NoError(pg.stg.DeleteAllSecrets(ctx, userID))
+		pg.Equal(&model.Usage{Bytes: 3, Secrets: 1, MaxBytes: 10, MaxSecrets: 2}, fnUsage())
+	})
+
+	pg.Run("usage released on expiration", func() {
+		time.Sleep(time.Until(expired.ExpiresAt))
+		pg.Require().NoError(pg.stg.CreateShare(ctx, newShare("1234567", time.Now().Add(time.Hour))))
+		pg.Equal(&model.Usage{Bytes: 7, Secrets: 1, MaxBytes: 10, MaxSecrets: 2}, fnUsage())
+	})
+}
+
+func (pg *PgStorageSuite) TestPayloadUpdatedAtNotResetByMetaUpdate() {
+	ctx, cancel := context.WithTimeout(context.Background(), _testDBTimeout)
+	defer cancel()
+
+	userID := pg.createTestUser(ctx)
+	secretName, _ := pg.createTestSecret(ctx, userID)
+
+	payloadUpdatedAt := time.Now().Add(-48 * time.Hour)
+	_, err := pg.stg.db.ExecContext(ctx,
+		"UPDATE secrets SET updated_at = $3, payload_updated_at = $3 WHERE user_id = $1 AND name = $2",
+		userID, secretName, payloadUpdatedAt)
+	pg.NoError(err)
+
+	pg.Run("update meta only", func() {
+		pg.NoError(pg.stg.UpdateSecret(ctx, userID, secretName, &model.SecretUpdate{Meta: "new meta", Version: 2}))
+
+		lst, err := pg.stg.GetAllSecrets(ctx, userID, time.Time{})
+		pg.NoError(err)
+		pg.Equal(1, len(lst))
+		pg.WithinDuration(time.Now(), lst[0].UpdatedAt, time.Minute)
+		pg.WithinDuration(payloadUpdatedAt, lst[0].PayloadUpdatedAt, time.Minute)
+	})
+
+	pg.Run("update payload", func() {
+		pg.NoError(pg.stg.UpdateSecret(ctx, userID, secretName, &model.SecretUpdate{
+			Version:       3,
+			PayloadRaw:    []byte("456"),
+			UpdatePayload: true,
+		}))
+
+		lst, err := pg.stg.GetAllSecrets(ctx, userID, time.Time{})
+		pg.NoError(err)
+		pg.Equal(1, len(lst))
+		pg.WithinDuration(time.Now(), lst[0].PayloadUpdatedAt, time.Minute)
+	})
+}
+
 func (pg *PgStorageSuite) createTestUser(ctx context.Context) int64 {
 	var userID int64
 	var err error
@@ -298,7 +815,27 @@ func TestPgStorageSuite(t *testing.T) {
 	suite.Run(t, new(PgStorageSuite))
 }
 
+func TestPgStorageSpanError(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+
+	// Nothing listens on port 1, so every query fails
+	db, err := sql.Open("postgres", "postgres://postgres@127.0.0.1:1/test?sslmode=disable&connect_timeout=1")
+	require.NoError(t, err)
+	stg := &PgStorage{db: db, logger: logger}
+	defer stg.Close()
+
+	_, err = stg.GetStats(context.Background())
+	require.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "PgStorage.GetStats", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Len(t, spans[0].Events(), 1)
+}
+
 func TestPgStorageCreateError(t *testing.T) {
-	_, err := NewPgStorage("FooBar", logger)
+	_, err := NewPgStorage("FooBar", Quota{}, logger)
 	assert.Error(t, err)
 }
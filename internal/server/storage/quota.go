@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+// Quota resources.
+const (
+	QuotaResourceBytes   = "bytes"
+	QuotaResourceSecrets = "secrets"
+)
+
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaError describes exceeded user's quota. Matches ErrQuotaExceeded with errors.Is.
+type QuotaError struct {
+	Resource  string
+	Used      int64
+	Requested int64
+	Limit     int64
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s quota exceeded: used %d, requested %d, limit %d", e.Resource, e.Used, e.Requested, e.Limit)
+}
+
+func (e *QuotaError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+// checkQuota checks that usage with added bytes and secrets fits user's quota.
+//
+// Only growth is checked, so user over quota still can delete or shrink secrets.
+func checkQuota(usage *model.Usage, addBytes, addSecrets int64) error {
+	if addSecrets > 0 && usage.MaxSecrets > 0 && usage.Secrets+addSecrets > usage.MaxSecrets {
+		return &QuotaError{
+			Resource:  QuotaResourceSecrets,
+			Used:      usage.Secrets,
+			Requested: addSecrets,
+			Limit:     usage.MaxSecrets,
+		}
+	}
+
+	if addBytes > 0 && usage.MaxBytes > 0 && usage.Bytes+addBytes > usage.MaxBytes {
+		return &QuotaError{
+			Resource:  QuotaResourceBytes,
+			Used:      usage.Bytes,
+			Requested: addBytes,
+			Limit:     usage.MaxBytes,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckQuota(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		usage      model.Usage
+		addBytes   int64
+		addSecrets int64
+		expErr     error
+	}{
+		{
+			name:       "unlimited",
+			usage:      model.Usage{Bytes: 1000, Secrets: 100},
+			addBytes:   1000,
+			addSecrets: 1,
+		},
+		{
+			name:       "fits quota",
+			usage:      model.Usage{Bytes: 10, Secrets: 1, MaxBytes: 20, MaxSecrets: 2},
+			addBytes:   10,
+			addSecrets: 1,
+		},
+		{
+			name:       "secrets exceeded",
+			usage:      model.Usage{Bytes: 10, Secrets: 2, MaxBytes: 20, MaxSecrets: 2},
+			addBytes:   5,
+			addSecrets: 1,
+			expErr:     &QuotaError{Resource: QuotaResourceSecrets, Used: 2, Requested: 1, Limit: 2},
+		},
+		{
+			name:       "bytes exceeded",
+			usage:      model.Usage{Bytes: 10, Secrets: 1, MaxBytes: 20, MaxSecrets: 2},
+			addBytes:   11,
+			addSecrets: 1,
+			expErr:     &QuotaError{Resource: QuotaResourceBytes, Used: 10, Requested: 11, Limit: 20},
+		},
+		{
+			name:     "shrink over quota",
+			usage:    model.Usage{Bytes: 30, Secrets: 3, MaxBytes: 20, MaxSecrets: 2},
+			addBytes: -5,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkQuota(&tt.usage, tt.addBytes, tt.addSecrets)
+			assert.Equal(t, tt.expErr, err)
+			if tt.expErr != nil {
+				assert.ErrorIs(t, err, ErrQuotaExceeded)
+			}
+		})
+	}
+}
@@ -3,23 +3,67 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/devldavydov/gophkeeper/internal/common/model"
 )
 
+// Stats represents storage totals.
+type Stats struct {
+	Users   int64
+	Secrets int64
+}
+
+// UserInfo represents user for administration.
+type UserInfo struct {
+	ID        int64
+	Login     string
+	Disabled  bool
+	CreatedAt time.Time
+}
+
+// Quota represents user's storage limits. Zero limit means unlimited.
+type Quota struct {
+	MaxBytes   int64
+	MaxSecrets int64
+}
+
+// UserAccess represents user's access state.
+type UserAccess struct {
+	Disabled bool
+	// Tokens issued before this time are revoked. Zero if no tokens revoked.
+	TokensValidAfter time.Time
+}
+
 // Storage is an interface to store users and secrets in persistent storage.
 type Storage interface {
 	// Create new user in storage.
 	CreateUser(ctx context.Context, login, password string) (int64, error)
 	// Find user in storage.
 	FindUser(ctx context.Context, login string) (int64, string, error)
+	// Get all users.
+	ListUsers(ctx context.Context) ([]UserInfo, error)
+	// Disable or enable user.
+	SetUserDisabled(ctx context.Context, login string, disabled bool) error
+	// Revoke all user's tokens, issued before now.
+	LogoutUser(ctx context.Context, login string) error
+	// Get user's access state.
+	GetUserAccess(ctx context.Context, userID int64) (*UserAccess, error)
+	// Get user's storage usage and quota by login.
+	GetUserStats(ctx context.Context, login string) (*model.Usage, error)
+	// Get user's storage usage and quota.
+	GetUserUsage(ctx context.Context, userID int64) (*model.Usage, error)
+	// Set user's quota. Nil quota resets user to default quota.
+	SetUserQuota(ctx context.Context, login string, quota *Quota) error
+	// Delete user with all secrets.
+	DeleteUser(ctx context.Context, login string) error
 
 	// Create user's secret.
 	CreateSecret(ctx context.Context, userID int64, secret *model.Secret) error
 	// Get user's secret.
 	GetSecret(ctx context.Context, userID int64, name string) (*model.Secret, error)
-	// Get all user's secrets.
-	GetAllSecrets(ctx context.Context, userID int64) ([]model.SecretInfo, error)
+	// Get user's secrets. If dueBefore is not zero, only secrets expiring or due to rotation before it.
+	GetAllSecrets(ctx context.Context, userID int64, dueBefore time.Time) ([]model.SecretInfo, error)
 	// Delete user's secret.
 	DeleteSecret(ctx context.Context, userID int64, name string) error
 	// Delete all user's secret.
@@ -27,6 +71,19 @@ type Storage interface {
 	// Update user's secret.
 	UpdateSecret(ctx context.Context, userID int64, name string, update *model.SecretUpdate) error
 
+	// Add event to audit log.
+	AddAuditEvent(ctx context.Context, event *model.AuditEvent) error
+	// Get user's audit events in time range, newest first.
+	GetAuditEvents(ctx context.Context, userID int64, from, to time.Time, limit int) ([]model.AuditEvent, error)
+
+	// Create one-time share.
+	CreateShare(ctx context.Context, share *model.Share) error
+	// Retrieve one-time share, decrementing its views.
+	RedeemShare(ctx context.Context, id string) (*model.Share, error)
+
+	// Get users and secrets totals.
+	GetStats(ctx context.Context) (*Stats, error)
+
 	// Check storage availability.
 	Ping(ctx context.Context) bool
 	// Close connection with storage.
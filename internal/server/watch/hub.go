@@ -0,0 +1,173 @@
+// Package watch contains publish/subscribe of user's secret change events.
+package watch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+)
+
+var ErrBrokerClosed = errors.New("broker closed")
+
+// DefaultSubscriberBuffer - default number of events, buffered for each subscriber.
+const DefaultSubscriberBuffer = 64
+
+// Event represents change of user's secret.
+type Event struct {
+	UserID int64             `json:"user_id"`
+	Secret model.SecretEvent `json:"secret"`
+}
+
+// Broker is an interface to publish secret events and subscribe on them.
+type Broker interface {
+	// Publish event to all subscribers of event user.
+	Publish(ctx context.Context, event Event) error
+	// Subscribe on user's events.
+	Subscribe(userID int64) *Subscription
+	// Close broker and all subscriptions.
+	Close()
+}
+
+// Subscription represents subscription on user's events.
+//
+// C is closed, when subscription closed, broker closed or subscriber
+// not read events fast enough (see Interrupted).
+type Subscription struct {
+	C <-chan model.SecretEvent
+
+	ch          chan model.SecretEvent
+	userID      int64
+	hub         *Hub
+	interrupted atomic.Bool
+}
+
+// Interrupted returns true, if subscription was closed by broker
+// and some events could be lost.
+func (s *Subscription) Interrupted() bool {
+	return s.interrupted.Load()
+}
+
+// Close unsubscribes from events.
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s, false)
+}
+
+// Hub is an in-memory Broker implementation for single server instance.
+type Hub struct {
+	mu         sync.Mutex
+	subs       map[int64]map[*Subscription]struct{}
+	bufferSize int
+	closed     bool
+}
+
+var _ Broker = (*Hub)(nil)
+
+// NewHub creates new Hub object.
+//
+// bufferSize is a number of events, buffered for each subscriber.
+func NewHub(bufferSize int) *Hub {
+	return &Hub{subs: make(map[int64]map[*Subscription]struct{}), bufferSize: bufferSize}
+}
+
+// Publish - sends event to all subscribers of event user.
+//
+// Subscriber with full buffer is interrupted, so it doesn't block others.
+//
+// Returns nil or ErrBrokerClosed.
+func (h *Hub) Publish(_ context.Context, event Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return ErrBrokerClosed
+	}
+
+	for sub := range h.subs[event.UserID] {
+		select {
+		case sub.ch <- event.Secret:
+		default:
+			h.remove(sub, true)
+		}
+	}
+
+	return nil
+}
+
+// Subscribe - subscribes on user's events.
+//
+// If hub is closed, subscription is returned already interrupted.
+func (h *Hub) Subscribe(userID int64) *Subscription {
+	ch := make(chan model.SecretEvent, h.bufferSize)
+	sub := &Subscription{C: ch, ch: ch, userID: userID, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		sub.interrupted.Store(true)
+		close(ch)
+		return sub
+	}
+
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[*Subscription]struct{})
+	}
+	h.subs[userID][sub] = struct{}{}
+
+	return sub
+}
+
+// Reset - interrupts all subscriptions, so subscribers resync their state.
+// Used, when events could be lost.
+func (h *Hub) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeAll()
+}
+
+// Close - interrupts all subscriptions and closes hub.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	h.removeAll()
+}
+
+func (h *Hub) unsubscribe(sub *Subscription, interrupted bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.remove(sub, interrupted)
+}
+
+func (h *Hub) removeAll() {
+	for _, userSubs := range h.subs {
+		for sub := range userSubs {
+			h.remove(sub, true)
+		}
+	}
+}
+
+// remove deletes subscription and closes its channel. Must be called under lock.
+func (h *Hub) remove(sub *Subscription, interrupted bool) {
+	userSubs, ok := h.subs[sub.userID]
+	if !ok {
+		return
+	}
+	if _, ok = userSubs[sub]; !ok {
+		return
+	}
+
+	delete(userSubs, sub)
+	if len(userSubs) == 0 {
+		delete(h.subs, sub.userID)
+	}
+
+	sub.interrupted.Store(interrupted)
+	close(sub.ch)
+}
@@ -0,0 +1,90 @@
+package watch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHubPublish(t *testing.T) {
+	hub := NewHub(DefaultSubscriberBuffer)
+	defer hub.Close()
+
+	sub1, sub2, subOther := hub.Subscribe(1), hub.Subscribe(1), hub.Subscribe(2)
+	defer sub1.Close()
+	defer sub2.Close()
+	defer subOther.Close()
+
+	event := model.SecretEvent{EventType: model.SecretCreated, Name: "foo", SecretType: model.TextSecret, Version: 1}
+	require.NoError(t, hub.Publish(context.Background(), Event{UserID: 1, Secret: event}))
+
+	assert.Equal(t, event, <-sub1.C)
+	assert.Equal(t, event, <-sub2.C)
+	assert.Len(t, subOther.C, 0)
+}
+
+func TestHubSubscriptionClose(t *testing.T) {
+	hub := NewHub(DefaultSubscriberBuffer)
+	defer hub.Close()
+
+	sub := hub.Subscribe(1)
+	sub.Close()
+	sub.Close()
+
+	_, ok := <-sub.C
+	assert.False(t, ok)
+	assert.False(t, sub.Interrupted())
+	assert.NoError(t, hub.Publish(context.Background(), Event{UserID: 1}))
+}
+
+func TestHubSlowSubscriber(t *testing.T) {
+	hub := NewHub(1)
+	defer hub.Close()
+
+	sub := hub.Subscribe(1)
+	require.NoError(t, hub.Publish(context.Background(), Event{UserID: 1, Secret: model.SecretEvent{Name: "foo"}}))
+	require.NoError(t, hub.Publish(context.Background(), Event{UserID: 1, Secret: model.SecretEvent{Name: "bar"}}))
+
+	assert.Equal(t, "foo", (<-sub.C).Name)
+	_, ok := <-sub.C
+	assert.False(t, ok)
+	assert.True(t, sub.Interrupted())
+}
+
+func TestHubReset(t *testing.T) {
+	hub := NewHub(DefaultSubscriberBuffer)
+	defer hub.Close()
+
+	sub := hub.Subscribe(1)
+	hub.Reset()
+
+	_, ok := <-sub.C
+	assert.False(t, ok)
+	assert.True(t, sub.Interrupted())
+
+	// Hub still accepts new subscribers
+	sub = hub.Subscribe(1)
+	defer sub.Close()
+	assert.False(t, sub.Interrupted())
+}
+
+func TestHubClose(t *testing.T) {
+	hub := NewHub(DefaultSubscriberBuffer)
+
+	sub := hub.Subscribe(1)
+	hub.Close()
+
+	_, ok := <-sub.C
+	assert.False(t, ok)
+	assert.True(t, sub.Interrupted())
+
+	sub = hub.Subscribe(1)
+	_, ok = <-sub.C
+	assert.False(t, ok)
+	assert.True(t, sub.Interrupted())
+
+	assert.ErrorIs(t, hub.Publish(context.Background(), Event{UserID: 1}), ErrBrokerClosed)
+}
@@ -0,0 +1,136 @@
+package watch
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	_pgChannel              = "gophkeeper_secret_events"
+	_pgMinReconnectInterval = 100 * time.Millisecond
+	_pgMaxReconnectInterval = 10 * time.Second
+	_pgPingInterval         = 90 * time.Second
+
+	_sqlNotify = `SELECT pg_notify($1, $2);`
+)
+
+// PgBroker is a Broker implementation on top of PostgreSQL LISTEN/NOTIFY.
+//
+// Events are published to database channel and delivered to subscribers
+// of all server instances, connected to the same database.
+type PgBroker struct {
+	hub      *Hub
+	db       *sql.DB
+	listener *pq.Listener
+	done     chan struct{}
+	logger   *logrus.Logger
+}
+
+var _ Broker = (*PgBroker)(nil)
+
+// NewPgBroker creates new PgBroker object and starts listening database channel.
+//
+// bufferSize is a number of events, buffered for each subscriber.
+func NewPgBroker(pgConnString string, bufferSize int, logger *logrus.Logger) (*PgBroker, error) {
+	db, err := sql.Open("postgres", pgConnString)
+	if err != nil {
+		return nil, err
+	}
+
+	listener := pq.NewListener(
+		pgConnString,
+		_pgMinReconnectInterval,
+		_pgMaxReconnectInterval,
+		func(_ pq.ListenerEventType, err error) {
+			if err != nil {
+				logger.Errorf("watch listener error: %v", err)
+			}
+		})
+	if err = listener.Listen(_pgChannel); err != nil {
+		listener.Close()
+		db.Close()
+		return nil, err
+	}
+
+	broker := &PgBroker{
+		hub:      NewHub(bufferSize),
+		db:       db,
+		listener: listener,
+		done:     make(chan struct{}),
+		logger:   logger,
+	}
+	go broker.listen()
+
+	return broker, nil
+}
+
+// Publish - sends event to database channel.
+//
+// Returns nil or internal PG error.
+func (b *PgBroker) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.db.ExecContext(ctx, _sqlNotify, _pgChannel, string(data))
+	return err
+}
+
+// Subscribe - subscribes on user's events.
+func (b *PgBroker) Subscribe(userID int64) *Subscription {
+	return b.hub.Subscribe(userID)
+}
+
+// Close - stops listening database channel and closes all subscriptions.
+func (b *PgBroker) Close() {
+	if err := b.listener.Close(); err != nil {
+		b.logger.Errorf("watch listener close err: %v", err)
+	}
+	<-b.done
+
+	b.hub.Close()
+
+	if err := b.db.Close(); err != nil {
+		b.logger.Errorf("watch database conn close err: %v", err)
+	}
+}
+
+func (b *PgBroker) listen() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(_pgPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case n, ok := <-b.listener.Notify:
+			if !ok {
+				return
+			}
+
+			// Connection was re-established, notifications could be lost
+			if n == nil {
+				b.logger.Warn("watch listener reconnected, resetting subscriptions")
+				b.hub.Reset()
+				continue
+			}
+
+			var event Event
+			if err := json.Unmarshal([]byte(n.Extra), &event); err != nil {
+				b.logger.Errorf("watch listener invalid notification: %v", err)
+				continue
+			}
+			_ = b.hub.Publish(context.Background(), event)
+		case <-ticker.C:
+			go func() {
+				_ = b.listener.Ping()
+			}()
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/devldavydov/gophkeeper/internal/common/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const _envTestDatabaseDsn = "TEST_DATABASE_DSN"
+
+func TestPgBroker(t *testing.T) {
+	dsn, ok := os.LookupEnv(_envTestDatabaseDsn)
+	if !ok {
+		t.Skip("Test environment not set")
+		return
+	}
+
+	publisher, err := NewPgBroker(dsn, DefaultSubscriberBuffer, logrus.New())
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	subscriber, err := NewPgBroker(dsn, DefaultSubscriberBuffer, logrus.New())
+	require.NoError(t, err)
+
+	sub := subscriber.Subscribe(1)
+	defer sub.Close()
+
+	event := model.SecretEvent{EventType: model.SecretUpdated, Name: "foo", Version: 2}
+	require.NoError(t, publisher.Publish(context.Background(), Event{UserID: 1, Secret: event}))
+
+	select {
+	case got := <-sub.C:
+		assert.Equal(t, event, got)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not received")
+	}
+
+	subscriber.Close()
+	_, ok = <-sub.C
+	assert.False(t, ok)
+	assert.True(t, sub.Interrupted())
+}